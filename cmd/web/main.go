@@ -1,23 +1,29 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 	"user-simple-crud/config"
 	"user-simple-crud/internal/delivery/http"
 	api "user-simple-crud/internal/delivery/http/middleware"
 	"user-simple-crud/internal/delivery/http/route"
+	"user-simple-crud/internal/gateway/notification"
 	"user-simple-crud/internal/repository"
 	services "user-simple-crud/internal/services"
 	"user-simple-crud/migration"
 	"user-simple-crud/pkg/database"
 	"user-simple-crud/pkg/httpclient"
 	"user-simple-crud/pkg/logger"
+	"user-simple-crud/pkg/oidc"
 	"user-simple-crud/pkg/server"
 	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/webauthn"
 	"user-simple-crud/pkg/xvalidator"
 )
 
@@ -45,6 +51,16 @@ var (
 func main() {
 	validate, _ := xvalidator.NewValidator()
 	conf := config.InitAppConfig(validate)
+	passwordPolicy := &xvalidator.PasswordPolicy{
+		MinLength:      conf.Password.MinLength,
+		RequireUpper:   conf.Password.RequireUpper,
+		RequireLower:   conf.Password.RequireLower,
+		RequireDigit:   conf.Password.RequireDigit,
+		RequireSymbol:  conf.Password.RequireSymbol,
+		MaxRepeated:    conf.Password.MaxRepeated,
+		RejectUserInfo: conf.Password.RejectUserInfo,
+	}
+	validate.SetPasswordPolicy(passwordPolicy)
 	logger.SetupLogger(&logger.Config{
 		AppENV:  conf.AppEnvConfig.AppEnv,
 		LogPath: conf.AppEnvConfig.LogFilePath,
@@ -58,23 +74,175 @@ func main() {
 		AllowHeaders: conf.AppEnvConfig.AllowHeaders,
 	})
 	// external
-	signaturer := signature.NewSignature(conf.AuthConfig.JwtSecretAccessToken)
+	signaturer := signature.NewSignature(initKeySet(conf), &signature.Config{
+		Issuer:         conf.AuthConfig.Issuer,
+		Audience:       conf.AuthConfig.Audience,
+		AccessTokenTTL: conf.AuthConfig.AccessTokenTTL,
+	}, initPasswordHasher(conf))
+	notifier := initNotifier(conf)
 	// repository
 	userRepository := repository.NewUserSQLRepository()
+	refreshTokenRepository := repository.NewRefreshTokenSQLRepository()
+	revocationRepository := initRevocationStore(conf)
+	userTokenRepository := repository.NewUserTokenSQLRepository()
+	mfaRepository := repository.NewMFASQLRepository()
+	loginAttemptRepository := initLoginAttemptStore(conf)
+	rateLimitRepository := initRateLimitStore(conf)
+	apiKeyRepository := repository.NewAPIKeySQLRepository()
+	oauthRepository := repository.NewOAuthSQLRepository()
+	federationRepository := repository.NewFederationSQLRepository()
+	sessionRepository := repository.NewSessionSQLRepository()
+	passwordHistoryRepository := repository.NewPasswordHistorySQLRepository()
+	requestNonceRepository := initRequestNonceStore(conf)
+	webAuthnRepository := repository.NewWebAuthnSQLRepository()
+	auditRepository := repository.NewAuditSQLRepository()
+	groupRepository := repository.NewGroupSQLRepository()
 
 	// service
-	userService := services.NewUserService(sqlClientRepo.GetDB(), userRepository, signaturer, validate)
+	tokenService := services.NewTokenService(
+		sqlClientRepo.GetDB(), userRepository, refreshTokenRepository, revocationRepository, sessionRepository,
+		signaturer, validate, conf.AuthConfig.RefreshTokenTTL, conf.AuthConfig.MaxSessionsPerUser,
+	)
+	sessionService := services.NewSessionService(sqlClientRepo.GetDB(), sessionRepository, refreshTokenRepository)
+	passwordPolicyService := services.NewPasswordPolicyService(
+		sqlClientRepo.GetDB(), passwordHistoryRepository, userTokenRepository, signaturer,
+		&services.PasswordPolicyConfig{
+			Policy:    passwordPolicy,
+			History:   conf.Password.History,
+			MaxAge:    conf.Password.MaxAge,
+			ChangeTTL: conf.Password.ChangeTTL,
+		},
+	)
+	accountService := services.NewAccountService(
+		sqlClientRepo.GetDB(), userRepository, userTokenRepository, rateLimitRepository, signaturer, tokenService,
+		passwordPolicyService, notifier, validate,
+		&services.AccountConfig{
+			VerificationTTL:          conf.AuthConfig.EmailVerificationTTL,
+			VerificationURL:          conf.AuthConfig.EmailVerificationURL,
+			VerificationMaxPerEmail:  conf.AuthConfig.EmailVerificationMaxPerEmail,
+			VerificationWindow:       conf.AuthConfig.EmailVerificationWindow,
+			PasswordResetTTL:         conf.AuthConfig.PasswordResetTTL,
+			PasswordResetURL:         conf.AuthConfig.PasswordResetURL,
+			PasswordResetMaxPerEmail: conf.AuthConfig.PasswordResetMaxPerEmail,
+			PasswordResetWindow:      conf.AuthConfig.PasswordResetWindow,
+		},
+	)
+	lockoutService := services.NewLockoutService(
+		sqlClientRepo.GetDB(), userRepository, loginAttemptRepository,
+		&services.LockoutConfig{
+			MaxAttempts:      conf.AuthConfig.LoginMaxAttempts,
+			MaxAttemptsPerIP: conf.AuthConfig.LoginMaxAttemptsIP,
+			Window:           conf.AuthConfig.LoginAttemptWindow,
+			LockoutDuration:  conf.AuthConfig.LoginLockoutDuration,
+			BackoffBase:      conf.AuthConfig.LoginBackoffBase,
+		},
+	)
+	mfaService := services.NewMFAService(
+		sqlClientRepo.GetDB(), userRepository, mfaRepository, userTokenRepository, tokenService, passwordPolicyService, lockoutService, validate,
+		&services.MFAConfig{
+			Issuer:       conf.AppName(),
+			ChallengeTTL: conf.AuthConfig.MFAChallengeTTL,
+		},
+	)
+	apiKeyService := services.NewAPIKeyService(
+		sqlClientRepo.GetDB(), userRepository, apiKeyRepository, validate,
+		conf.AuthConfig.APIKeyMaxTTL,
+	)
+	oauthService := services.NewOAuthService(
+		sqlClientRepo.GetDB(), userRepository, oauthRepository, refreshTokenRepository, signaturer, validate,
+		&services.OAuthConfig{
+			Issuer:          conf.AuthConfig.Issuer,
+			BaseURL:         conf.AuthConfig.OAuthBaseURL,
+			ConsentURL:      conf.AuthConfig.OAuthConsentURL,
+			CodeTTL:         conf.AuthConfig.OAuthCodeTTL,
+			AccessTokenTTL:  conf.AuthConfig.AccessTokenTTL,
+			RefreshTokenTTL: conf.AuthConfig.RefreshTokenTTL,
+		},
+	)
+	federationService := services.NewFederationService(
+		sqlClientRepo.GetDB(), userRepository, federationRepository, tokenService, initOIDCProviders(conf),
+		conf.Federation.StateTTL,
+	)
+	auditService := services.NewAuditService(sqlClientRepo.GetDB(), auditRepository)
+	userService := services.NewUserService(
+		sqlClientRepo.GetDB(), userRepository, signaturer, tokenService, accountService, mfaService, lockoutService,
+		passwordPolicyService, auditService, validate,
+		conf.AuthConfig.BootstrapAdmins, conf.AuthConfig.RequireVerifiedEmail,
+	)
+	groupService := services.NewGroupService(
+		sqlClientRepo.GetDB(), groupRepository, userRepository, auditService, validate,
+	)
+	magicLinkService := services.NewMagicLinkService(
+		sqlClientRepo.GetDB(), userRepository, userTokenRepository, rateLimitRepository, tokenService, mfaService,
+		lockoutService, notifier, validate,
+		&services.MagicLinkConfig{
+			TTL:         conf.AuthConfig.MagicLinkTTL,
+			URL:         conf.AuthConfig.MagicLinkURL,
+			MaxPerEmail: conf.AuthConfig.MagicLinkMaxPerEmail,
+			Window:      conf.AuthConfig.MagicLinkWindow,
+		},
+	)
+	webAuthnService := services.NewWebAuthnService(
+		sqlClientRepo.GetDB(), userRepository, webAuthnRepository, tokenService, mfaService,
+		webauthn.NewRelyingParty(&webauthn.Config{
+			RPID:             conf.WebAuthn.RPID,
+			RPName:           conf.WebAuthn.RPName,
+			Origins:          conf.WebAuthn.Origins,
+			Timeout:          conf.WebAuthn.ChallengeTTL,
+			UserVerification: conf.WebAuthn.UserVerification,
+		}),
+		validate, conf.WebAuthn.ChallengeTTL,
+	)
+	impersonationService := services.NewImpersonationService(
+		sqlClientRepo.GetDB(), userRepository, signaturer, conf.AuthConfig.ImpersonationTTL,
+	)
+	requestSignatureService := services.NewRequestSignatureService(
+		requestNonceRepository, validate, initSignatureClients(conf),
+	)
 	// Handler
-	authMiddleware := api.NewAuthMiddleware(signaturer)
+	authMiddleware := api.NewAuthMiddleware(tokenService, apiKeyService)
+	signatureMiddleware := api.NewSignatureMiddleware(requestSignatureService)
 	userHandler := http.NewUserHTTPHandler(userService)
+	authHandler := http.NewAuthHTTPHandler(tokenService)
+	accountHandler := http.NewAccountHTTPHandler(accountService)
+	mfaHandler := http.NewMFAHTTPHandler(mfaService)
+	lockoutHandler := http.NewLockoutHTTPHandler(lockoutService)
+	apiKeyHandler := http.NewAPIKeyHTTPHandler(apiKeyService)
+	oauthHandler := http.NewOAuthHTTPHandler(oauthService)
+	federationHandler := http.NewFederationHTTPHandler(federationService)
+	sessionHandler := http.NewSessionHTTPHandler(sessionService)
+	magicLinkHandler := http.NewMagicLinkHTTPHandler(magicLinkService)
+	impersonationHandler := http.NewImpersonationHTTPHandler(impersonationService)
+	signatureHandler := http.NewSignatureHTTPHandler(requestSignatureService)
+	webAuthnHandler := http.NewWebAuthnHTTPHandler(webAuthnService)
+	auditHandler := http.NewAuditHTTPHandler(auditService)
+	groupHandler := http.NewGroupHTTPHandler(groupService)
+	wellKnownHandler := http.NewWellKnownHTTPHandler(signaturer)
 
 	router := route.Router{
-		App:            ginServer.App,
-		UserHandler:    userHandler,
-		AuthMiddleware: authMiddleware,
+		App:                  ginServer.App,
+		UserHandler:          userHandler,
+		AuthHandler:          authHandler,
+		MagicLinkHandler:     magicLinkHandler,
+		AccountHandler:       accountHandler,
+		MFAHandler:           mfaHandler,
+		LockoutHandler:       lockoutHandler,
+		APIKeyHandler:        apiKeyHandler,
+		OAuthHandler:         oauthHandler,
+		FederationHandler:    federationHandler,
+		SessionHandler:       sessionHandler,
+		ImpersonationHandler: impersonationHandler,
+		SignatureHandler:     signatureHandler,
+		WebAuthnHandler:      webAuthnHandler,
+		AuditHandler:         auditHandler,
+		GroupHandler:         groupHandler,
+		WellKnown:            wellKnownHandler,
+		AuthMiddleware:       authMiddleware,
+		SignatureMiddleware:  signatureMiddleware,
 	}
 	router.Setup()
 	router.SwaggerRouter()
+	go purgeDeletedUsers(userService, conf.User)
 	echan := make(chan error)
 	go func() {
 		echan <- ginServer.Start()
@@ -87,7 +255,26 @@ func main() {
 	case <-term:
 		slog.Info("signal terminated detected")
 	case err := <-echan:
-		slog.Error("Failed to start http server", err)
+		slog.Error("Failed to start http server", "error", err.Error())
+	}
+}
+
+// purgeDeletedUsers removes users whose retention window has passed, once at
+// start and then every PurgeInterval.
+func purgeDeletedUsers(userService services.UserService, conf *config.UserConfig) {
+	if conf.DeletedRetention == 0 {
+		return
+	}
+	ticker := time.NewTicker(conf.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, exc := userService.PurgeDeleted(context.Background(), conf.DeletedRetention)
+		if exc != nil {
+			slog.Error("Failed to purge deleted users", "message", exc.Message, "error", exc.GetError())
+		} else if purged > 0 {
+			slog.Info("Purged deleted users", "count", purged)
+		}
+		<-ticker.C
 	}
 }
 
@@ -111,6 +298,115 @@ func initSQL(conf *config.Config) *database.Database {
 	return db
 }
 
+func initKeySet(conf *config.Config) *signature.KeySet {
+	files, err := conf.AuthConfig.SigningKeyFiles()
+	if err != nil {
+		slog.Error("Failed to parse signing keys", "error", err.Error())
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		return signature.NewHMACKeySet(conf.AuthConfig.JwtSecretAccessToken)
+	}
+	keys := make([]*signature.SigningKey, 0, len(files))
+	for _, file := range files {
+		key, err := signature.LoadPEMKey(file.Kid, file.Path, file.ActiveFrom)
+		if err != nil {
+			slog.Error("Failed to load signing key", "kid", file.Kid, "error", err.Error())
+			os.Exit(1)
+		}
+		keys = append(keys, key)
+	}
+	// HS256 tokens from before the switch to signing keys are only accepted when
+	// asked for, and no longer than JWT_LEGACY_HS256_UNTIL.
+	var legacySecret string
+	if conf.AuthConfig.AcceptLegacyHS256 {
+		legacySecret = conf.AuthConfig.JwtSecretAccessToken
+	}
+	keySet, err := signature.NewKeySet(keys, conf.AuthConfig.KeyRetireAfter, legacySecret, conf.AuthConfig.LegacyHS256Until)
+	if err != nil {
+		slog.Error("Failed to build signing key set", "error", err.Error())
+		os.Exit(1)
+	}
+	return keySet
+}
+
+func initPasswordHasher(conf *config.Config) signature.PasswordHasher {
+	hasher, err := signature.NewPasswordHasher(&signature.PasswordConfig{
+		Algorithm:         conf.Password.Algorithm,
+		BcryptCost:        conf.Password.BcryptCost,
+		Argon2Memory:      conf.Password.Argon2Memory,
+		Argon2Iterations:  conf.Password.Argon2Iterations,
+		Argon2Parallelism: conf.Password.Argon2Parallelism,
+	})
+	if err != nil {
+		slog.Error("Failed to configure password hashing", "error", err.Error())
+		os.Exit(1)
+	}
+	return hasher
+}
+
+func initRevocationStore(conf *config.Config) repository.TokenRevocationRepository {
+	if conf.AuthConfig.RevocationStore == "sql" {
+		return repository.NewTokenRevocationSQLRepository(sqlClientRepo.GetDB())
+	}
+	return repository.NewTokenRevocationMemoryRepository()
+}
+
+func initLoginAttemptStore(conf *config.Config) repository.LoginAttemptRepository {
+	if conf.AuthConfig.LoginAttemptStore == "sql" {
+		return repository.NewLoginAttemptSQLRepository(sqlClientRepo.GetDB())
+	}
+	return repository.NewLoginAttemptMemoryRepository()
+}
+
+// initRateLimitStore keeps request counters next to the login failure counters.
+func initRateLimitStore(conf *config.Config) repository.RateLimitRepository {
+	if conf.AuthConfig.LoginAttemptStore == "sql" {
+		return repository.NewRateLimitSQLRepository(sqlClientRepo.GetDB())
+	}
+	return repository.NewRateLimitMemoryRepository()
+}
+
+func initRequestNonceStore(conf *config.Config) repository.RequestNonceRepository {
+	if conf.Signature.NonceStore == "sql" {
+		return repository.NewRequestNonceSQLRepository(sqlClientRepo.GetDB())
+	}
+	return repository.NewRequestNonceMemoryRepository()
+}
+
+func initSignatureClients(conf *config.Config) *services.RequestSignatureConfig {
+	clients := make([]*services.SignatureClient, 0, len(conf.Signature.Clients))
+	for _, client := range conf.Signature.Clients {
+		clients = append(clients, &services.SignatureClient{
+			ID:      client.ID,
+			Secret:  client.Secret,
+			Sandbox: client.Sandbox,
+		})
+	}
+	return &services.RequestSignatureConfig{Clients: clients, MaxSkew: conf.Signature.MaxSkew}
+}
+
+func initNotifier(conf *config.Config) notification.Notifier {
+	if conf.Notification.Driver == "smtp" {
+		return notification.NewSMTPNotifier(conf.Notification)
+	}
+	return notification.NewLogNotifier()
+}
+
+func initOIDCProviders(conf *config.Config) map[string]oidc.Provider {
+	providers := make(map[string]oidc.Provider, len(conf.Federation.Providers))
+	for _, provider := range conf.Federation.Providers {
+		providers[provider.Name] = oidc.NewProvider(&oidc.Config{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			Scopes:       provider.Scopes,
+			RedirectURL:  strings.TrimSuffix(conf.AuthConfig.OAuthBaseURL, "/") + "/auth/oidc/" + provider.Name + "/callback",
+		}, nil)
+	}
+	return providers
+}
+
 func initHttpclient() httpclient.Client {
 	httpClientFactory := httpclient.New()
 	httpClient := httpClientFactory.CreateClient()
@@ -1,15 +1,168 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Auth struct {
-	JwtSecretAccessToken string `validate:"required" name:"JWT_SECRET_ACCESS_TOKEN"`
+	JwtSecretAccessToken         string        `validate:"required_without=SigningKeys" name:"JWT_SECRET_ACCESS_TOKEN"`
+	SigningKeys                  []string      `name:"JWT_SIGNING_KEYS"`
+	AcceptLegacyHS256            bool          `name:"JWT_ACCEPT_LEGACY_HS256"`
+	LegacyHS256Until             time.Time     `validate:"required_if=AcceptLegacyHS256 true" name:"JWT_LEGACY_HS256_UNTIL"`
+	KeyRetireAfter               time.Duration `validate:"required" name:"JWT_KEY_RETIRE_AFTER"`
+	Issuer                       string        `validate:"required" name:"JWT_ISSUER"`
+	Audience                     []string      `validate:"required,min=1" name:"JWT_AUDIENCE"`
+	AccessTokenTTL               time.Duration `validate:"required" name:"JWT_ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL              time.Duration `validate:"required" name:"JWT_REFRESH_TOKEN_TTL"`
+	RevocationStore              string        `validate:"required,eq=memory|eq=sql" name:"TOKEN_REVOCATION_STORE"`
+	BootstrapAdmins              []string      `name:"RBAC_BOOTSTRAP_ADMINS"`
+	RequireVerifiedEmail         bool          `name:"AUTH_REQUIRE_VERIFIED_EMAIL"`
+	EmailVerificationTTL         time.Duration `validate:"required" name:"EMAIL_VERIFICATION_TTL"`
+	EmailVerificationURL         string        `validate:"required,url" name:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationMaxPerEmail int           `validate:"gte=0" name:"EMAIL_VERIFICATION_MAX_PER_EMAIL"`
+	EmailVerificationWindow      time.Duration `validate:"required" name:"EMAIL_VERIFICATION_WINDOW"`
+	PasswordResetTTL             time.Duration `validate:"required" name:"PASSWORD_RESET_TTL"`
+	PasswordResetURL             string        `validate:"required,url" name:"PASSWORD_RESET_URL"`
+	PasswordResetMaxPerEmail     int           `validate:"gte=0" name:"PASSWORD_RESET_MAX_PER_EMAIL"`
+	PasswordResetWindow          time.Duration `validate:"required" name:"PASSWORD_RESET_WINDOW"`
+	MFAChallengeTTL              time.Duration `validate:"required" name:"MFA_CHALLENGE_TTL"`
+	LoginAttemptStore            string        `validate:"required,eq=memory|eq=sql" name:"LOGIN_ATTEMPT_STORE"`
+	LoginMaxAttempts             int           `validate:"gte=0" name:"LOGIN_MAX_ATTEMPTS"`
+	LoginMaxAttemptsIP           int           `validate:"gte=0" name:"LOGIN_MAX_ATTEMPTS_PER_IP"`
+	LoginAttemptWindow           time.Duration `validate:"required" name:"LOGIN_ATTEMPT_WINDOW"`
+	LoginLockoutDuration         time.Duration `validate:"required" name:"LOGIN_LOCKOUT_DURATION"`
+	LoginBackoffBase             time.Duration `name:"LOGIN_BACKOFF_BASE"`
+	APIKeyMaxTTL                 time.Duration `validate:"required" name:"API_KEY_MAX_TTL"`
+	OAuthBaseURL                 string        `validate:"required,url" name:"OAUTH_BASE_URL"`
+	OAuthCodeTTL                 time.Duration `validate:"required" name:"OAUTH_CODE_TTL"`
+	OAuthConsentURL              string        `validate:"required,url" name:"OAUTH_CONSENT_URL"`
+	MaxSessionsPerUser           int           `validate:"gte=0" name:"SESSION_MAX_PER_USER"`
+	ImpersonationTTL             time.Duration `validate:"required,ltefield=AccessTokenTTL" name:"IMPERSONATION_TTL"`
+	MagicLinkTTL                 time.Duration `validate:"required" name:"MAGIC_LINK_TTL"`
+	MagicLinkURL                 string        `validate:"required,url" name:"MAGIC_LINK_URL"`
+	MagicLinkMaxPerEmail         int           `validate:"gte=0" name:"MAGIC_LINK_MAX_PER_EMAIL"`
+	MagicLinkWindow              time.Duration `validate:"required" name:"MAGIC_LINK_WINDOW"`
+}
+
+// SigningKeyFile is one entry of JWT_SIGNING_KEYS, written as
+// "<kid>=<pem path>" or "<kid>=<pem path>@<RFC 3339 activation time>".
+type SigningKeyFile struct {
+	Kid        string
+	Path       string
+	ActiveFrom time.Time
 }
 
 func AuthConfig() *Auth {
+	viper.SetDefault("JWT_KEY_RETIRE_AFTER", "24h")
+	viper.SetDefault("JWT_ISSUER", "user-simple-crud")
+	viper.SetDefault("JWT_AUDIENCE", "user-simple-crud")
+	viper.SetDefault("JWT_ACCESS_TOKEN_TTL", "1h")
+	viper.SetDefault("JWT_REFRESH_TOKEN_TTL", "720h")
+	viper.SetDefault("TOKEN_REVOCATION_STORE", "memory")
+	viper.SetDefault("EMAIL_VERIFICATION_TTL", "24h")
+	viper.SetDefault("EMAIL_VERIFICATION_URL", "http://localhost:9004/auth/verify-email")
+	viper.SetDefault("EMAIL_VERIFICATION_MAX_PER_EMAIL", 3)
+	viper.SetDefault("EMAIL_VERIFICATION_WINDOW", "1h")
+	viper.SetDefault("PASSWORD_RESET_TTL", "30m")
+	viper.SetDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	viper.SetDefault("PASSWORD_RESET_MAX_PER_EMAIL", 3)
+	viper.SetDefault("PASSWORD_RESET_WINDOW", "1h")
+	viper.SetDefault("MFA_CHALLENGE_TTL", "5m")
+	viper.SetDefault("LOGIN_ATTEMPT_STORE", "memory")
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_IP", 50)
+	viper.SetDefault("LOGIN_ATTEMPT_WINDOW", "15m")
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("LOGIN_BACKOFF_BASE", "1s")
+	viper.SetDefault("API_KEY_MAX_TTL", "8760h")
+	viper.SetDefault("OAUTH_BASE_URL", "http://localhost:9004")
+	viper.SetDefault("OAUTH_CODE_TTL", "5m")
+	viper.SetDefault("OAUTH_CONSENT_URL", "http://localhost:3000/oauth/consent")
+	viper.SetDefault("SESSION_MAX_PER_USER", 0)
+	viper.SetDefault("IMPERSONATION_TTL", "15m")
+	viper.SetDefault("MAGIC_LINK_TTL", "15m")
+	viper.SetDefault("MAGIC_LINK_URL", "http://localhost:3000/magic-link")
+	viper.SetDefault("MAGIC_LINK_MAX_PER_EMAIL", 3)
+	viper.SetDefault("MAGIC_LINK_WINDOW", "1h")
 	return &Auth{
-		JwtSecretAccessToken: viper.GetString("JWT_SECRET_ACCESS_TOKEN"),
+		JwtSecretAccessToken:         viper.GetString("JWT_SECRET_ACCESS_TOKEN"),
+		SigningKeys:                  getList("JWT_SIGNING_KEYS"),
+		AcceptLegacyHS256:            viper.GetBool("JWT_ACCEPT_LEGACY_HS256"),
+		LegacyHS256Until:             viper.GetTime("JWT_LEGACY_HS256_UNTIL"),
+		KeyRetireAfter:               viper.GetDuration("JWT_KEY_RETIRE_AFTER"),
+		Issuer:                       viper.GetString("JWT_ISSUER"),
+		Audience:                     getList("JWT_AUDIENCE"),
+		AccessTokenTTL:               viper.GetDuration("JWT_ACCESS_TOKEN_TTL"),
+		RefreshTokenTTL:              viper.GetDuration("JWT_REFRESH_TOKEN_TTL"),
+		RevocationStore:              viper.GetString("TOKEN_REVOCATION_STORE"),
+		BootstrapAdmins:              getList("RBAC_BOOTSTRAP_ADMINS"),
+		RequireVerifiedEmail:         viper.GetBool("AUTH_REQUIRE_VERIFIED_EMAIL"),
+		EmailVerificationTTL:         viper.GetDuration("EMAIL_VERIFICATION_TTL"),
+		EmailVerificationURL:         viper.GetString("EMAIL_VERIFICATION_URL"),
+		EmailVerificationMaxPerEmail: viper.GetInt("EMAIL_VERIFICATION_MAX_PER_EMAIL"),
+		EmailVerificationWindow:      viper.GetDuration("EMAIL_VERIFICATION_WINDOW"),
+		PasswordResetTTL:             viper.GetDuration("PASSWORD_RESET_TTL"),
+		PasswordResetURL:             viper.GetString("PASSWORD_RESET_URL"),
+		PasswordResetMaxPerEmail:     viper.GetInt("PASSWORD_RESET_MAX_PER_EMAIL"),
+		PasswordResetWindow:          viper.GetDuration("PASSWORD_RESET_WINDOW"),
+		MFAChallengeTTL:              viper.GetDuration("MFA_CHALLENGE_TTL"),
+		LoginAttemptStore:            viper.GetString("LOGIN_ATTEMPT_STORE"),
+		LoginMaxAttempts:             viper.GetInt("LOGIN_MAX_ATTEMPTS"),
+		LoginMaxAttemptsIP:           viper.GetInt("LOGIN_MAX_ATTEMPTS_PER_IP"),
+		LoginAttemptWindow:           viper.GetDuration("LOGIN_ATTEMPT_WINDOW"),
+		LoginLockoutDuration:         viper.GetDuration("LOGIN_LOCKOUT_DURATION"),
+		LoginBackoffBase:             viper.GetDuration("LOGIN_BACKOFF_BASE"),
+		APIKeyMaxTTL:                 viper.GetDuration("API_KEY_MAX_TTL"),
+		OAuthBaseURL:                 viper.GetString("OAUTH_BASE_URL"),
+		OAuthCodeTTL:                 viper.GetDuration("OAUTH_CODE_TTL"),
+		OAuthConsentURL:              viper.GetString("OAUTH_CONSENT_URL"),
+		MaxSessionsPerUser:           viper.GetInt("SESSION_MAX_PER_USER"),
+		ImpersonationTTL:             viper.GetDuration("IMPERSONATION_TTL"),
+		MagicLinkTTL:                 viper.GetDuration("MAGIC_LINK_TTL"),
+		MagicLinkURL:                 viper.GetString("MAGIC_LINK_URL"),
+		MagicLinkMaxPerEmail:         viper.GetInt("MAGIC_LINK_MAX_PER_EMAIL"),
+		MagicLinkWindow:              viper.GetDuration("MAGIC_LINK_WINDOW"),
+	}
+}
+
+// getList reads a comma separated setting. viper.GetStringSlice splits env
+// values on whitespace only, which breaks "a,b" style lists.
+func getList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(viper.GetString(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// SigningKeyFiles parses JWT_SIGNING_KEYS. Keys without an activation time are
+// active from the zero time, so the one listed last among them signs. Only what
+// follows the last @ is taken as the activation time, and only when it is one,
+// so paths may contain @ as well.
+func (a *Auth) SigningKeyFiles() ([]SigningKeyFile, error) {
+	var files []SigningKeyFile
+	for _, entry := range a.SigningKeys {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, rest, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || rest == "" {
+			return nil, fmt.Errorf("invalid JWT_SIGNING_KEYS entry %q, expected kid=path[@activeFrom]", entry)
+		}
+		file := SigningKeyFile{Kid: kid, Path: rest}
+		if at := strings.LastIndex(rest, "@"); at > 0 {
+			if t, err := time.Parse(time.RFC3339, rest[at+1:]); err == nil {
+				file.Path, file.ActiveFrom = rest[:at], t
+			}
+		}
+		files = append(files, file)
 	}
+	return files, nil
 }
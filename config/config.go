@@ -14,6 +14,12 @@ type Config struct {
 	AppEnvConfig   *AppConfig
 	DatabaseConfig *DatabaseConfig
 	AuthConfig     *Auth
+	Notification   *NotificationConfig
+	Password       *PasswordConfig
+	Federation     *FederationConfig
+	Signature      *SignatureConfig
+	WebAuthn       *WebAuthnConfig
+	User           *UserConfig
 }
 
 func (c Config) IsStaging() bool {
@@ -59,6 +65,12 @@ func InitAppConfig(validate *xvalidator.Validator) *Config {
 		AppEnvConfig:   AppConfigInit(),
 		DatabaseConfig: DatabaseConfigConfig(),
 		AuthConfig:     AuthConfig(),
+		Notification:   NotificationConfigInit(),
+		Password:       PasswordConfigInit(),
+		Federation:     FederationConfigInit(),
+		Signature:      SignatureConfigInit(),
+		WebAuthn:       WebAuthnConfigInit(),
+		User:           UserConfigInit(),
 	}
 	errs := validate.Struct(c)
 	if errs != nil {
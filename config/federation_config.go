@@ -0,0 +1,41 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+// FederationConfig lists the OpenID Connect providers users can sign in with.
+// Each name in OIDC_PROVIDERS is configured through OIDC_<NAME>_* variables.
+type FederationConfig struct {
+	StateTTL  time.Duration         `validate:"required" name:"OIDC_STATE_TTL"`
+	Providers []*OIDCProviderConfig `validate:"dive"`
+}
+
+type OIDCProviderConfig struct {
+	Name         string   `validate:"required,alphanum,max=64" name:"OIDC_PROVIDERS"`
+	Issuer       string   `validate:"required,url" name:"OIDC_<NAME>_ISSUER"`
+	ClientID     string   `validate:"required" name:"OIDC_<NAME>_CLIENT_ID"`
+	ClientSecret string   `validate:"required" name:"OIDC_<NAME>_CLIENT_SECRET"`
+	Scopes       []string `validate:"required,min=1" name:"OIDC_<NAME>_SCOPES"`
+}
+
+func FederationConfigInit() *FederationConfig {
+	viper.SetDefault("OIDC_STATE_TTL", "10m")
+	conf := &FederationConfig{
+		StateTTL: viper.GetDuration("OIDC_STATE_TTL"),
+	}
+	for _, name := range getList("OIDC_PROVIDERS") {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		viper.SetDefault(prefix+"SCOPES", "openid,email,profile")
+		conf.Providers = append(conf.Providers, &OIDCProviderConfig{
+			Name:         strings.ToLower(name),
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			Scopes:       getList(prefix + "SCOPES"),
+		})
+	}
+	return conf
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+type NotificationConfig struct {
+	Driver       string `validate:"required,eq=log|eq=smtp" name:"NOTIFIER_DRIVER"`
+	SMTPHost     string `validate:"required_if=Driver smtp" name:"SMTP_HOST"`
+	SMTPPort     int    `validate:"required_if=Driver smtp" name:"SMTP_PORT"`
+	SMTPUsername string `name:"SMTP_USERNAME"`
+	SMTPPassword string `name:"SMTP_PASSWORD"`
+	SMTPFrom     string `validate:"required_if=Driver smtp,omitempty,email" name:"SMTP_FROM"`
+}
+
+func NotificationConfigInit() *NotificationConfig {
+	viper.SetDefault("NOTIFIER_DRIVER", "log")
+	viper.SetDefault("SMTP_PORT", 587)
+	return &NotificationConfig{
+		Driver:       viper.GetString("NOTIFIER_DRIVER"),
+		SMTPHost:     viper.GetString("SMTP_HOST"),
+		SMTPPort:     viper.GetInt("SMTP_PORT"),
+		SMTPUsername: viper.GetString("SMTP_USERNAME"),
+		SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+		SMTPFrom:     viper.GetString("SMTP_FROM"),
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type PasswordConfig struct {
+	Algorithm         string `validate:"required,eq=argon2id|eq=bcrypt" name:"PASSWORD_HASH_ALGORITHM"`
+	BcryptCost        int    `validate:"gte=4,lte=31" name:"PASSWORD_BCRYPT_COST"`
+	Argon2Memory      uint32 `validate:"gte=8" name:"PASSWORD_ARGON2_MEMORY"`
+	Argon2Iterations  uint32 `validate:"gte=1" name:"PASSWORD_ARGON2_ITERATIONS"`
+	Argon2Parallelism uint8  `validate:"gte=1" name:"PASSWORD_ARGON2_PARALLELISM"`
+	// Policy for new passwords
+	MinLength      int           `validate:"gte=1" name:"PASSWORD_MIN_LENGTH"`
+	RequireUpper   bool          `name:"PASSWORD_REQUIRE_UPPERCASE"`
+	RequireLower   bool          `name:"PASSWORD_REQUIRE_LOWERCASE"`
+	RequireDigit   bool          `name:"PASSWORD_REQUIRE_DIGIT"`
+	RequireSymbol  bool          `name:"PASSWORD_REQUIRE_SYMBOL"`
+	MaxRepeated    int           `validate:"gte=0" name:"PASSWORD_MAX_REPEATED"`
+	RejectUserInfo bool          `name:"PASSWORD_REJECT_USER_INFO"`
+	History        int           `validate:"gte=0" name:"PASSWORD_HISTORY"`
+	MaxAge         time.Duration `validate:"gte=0" name:"PASSWORD_MAX_AGE"`
+	ChangeTTL      time.Duration `validate:"required" name:"PASSWORD_CHANGE_TTL"`
+}
+
+func PasswordConfigInit() *PasswordConfig {
+	viper.SetDefault("PASSWORD_HASH_ALGORITHM", "argon2id")
+	viper.SetDefault("PASSWORD_BCRYPT_COST", 12)
+	viper.SetDefault("PASSWORD_ARGON2_MEMORY", 64*1024)
+	viper.SetDefault("PASSWORD_ARGON2_ITERATIONS", 3)
+	viper.SetDefault("PASSWORD_ARGON2_PARALLELISM", 2)
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+	viper.SetDefault("PASSWORD_REQUIRE_SYMBOL", true)
+	viper.SetDefault("PASSWORD_MAX_REPEATED", 0)
+	viper.SetDefault("PASSWORD_REJECT_USER_INFO", true)
+	viper.SetDefault("PASSWORD_HISTORY", 5)
+	viper.SetDefault("PASSWORD_MAX_AGE", "0s")
+	viper.SetDefault("PASSWORD_CHANGE_TTL", "10m")
+	return &PasswordConfig{
+		Algorithm:         viper.GetString("PASSWORD_HASH_ALGORITHM"),
+		BcryptCost:        viper.GetInt("PASSWORD_BCRYPT_COST"),
+		Argon2Memory:      viper.GetUint32("PASSWORD_ARGON2_MEMORY"),
+		Argon2Iterations:  viper.GetUint32("PASSWORD_ARGON2_ITERATIONS"),
+		Argon2Parallelism: uint8(viper.GetUint("PASSWORD_ARGON2_PARALLELISM")),
+		MinLength:         viper.GetInt("PASSWORD_MIN_LENGTH"),
+		RequireUpper:      viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+		RequireLower:      viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+		RequireDigit:      viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+		RequireSymbol:     viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+		MaxRepeated:       viper.GetInt("PASSWORD_MAX_REPEATED"),
+		RejectUserInfo:    viper.GetBool("PASSWORD_REJECT_USER_INFO"),
+		History:           viper.GetInt("PASSWORD_HISTORY"),
+		MaxAge:            viper.GetDuration("PASSWORD_MAX_AGE"),
+		ChangeTTL:         viper.GetDuration("PASSWORD_CHANGE_TTL"),
+	}
+}
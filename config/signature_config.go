@@ -0,0 +1,40 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+// SignatureConfig lists the partners that sign server-to-server requests.
+// Each name in SIGNATURE_CLIENTS is configured through SIGNATURE_<NAME>_*
+// variables.
+type SignatureConfig struct {
+	MaxSkew    time.Duration            `validate:"required" name:"SIGNATURE_MAX_SKEW"`
+	NonceStore string                   `validate:"required,eq=memory|eq=sql" name:"SIGNATURE_NONCE_STORE"`
+	Clients    []*SignatureClientConfig `validate:"dive"`
+}
+
+type SignatureClientConfig struct {
+	ID      string `validate:"required,max=64" name:"SIGNATURE_CLIENTS"`
+	Secret  string `validate:"required,min=32" name:"SIGNATURE_<NAME>_SECRET"`
+	Sandbox bool   `name:"SIGNATURE_<NAME>_SANDBOX"`
+}
+
+func SignatureConfigInit() *SignatureConfig {
+	viper.SetDefault("SIGNATURE_MAX_SKEW", "5m")
+	viper.SetDefault("SIGNATURE_NONCE_STORE", "memory")
+	conf := &SignatureConfig{
+		MaxSkew:    viper.GetDuration("SIGNATURE_MAX_SKEW"),
+		NonceStore: viper.GetString("SIGNATURE_NONCE_STORE"),
+	}
+	for _, name := range getList("SIGNATURE_CLIENTS") {
+		prefix := "SIGNATURE_" + strings.ToUpper(name) + "_"
+		conf.Clients = append(conf.Clients, &SignatureClientConfig{
+			ID:      name,
+			Secret:  viper.GetString(prefix + "SECRET"),
+			Sandbox: viper.GetBool(prefix + "SANDBOX"),
+		})
+	}
+	return conf
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// UserConfig controls how long deleted users can still be restored.
+// DeletedRetention 0 keeps them until they are restored.
+type UserConfig struct {
+	DeletedRetention time.Duration `validate:"gte=0" name:"USER_DELETED_RETENTION"`
+	PurgeInterval    time.Duration `validate:"required" name:"USER_PURGE_INTERVAL"`
+}
+
+func UserConfigInit() *UserConfig {
+	viper.SetDefault("USER_DELETED_RETENTION", "720h")
+	viper.SetDefault("USER_PURGE_INTERVAL", "1h")
+	return &UserConfig{
+		DeletedRetention: viper.GetDuration("USER_DELETED_RETENTION"),
+		PurgeInterval:    viper.GetDuration("USER_PURGE_INTERVAL"),
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"time"
+)
+
+// WebAuthnConfig describes this service as a WebAuthn relying party. RPID is
+// the domain passkeys are bound to and must be the origins' host or a parent
+// domain of it.
+type WebAuthnConfig struct {
+	RPID             string        `validate:"required,hostname" name:"WEBAUTHN_RP_ID"`
+	RPName           string        `validate:"required" name:"WEBAUTHN_RP_NAME"`
+	Origins          []string      `validate:"required,min=1,dive,url" name:"WEBAUTHN_ORIGINS"`
+	ChallengeTTL     time.Duration `validate:"required" name:"WEBAUTHN_CHALLENGE_TTL"`
+	UserVerification string        `validate:"required,oneof=required preferred discouraged" name:"WEBAUTHN_USER_VERIFICATION"`
+}
+
+func WebAuthnConfigInit() *WebAuthnConfig {
+	viper.SetDefault("WEBAUTHN_RP_ID", "localhost")
+	viper.SetDefault("WEBAUTHN_RP_NAME", "user-simple-crud")
+	viper.SetDefault("WEBAUTHN_ORIGINS", "http://localhost:3000")
+	viper.SetDefault("WEBAUTHN_CHALLENGE_TTL", "5m")
+	viper.SetDefault("WEBAUTHN_USER_VERIFICATION", "preferred")
+	return &WebAuthnConfig{
+		RPID:             viper.GetString("WEBAUTHN_RP_ID"),
+		RPName:           viper.GetString("WEBAUTHN_RP_NAME"),
+		Origins:          getList("WEBAUTHN_ORIGINS"),
+		ChallengeTTL:     viper.GetDuration("WEBAUTHN_CHALLENGE_TTL"),
+		UserVerification: viper.GetString("WEBAUTHN_USER_VERIFICATION"),
+	}
+}
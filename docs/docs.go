@@ -24,9 +24,49 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticates the user and returns an access token",
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys used to verify access tokens, including keys scheduled to become active and retired keys that still verify. The body is a plain RFC 7517 key set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_pkg_signature.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/.well-known/openid-configuration": {
+            "get": {
+                "description": "Describes the endpoints, scopes and algorithms of this provider. The body is a plain OpenID Provider Metadata document.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "OpenID Connect discovery document",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OpenIDConfiguration"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys": {
+            "get": {
+                "description": "Lists the API keys that belong to no user",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,18 +74,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "User login",
+                "summary": "List service account API keys",
                 "parameters": [
                     {
-                        "description": "Login Request",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
-                        }
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -60,25 +98,26 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.APIKey"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
+                    "403": {
                         "description": "error",
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
+            },
             "post": {
-                "description": "Registers a new user with the provided username and password",
+                "description": "Creates an API key that belongs to no user and is granted exactly the given scopes. The key is only shown in this response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -86,17 +125,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Create a service account API key",
                 "parameters": [
                     {
-                        "description": "Registration Request",
-                        "name": "register",
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "API Key Request",
+                        "name": "api-key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.APIKeyRequest"
                         }
                     }
                 ],
@@ -112,7 +158,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.APIKeySecret"
                                         }
                                     }
                                 }
@@ -124,13 +170,70 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
                     }
                 }
             }
         },
-        "/users": {
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "description": "Revokes an API key that belongs to no user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Revoke a service account API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit": {
             "get": {
-                "description": "Retrieves a paginated list of users with optional ordering and filtering",
+                "description": "Retrieves a paginated list of changes made to user and group records, newest first unless sorted otherwise. Password hashes are redacted from the changes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -138,9 +241,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "List users",
+                "summary": "List audit log entries",
                 "parameters": [
                     {
                         "type": "string",
@@ -163,13 +266,13 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter rules\u003cbr\u003e\u003cbr\u003e### Rules Filter\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{value}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * eq (=)\u003cbr\u003e  * lt (\u003c)\u003cbr\u003e  * gt (\u003e)\u003cbr\u003e  * lte (\u003c=)\u003cbr\u003e  * gte (\u003e=)\u003cbr\u003e  * in (in)\u003cbr\u003e  * like (like)\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * username\u003cbr\u003e  * email",
+                        "description": "Filter rules\u003cbr\u003e\u003cbr\u003e### Rules Filter\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{value}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * eq (=)\u003cbr\u003e  * lt (\u003c)\u003cbr\u003e  * gt (\u003e)\u003cbr\u003e  * lte (\u003c=)\u003cbr\u003e  * gte (\u003e=)\u003cbr\u003e  * in (in)\u003cbr\u003e  * like (like)\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * actor (actor user ID)\u003cbr\u003e  * action\u003cbr\u003e  * target (target record ID)\u003cbr\u003e  * created_at",
                         "name": "filter",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort rules:\u003cbr\u003e\u003cbr\u003e### Rules Sort\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * asc\u003cbr\u003e  * desc\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * title\u003cbr\u003e  * isbn\u003cbr\u003e  * author_id",
+                        "description": "Sort rules:\u003cbr\u003e\u003cbr\u003e### Rules Sort\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * asc\u003cbr\u003e  * desc\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * actor\u003cbr\u003e  * action\u003cbr\u003e  * target\u003cbr\u003e  * created_at",
                         "name": "sort",
                         "in": "query"
                     }
@@ -188,7 +291,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.AuditLog"
                                             }
                                         },
                                         "pagination": {
@@ -204,16 +307,70 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
                     }
                 }
-            },
-            "post": {
-                "security": [
+            }
+        },
+        "/admin/oauth/clients": {
+            "get": {
+                "description": "Lists the registered OAuth clients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List OAuth clients",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
-                "description": "Creates a new book with the provided details",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthClient"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers an application allowed to obtain tokens. Confidential clients get a client_secret that is only shown in this response; public clients get none and must use PKCE.",
                 "consumes": [
                     "application/json"
                 ],
@@ -221,9 +378,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Create a new book",
+                "summary": "Register an OAuth client",
                 "parameters": [
                     {
                         "type": "string",
@@ -233,12 +390,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "User Request",
-                        "name": "notification-list",
+                        "description": "OAuth Client Request",
+                        "name": "client",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthClientRequest"
                         }
                     }
                 ],
@@ -254,7 +411,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthClientSecret"
                                         }
                                     }
                                 }
@@ -266,13 +423,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Retrieves the details of a specific book by ID",
+        "/admin/oauth/clients/{id}": {
+            "delete": {
+                "description": "Deletes an OAuth client and revokes the refresh tokens issued to it",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,9 +443,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Get details of a book",
+                "summary": "Delete an OAuth client",
                 "parameters": [
                     {
                         "type": "string",
@@ -293,7 +456,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID (UUID format)",
+                        "description": "Client ID (UUID format)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -303,19 +466,7 @@ const docTemplate = `{
                     "200": {
                         "description": "success",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
                         }
                     },
                     "400": {
@@ -323,11 +474,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing book with the provided details",
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
+                "description": "Issues a short-lived access token that acts as the user, with the user's permissions and an act claim naming the admin. Every request made with it is logged. It can't change the user's password or MFA, mint API keys or authorize OAuth clients, and admins can't be impersonated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,9 +494,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Update an existing book",
+                "summary": "Impersonate a user",
                 "parameters": [
                     {
                         "type": "string",
@@ -352,15 +511,6 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated User details",
-                        "name": "book",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
-                        }
                     }
                 ],
                 "responses": {
@@ -375,7 +525,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.ImpersonationResponse"
                                         }
                                     }
                                 }
@@ -387,11 +537,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/lock": {
             "delete": {
-                "description": "Deletes an existing book by ID",
+                "description": "Lifts a lock caused by failed logins and clears the account's failure count. Throttling of the client IP is left in place.",
                 "consumes": [
                     "application/json"
                 ],
@@ -399,9 +563,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Delete an existing book",
+                "summary": "Unlock a user's account",
                 "parameters": [
                     {
                         "type": "string",
@@ -428,88 +592,4720 @@ const docTemplate = `{
                     "400": {
                         "description": "error",
                         "schema": {
-                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "user-simple-crud_internal_delivery_http_response.DataResponse": {
-            "type": "object",
-            "properties": {
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/mfa": {
+            "delete": {
+                "description": "Removes the user's TOTP secret and recovery codes so they can sign in with their password and enroll again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reset a user's MFA",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/reactivate": {
+            "post": {
+                "description": "Lets a suspended, banned or pending user sign in again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reactivate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reactivate Request",
+                        "name": "reactivation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ReactivateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/restore": {
+            "post": {
+                "description": "Brings back a soft deleted user that hasn't been purged yet. Fails when another user has taken its username or email since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore a deleted user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/roles": {
+            "post": {
+                "description": "Grants a role to the user. The user's current access tokens are revoked so the new claims apply on the next refresh.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Assign a role to a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role Request",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/roles/{role}": {
+            "delete": {
+                "description": "Removes a role from the user. The user's current access tokens are revoked so the new claims apply on the next refresh.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Revoke a role from a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "delete": {
+                "description": "Invalidates every access and refresh token issued to the user so far",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Revoke all sessions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "description": "Stops the user from signing in and ends its sessions. A suspension lasts until expires_at, or until the user is reactivated when it has none. With ban the user stays banned until reactivated. Admins can't suspend themselves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Suspend or ban a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspend Request",
+                        "name": "suspension",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.SuspendRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/api-keys": {
+            "get": {
+                "description": "Lists the caller's API keys, including revoked and expired ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "List personal access tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.APIKey"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an API key acting as the caller, limited to the given scopes. The key is only shown in this response. Send it as \"Authorization: ApiKey \u003ckey\u003e\" or in the X-API-Key header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Create a personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "API Key Request",
+                        "name": "api-key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.APIKeySecret"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/api-keys/{id}": {
+            "delete": {
+                "description": "Revokes one of the caller's API keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Revoke a personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API Key ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/change-expired-password": {
+            "post": {
+                "description": "Redeems the password_change_token a login returned for an expired password and sets a new password. Policy violations are listed under \"password\", each with its rule and message. Log in again afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Change an expired password",
+                "parameters": [
+                    {
+                        "description": "Change Expired Password Request",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ChangeExpiredPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Emails a time-limited password reset link. The response is the same whether or not the address belongs to an account. Each address can request a limited number of resets per window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Forgot Password Request",
+                        "name": "forgot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticates the user, starts a session for the calling device and returns an access token bound to it. An expired password returns password_change_required and a password_change_token instead of tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before trying again"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before trying again"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revokes the access token used for this request. When a refresh token is sent, its whole token family is revoked as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Logout Request",
+                        "name": "logout",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/magic-link": {
+            "post": {
+                "description": "Emails a single-use, time-limited sign-in link. The response is the same whether or not the address belongs to an account. Each address may only request a few links per window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request a sign-in link",
+                "parameters": [
+                    {
+                        "description": "Magic Link Request",
+                        "name": "magicLink",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.MagicLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/magic-link/consume": {
+            "post": {
+                "description": "Redeems the token from a sign-in link. The response is the same as a password login: tokens, or an mfa_token when the user has MFA.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Sign in with a link",
+                "parameters": [
+                    {
+                        "description": "Consume Magic Link Request",
+                        "name": "consume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ConsumeMagicLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "description": "Retrieves the profile of the user the access token was issued to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get the caller's profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/confirm": {
+            "post": {
+                "description": "Enables MFA with the first code from the authenticator app and returns one-time recovery codes. The codes are not shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Confirm MFA enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "MFA Code",
+                        "name": "confirm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.MFARecoveryCodes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "description": "Creates a TOTP secret for the caller and returns it as an otpauth:// URI and a QR code PNG. MFA stays off until the first code is confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Start MFA enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.MFAEnrollment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Exchanges the mfa_token from /auth/login and a TOTP or recovery code for access and refresh tokens. The mfa_token is spent on the first attempt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Complete an MFA login",
+                "parameters": [
+                    {
+                        "description": "MFA Verify Request",
+                        "name": "verify",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.MFAVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "Lists the names of the external OpenID Connect providers users can sign in with",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List identity providers",
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Completes a login started at /auth/oidc/{provider}/login. The provider account is linked to the user with the same verified email, or a new user is created for it, and tokens are issued as on a password login.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Identity provider callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State sent to the provider",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Error returned by the provider",
+                        "name": "error",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirects the browser to the provider's login page. The login must be completed by the same browser, which is tracked with a short lived cookie.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Sign in with an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "redirect to the provider"
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new access token and a rotated refresh token. Replaying an already rotated refresh token revokes every token of its family.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh Request",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Registers a new user with the provided username and password. Password policy violations are listed under \"password\", each with its rule and message.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration Request",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "description": "Sends a new verification email and invalidates the previous one. The response is the same whether or not the address belongs to an unverified account. Each address can request a limited number of emails per window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Resend Verification Request",
+                        "name": "resend",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Redeems a password reset token and sets a new password. Every session of the user is signed out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset Password Request",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "description": "Lists the devices the caller is signed in from. The session of the token used for this request is marked as current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.Session"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Signs the caller out of every device except the one making this request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke all other sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "description": "Signs the caller out of one device. Its access and refresh tokens stop working immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "get": {
+                "description": "Redeems the single-use token from a verification email. The token is read from the query string on GET, which is what the emailed link uses, and from the JSON body on POST.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Verify Email Request",
+                        "name": "verify",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Redeems the single-use token from a verification email. The token is read from the query string on GET, which is what the emailed link uses, and from the JSON body on POST.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Verify Email Request",
+                        "name": "verify",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/credentials": {
+            "get": {
+                "description": "Lists the WebAuthn credentials registered to the caller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "List passkeys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnCredential"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/credentials/{id}": {
+            "delete": {
+                "description": "Removes one of the caller's WebAuthn credentials so it can no longer be used to log in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "Remove a passkey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Credential ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/login/begin": {
+            "post": {
+                "description": "Returns the options to pass to navigator.credentials.get. Without a username any discoverable credential may be used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "Start a passkey login",
+                "parameters": [
+                    {
+                        "description": "WebAuthn Login Request",
+                        "name": "login",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.WebAuthnLoginOptions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/login/finish": {
+            "post": {
+                "description": "Verifies the assertion returned by navigator.credentials.get. The response is the same as a password login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "Finish a passkey login",
+                "parameters": [
+                    {
+                        "description": "WebAuthn Assertion",
+                        "name": "assertion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnAssertion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/register/begin": {
+            "post": {
+                "description": "Returns the options to pass to navigator.credentials.create. Binary values are base64url encoded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "Start registering a passkey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_services.WebAuthnRegistrationOptions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/register/finish": {
+            "post": {
+                "description": "Verifies the credential returned by navigator.credentials.create and adds it to the caller's account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WebAuthn"
+                ],
+                "summary": "Finish registering a passkey",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "WebAuthn Registration Request",
+                        "name": "registration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnCredential"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups": {
+            "get": {
+                "description": "Retrieves a paginated list of groups with optional ordering and filtering. The members of a group are listed by /users with the filter group:{id}:eq.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "List groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter rules\u003cbr\u003e\u003cbr\u003e### Rules Filter\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{value}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * eq (=)\u003cbr\u003e  * lt (\u003c)\u003cbr\u003e  * gt (\u003e)\u003cbr\u003e  * lte (\u003c=)\u003cbr\u003e  * gte (\u003e=)\u003cbr\u003e  * in (in)\u003cbr\u003e  * like (like)\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * name\u003cbr\u003e  * created_at",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort rules:\u003cbr\u003e\u003cbr\u003e### Rules Sort\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * asc\u003cbr\u003e  * desc\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * name\u003cbr\u003e  * created_at",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.PaginationResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.Group"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_model.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a group of users. Group names are unique regardless of case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Create a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Group Request",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}": {
+            "get": {
+                "description": "Retrieves a group by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the name and description of a group",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Update a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group Request",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.Group"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a group and its memberships. The members themselves are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Delete a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members": {
+            "post": {
+                "description": "Makes the user a member of the group. Adding a member twice changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Add a user to a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group Member Request",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.GroupMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/groups/{id}/members/{userId}": {
+            "delete": {
+                "description": "Ends the user's membership of the group. Removing a user that isn't a member changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Remove a user from a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/authorize": {
+            "get": {
+                "description": "Checks the authorization request and redirects the browser to the consent page, which signs the user in and asks them to approve the client. The request is passed on in the query together with client_name, with scope resolved. Only the code response type with PKCE (S256) is supported. Errors about the client or redirect_uri are returned as JSON, every other error is sent to the redirect_uri.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "OAuth2 authorization endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Must be code",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered redirect URI, optional when the client has only one",
+                        "name": "redirect_uri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space separated scopes, defaults to every scope of the client",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque value returned to the client",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "base64url SHA-256 of the code verifier",
+                        "name": "code_challenge",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Must be S256",
+                        "name": "code_challenge_method",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Value copied into the ID token",
+                        "name": "nonce",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "redirect to the consent page, or to the client with an error"
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Called by the consent page with the signed in user's access token and the parameters it was opened with. Approving issues an authorization code, denying answers the client with access_denied. The page should send the browser to the returned redirect_uri either way. Errors about the client or redirect_uri are returned as JSON.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "Answer an OAuth2 authorization request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authorization request and the user's answer",
+                        "name": "consent",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthConsentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthConsentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthError"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/token": {
+            "post": {
+                "description": "Exchanges an authorization code, a refresh token or the client credentials for tokens. Confidential clients authenticate with HTTP Basic or client_id and client_secret form fields, public clients send only client_id. Responses follow RFC 6749.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "OAuth2 token endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization_code, refresh_token or client_credentials",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Redirect URI the code was issued for, required when the authorization request included it",
+                        "name": "redirect_uri",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code verifier",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space separated scopes",
+                        "name": "scope",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client secret",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthError"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.OAuthError"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/userinfo": {
+            "get": {
+                "description": "Returns the claims of the user the access token was issued to, for the scopes it was granted. The token must have the openid scope. The body is a plain claims object.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "OpenID Connect userinfo endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Returns the claims of the user the access token was issued to, for the scopes it was granted. The token must have the openid scope. The body is a plain claims object.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "OpenID Connect userinfo endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signature/sandbox": {
+            "post": {
+                "description": "Returns the signature the server expects for a request, along with the string it signs, so sandbox clients can compare it with their own. Send the exact body of the request being debugged, its method in httpMethod and its path with the query string in httpPath. Only clients flagged as sandbox may use it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signature"
+                ],
+                "summary": "Compute a request signature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partner client ID",
+                        "name": "X-Client-Id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix time in seconds",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique value per request",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Method of the request being signed",
+                        "name": "httpMethod",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path and query of the request being signed",
+                        "name": "httpPath",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_model.Signature"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signature/verify": {
+            "post": {
+                "description": "Accepts any method and body and succeeds only when the request is correctly signed, so partners can test their signing before calling real endpoints. The string to sign is METHOD, path with query, X-Timestamp, X-Nonce and the hex SHA-256 of the body, joined by newlines. X-Signature is its hex HMAC-SHA256 under the client secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signature"
+                ],
+                "summary": "Check a signed request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partner client ID",
+                        "name": "X-Client-Id",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix time in seconds",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique value per request",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex HMAC-SHA256 of the string to sign",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieves a paginated list of users with optional ordering and filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Number of items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter rules\u003cbr\u003e\u003cbr\u003e### Rules Filter\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{value}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * eq (=)\u003cbr\u003e  * lt (\u003c)\u003cbr\u003e  * gt (\u003e)\u003cbr\u003e  * lte (\u003c=)\u003cbr\u003e  * gte (\u003e=)\u003cbr\u003e  * in (in)\u003cbr\u003e  * like (like)\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * username\u003cbr\u003e  * email\u003cbr\u003e  * status (active, suspended, banned or pending)\u003cbr\u003e  * group (group ID, eq or in only)",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list soft deleted users, needs the users:restore permission",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort rules:\u003cbr\u003e\u003cbr\u003e### Rules Sort\u003cbr\u003erule:\u003cbr\u003e  * {Name of Field}:{Symbol}\u003cbr\u003e\u003cbr\u003eSymbols:\u003cbr\u003e  * asc\u003cbr\u003e  * desc\u003cbr\u003e\u003cbr\u003eField list:\u003cbr\u003e  * id\u003cbr\u003e  * username\u003cbr\u003e  * email\u003cbr\u003e  * status",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.PaginationResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                            }
+                                        },
+                                        "pagination": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_model.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new book with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User Request",
+                        "name": "notification-list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserLogin"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Retrieves the details of a specific book by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get details of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated relations to load along: groups",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user, send it back as If-Match to update or delete it"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the user's username and email, an empty value clears it. A changed email has to be verified again. Passwords are no longer accepted here, change them through /users/{id}/password. Prefer PATCH, which only touches the members it is sent. Send the ETag the user was read with as If-Match to fail with 412 instead of overwriting someone else's change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Replace a user's profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user the update is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User profile",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes the user and ends its sessions. The user can be restored through /admin/users/{id}/restore until the retention window passes and it is purged. With If-Match the user is only deleted if it hasn't changed since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user the delete is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Applies a JSON Merge Patch (RFC 7396) to the user's username and email. Members left out keep their value and null clears one. Only changed values are checked for uniqueness, and a changed email has to be verified again. Passwords are changed through /users/{id}/password. Send the ETag the user was read with as If-Match to fail with 412 instead of overwriting someone else's change.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update part of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user the patch is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch of the user profile",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.UserProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user-simple-crud_internal_entity.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password": {
+            "post": {
+                "description": "Replaces the password after checking the current one. Wrong current passwords count toward the account lockout. All of the user's sessions are ended on success.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change the caller's password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "format: Bearer \u003cJWT TOKEN\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Password Request",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_entity.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "error",
+                        "schema": {
+                            "$ref": "#/definitions/user-simple-crud_internal_delivery_http_response.DataResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "user-simple-crud_internal_delivery_http_response.DataResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "responseCode": {
+                    "type": "integer"
+                },
+                "responseMessage": {}
+            }
+        },
+        "user-simple-crud_internal_delivery_http_response.PaginationResponse": {
+            "type": "object",
+            "properties": {
                 "data": {},
+                "pagination": {},
+                "responseCode": {
+                    "type": "integer"
+                },
+                "responseMessage": {}
+            }
+        },
+        "user-simple-crud_internal_delivery_http_response.SuccessResponse": {
+            "type": "object",
+            "properties": {
                 "responseCode": {
                     "type": "integer"
                 },
-                "responseMessage": {}
+                "responseMessage": {}
+            }
+        },
+        "user-simple-crud_internal_entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "nightly-export"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the key, kept so owners can tell their keys apart",
+                    "type": "string",
+                    "example": "usc_3q2-7wYl"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "nightly-export"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.APIKeySecret": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "usc_3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "nightly-export"
+                },
+                "prefix": {
+                    "description": "Prefix is the start of the key, kept so owners can tell their keys apart",
+                    "type": "string",
+                    "example": "usc_3q2-7wYl"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.AuditChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "john_doe_updated"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "user.update"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+                },
+                "actor_name": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/user-simple-crud_internal_entity.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b8d3f3d-d343-4390-964c-4f05c4c803d6"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorId is the admin behind an impersonation token that made the change",
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f0c6a1e-9a4b-4c8e-8a43-0e2f4b7f5d21"
+                },
+                "target_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ChangeExpiredPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "password_change_token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "NewSecurePass123!"
+                },
+                "password_change_token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "SecurePass123!"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "NewSecurePass123!"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ConsumeMagicLinkRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.Group": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Everyone working on the product"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "engineering"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.GroupMemberRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.GroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Everyone working on the product"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "engineering"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.MFAEnrollment": {
+            "type": "object",
+            "properties": {
+                "otpauth_url": {
+                    "type": "string",
+                    "example": "otpauth://totp/user-simple-crud:john_doe?issuer=user-simple-crud\u0026secret=JBSWY3DPEHPK3PXP"
+                },
+                "qr_code": {
+                    "description": "QRCode is a PNG of OtpauthURL encoded as a data URI",
+                    "type": "string",
+                    "example": "data:image/png;base64,iVBORw0KGgo="
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.MFARecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k7qzm-p2x4d"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.MFAVerifyRequest": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                },
+                "recovery_code": {
+                    "type": "string",
+                    "example": "k7qzm-p2x4d"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.MagicLinkRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthClient": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grant_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "authorization_code"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-portal"
+                },
+                "public": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://billing.example.com/callback"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "openid"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthClientRequest": {
+            "type": "object",
+            "required": [
+                "grant_types",
+                "name",
+                "redirect_uris",
+                "scopes"
+            ],
+            "properties": {
+                "grant_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "authorization_code"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "billing-portal"
+                },
+                "public": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://billing.example.com/callback"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "openid"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthClientSecret": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "client_secret": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grant_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "authorization_code"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-portal"
+                },
+                "public": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://billing.example.com/callback"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "openid"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthConsentRequest": {
+            "type": "object",
+            "properties": {
+                "approve": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "code_challenge": {
+                    "type": "string",
+                    "example": "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+                },
+                "code_challenge_method": {
+                    "type": "string",
+                    "example": "S256"
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "n-0S6_WzA2Mj"
+                },
+                "redirect_uri": {
+                    "type": "string",
+                    "example": "https://billing.example.com/callback"
+                },
+                "response_type": {
+                    "type": "string",
+                    "example": "code"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "openid profile users:read"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "af0ifjsldkj"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthConsentResponse": {
+            "type": "object",
+            "properties": {
+                "redirect_uri": {
+                    "type": "string",
+                    "example": "https://billing.example.com/callback?code=SplxlOBeZQQYbYS6WxSbIA\u0026state=af0ifjsldkj"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid_grant"
+                },
+                "error_description": {
+                    "type": "string",
+                    "example": "authorization code has expired"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OAuthTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjQtMDEifQ..."
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "id_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjQtMDEifQ..."
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "openid users:read"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.OpenIDConfiguration": {
+            "type": "object",
+            "properties": {
+                "authorization_endpoint": {
+                    "type": "string"
+                },
+                "claims_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code_challenge_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "grant_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id_token_signing_alg_values_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "jwks_uri": {
+                    "type": "string"
+                },
+                "response_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "subject_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_endpoint": {
+                    "type": "string"
+                },
+                "token_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userinfo_endpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ReactivateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Payment received"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "NewSecurePass123!"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.RoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current marks the session the listing request was made from",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (X11; Linux x86_64)"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.SuspendRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "ban": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Chargeback on the last invoice"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.User": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt hides the user from queries until it is restored or purged after the retention window",
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2024-01-02T15:04:05Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "groups": {
+                    "description": "Groups is only loaded when FindByID is asked to preload UserGroupsAssociation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-simple-crud_internal_entity.Group"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "password_changed_at": {
+                    "description": "PasswordChangedAt starts the password expiry clock, accounts from before it was tracked never expire",
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user"
+                    ]
+                },
+                "status": {
+                    "description": "Status is the stored account status, StatusAt tells the one in effect",
+                    "type": "string",
+                    "example": "active"
+                },
+                "status_expires_at": {
+                    "description": "StatusExpiresAt ends a suspension, without it the user stays suspended until reactivated",
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "status_reason": {
+                    "description": "StatusReason is the reason an admin gave for the last suspension, ban or reactivation",
+                    "type": "string",
+                    "example": "Chargeback on the last invoice"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "version": {
+                    "description": "Version is bumped by every update and sent as the ETag; an update carrying an older one fails",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.UserInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "preferred_username": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "sub": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.UserLogin": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
+                },
+                "password": {
+                    "description": "checked against the password policy on register",
+                    "type": "string",
+                    "example": "SecurePass123!"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
             }
         },
-        "user-simple-crud_internal_delivery_http_response.PaginationResponse": {
+        "user-simple-crud_internal_entity.UserProfile": {
             "type": "object",
             "properties": {
-                "data": {},
-                "pagination": {},
-                "responseCode": {
-                    "type": "integer"
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@example.com"
                 },
-                "responseMessage": {}
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
             }
         },
-        "user-simple-crud_internal_delivery_http_response.SuccessResponse": {
+        "user-simple-crud_internal_entity.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.WebAuthnAssertion": {
             "type": "object",
+            "required": [
+                "id",
+                "rawId",
+                "response",
+                "type"
+            ],
             "properties": {
-                "responseCode": {
-                    "type": "integer"
+                "id": {
+                    "type": "string",
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
                 },
-                "responseMessage": {}
+                "rawId": {
+                    "type": "string",
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
+                },
+                "response": {
+                    "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnAssertionResponse"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
             }
         },
-        "user-simple-crud_internal_entity.User": {
+        "user-simple-crud_internal_entity.WebAuthnAssertionResponse": {
             "type": "object",
+            "required": [
+                "authenticatorData",
+                "clientDataJSON",
+                "signature"
+            ],
             "properties": {
-                "email": {
+                "authenticatorData": {
                     "type": "string",
-                    "example": "john_doe@example.com"
+                    "example": "SZYN5YgOjGh0NBcPZHZgW4_krrmihjLHmVzzuoMdl2MFAAAAAQ"
+                },
+                "clientDataJSON": {
+                    "type": "string",
+                    "example": "eyJ0eXBlIjoid2ViYXV0aG4uZ2V0In0"
+                },
+                "signature": {
+                    "type": "string",
+                    "example": "MEUCIQDx"
                 },
+                "userHandle": {
+                    "type": "string",
+                    "example": "MTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAw"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.WebAuthnAttestation": {
+            "type": "object",
+            "required": [
+                "id",
+                "rawId",
+                "response",
+                "type"
+            ],
+            "properties": {
                 "id": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
                 },
-                "password": {
-                    "description": "Example of bcrypt-hashed password",
+                "rawId": {
                     "type": "string",
-                    "example": "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu"
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
                 },
-                "username": {
+                "response": {
+                    "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnAttestationResponse"
+                },
+                "type": {
                     "type": "string",
-                    "example": "john_doe"
+                    "example": "public-key"
                 }
             }
         },
-        "user-simple-crud_internal_entity.UserLogin": {
+        "user-simple-crud_internal_entity.WebAuthnAttestationResponse": {
             "type": "object",
             "required": [
-                "password"
+                "attestationObject",
+                "clientDataJSON"
             ],
             "properties": {
-                "email": {
+                "attestationObject": {
                     "type": "string",
-                    "example": "john_doe@example.com"
+                    "example": "o2NmbXRkbm9uZQ"
                 },
-                "password": {
-                    "description": "\"password\" custom validation assumed",
+                "clientDataJSON": {
                     "type": "string",
-                    "minLength": 8,
-                    "example": "SecurePass123!"
+                    "example": "eyJ0eXBlIjoid2ViYXV0aG4uY3JlYXRlIn0"
                 },
+                "transports": {
+                    "type": "array",
+                    "maxItems": 8,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal"
+                    ]
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.WebAuthnCredential": {
+            "type": "object",
+            "properties": {
+                "aaguid": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "algorithm": {
+                    "type": "integer",
+                    "example": -7
+                },
+                "backed_up": {
+                    "type": "boolean"
+                },
+                "backup_eligible": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credential_id": {
+                    "description": "CredentialId is the authenticator's credential ID, base64url encoded",
+                    "type": "string",
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "MacBook Touch ID"
+                },
+                "sign_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "user-simple-crud_internal_entity.WebAuthnLoginRequest": {
+            "type": "object",
+            "properties": {
                 "username": {
                     "type": "string",
+                    "maxLength": 255,
                     "example": "john_doe"
                 }
             }
         },
+        "user-simple-crud_internal_entity.WebAuthnRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "credential",
+                "name"
+            ],
+            "properties": {
+                "credential": {
+                    "$ref": "#/definitions/user-simple-crud_internal_entity.WebAuthnAttestation"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "MacBook Touch ID"
+                }
+            }
+        },
         "user-simple-crud_internal_model.Pagination": {
             "type": "object",
             "properties": {
@@ -540,6 +5336,44 @@ const docTemplate = `{
                 }
             }
         },
+        "user-simple-crud_internal_model.Signature": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "type": "string",
+                    "example": "asdkjhad7asjkdhb#%4jzhnjkfx8@"
+                },
+                "string_to_sign": {
+                    "type": "string",
+                    "example": "POST\n/signature/verify\n1700000000\n5f1c2e\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+                }
+            }
+        },
+        "user-simple-crud_internal_services.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
         "user-simple-crud_internal_services.UserLoginResponse": {
             "type": "object",
             "properties": {
@@ -547,6 +5381,33 @@ const docTemplate = `{
                     "type": "string",
                     "example": "john_doe@example.com"
                 },
+                "mfa_required": {
+                    "description": "MFARequired means no tokens were issued yet; send MFAToken with a code to /auth/mfa/verify",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_token": {
+                    "type": "string",
+                    "example": "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"
+                },
+                "password_change_required": {
+                    "description": "PasswordChangeRequired means the password expired and no tokens were issued;\nsend PasswordChangeToken with a new password to /auth/change-expired-password",
+                    "type": "boolean",
+                    "example": false
+                },
+                "password_change_token": {
+                    "type": "string",
+                    "example": "0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E3q2-7wYl"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken is shown once; exchange it at /auth/refresh for a new pair",
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
                 "token": {
                     "description": "JWT token example",
                     "type": "string",
@@ -557,6 +5418,212 @@ const docTemplate = `{
                     "example": "john_doe"
                 }
             }
+        },
+        "user-simple-crud_internal_services.WebAuthnLoginOptions": {
+            "type": "object",
+            "properties": {
+                "publicKey": {
+                    "$ref": "#/definitions/user-simple-crud_pkg_webauthn.RequestOptions"
+                }
+            }
+        },
+        "user-simple-crud_internal_services.WebAuthnRegistrationOptions": {
+            "type": "object",
+            "properties": {
+                "publicKey": {
+                    "$ref": "#/definitions/user-simple-crud_pkg_webauthn.CreationOptions"
+                }
+            }
+        },
+        "user-simple-crud_pkg_signature.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "RS256"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "2024-01"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "user-simple-crud_pkg_signature.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-simple-crud_pkg_signature.JSONWebKey"
+                    }
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.AuthenticatorSelection": {
+            "type": "object",
+            "properties": {
+                "residentKey": {
+                    "type": "string",
+                    "example": "preferred"
+                },
+                "userVerification": {
+                    "type": "string",
+                    "example": "preferred"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.CreationOptions": {
+            "type": "object",
+            "properties": {
+                "attestation": {
+                    "type": "string",
+                    "example": "none"
+                },
+                "authenticatorSelection": {
+                    "$ref": "#/definitions/user-simple-crud_pkg_webauthn.AuthenticatorSelection"
+                },
+                "challenge": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "excludeCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-simple-crud_pkg_webauthn.CredentialDescriptor"
+                    }
+                },
+                "pubKeyCredParams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-simple-crud_pkg_webauthn.CredentialParameter"
+                    }
+                },
+                "rp": {
+                    "$ref": "#/definitions/user-simple-crud_pkg_webauthn.RelyingPartyEntity"
+                },
+                "timeout": {
+                    "type": "integer",
+                    "example": 300000
+                },
+                "user": {
+                    "$ref": "#/definitions/user-simple-crud_pkg_webauthn.UserEntity"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.CredentialDescriptor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "mT3eGz3bVqHc0y1pJ2xk6w"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "internal"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.CredentialParameter": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "integer",
+                    "example": -7
+                },
+                "type": {
+                    "type": "string",
+                    "example": "public-key"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.RelyingPartyEntity": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "localhost"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "user-simple-crud"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.RequestOptions": {
+            "type": "object",
+            "properties": {
+                "allowCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user-simple-crud_pkg_webauthn.CredentialDescriptor"
+                    }
+                },
+                "challenge": {
+                    "type": "string",
+                    "example": "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+                },
+                "rpId": {
+                    "type": "string",
+                    "example": "localhost"
+                },
+                "timeout": {
+                    "type": "integer",
+                    "example": 300000
+                },
+                "userVerification": {
+                    "type": "string",
+                    "example": "preferred"
+                }
+            }
+        },
+        "user-simple-crud_pkg_webauthn.UserEntity": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string",
+                    "example": "john_doe"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "MTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAw"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
         }
     },
     "externalDocs": {
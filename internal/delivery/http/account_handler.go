@@ -0,0 +1,143 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type AccountHTTPHandler struct {
+	Handler
+	AccountService service.AccountService
+}
+
+func NewAccountHTTPHandler(account service.AccountService) *AccountHTTPHandler {
+	return &AccountHTTPHandler{
+		AccountService: account,
+	}
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Redeems the single-use token from a verification email. The token is read from the query string on GET, which is what the emailed link uses, and from the JSON body on POST.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token query string false "Verification token"
+// @Param verify body entity.VerifyEmailRequest false "Verify Email Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Router /auth/verify-email [get]
+// @Router /auth/verify-email [post]
+func (h AccountHTTPHandler) VerifyEmail(ctx *gin.Context) {
+	request := entity.VerifyEmailRequest{}
+	if err := ctx.ShouldBind(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.AccountService.VerifyEmail(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Sends a new verification email and invalidates the previous one. The response is the same whether or not the address belongs to an unverified account. Each address can request a limited number of emails per window.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param resend body entity.ResendVerificationRequest true "Resend Verification Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 429 {object} response.DataResponse "error"
+// @Router /auth/resend-verification [post]
+func (h AccountHTTPHandler) ResendVerification(ctx *gin.Context) {
+	request := entity.ResendVerificationRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.AccountService.ResendVerification(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Emails a time-limited password reset link. The response is the same whether or not the address belongs to an account. Each address can request a limited number of resets per window.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param forgot body entity.ForgotPasswordRequest true "Forgot Password Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 429 {object} response.DataResponse "error"
+// @Router /auth/forgot-password [post]
+func (h AccountHTTPHandler) ForgotPassword(ctx *gin.Context) {
+	request := entity.ForgotPasswordRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.AccountService.ForgotPassword(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Redeems a password reset token and sets a new password. Every session of the user is signed out.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param reset body entity.ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Router /auth/reset-password [post]
+func (h AccountHTTPHandler) ResetPassword(ctx *gin.Context) {
+	request := entity.ResetPasswordRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.AccountService.ResetPassword(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// ChangeExpiredPassword godoc
+// @Summary Change an expired password
+// @Description Redeems the password_change_token a login returned for an expired password and sets a new password. Policy violations are listed under "password", each with its rule and message. Log in again afterwards.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param change body entity.ChangeExpiredPasswordRequest true "Change Expired Password Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Router /auth/change-expired-password [post]
+func (h AccountHTTPHandler) ChangeExpiredPassword(ctx *gin.Context) {
+	request := entity.ChangeExpiredPasswordRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.AccountService.ChangeExpiredPassword(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
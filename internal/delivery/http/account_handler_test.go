@@ -0,0 +1,227 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestAccountHttpHandler_VerifyEmail(t *testing.T) {
+	t.Run("VerifyEmail From Link Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.GET("/auth/verify-email", accountHandler.VerifyEmail)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/verify-email?token=verification_token", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("VerifyEmail", mock.Anything, &entity.VerifyEmailRequest{Token: "verification_token"}).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockAccountService.AssertExpectations(t)
+	})
+
+	t.Run("VerifyEmail From Body Invalid Token", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/verify-email", accountHandler.VerifyEmail)
+
+		// Mock Data
+		requestBody := &entity.VerifyEmailRequest{Token: "spent_token"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/verify-email", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("VerifyEmail", mock.Anything, requestBody).Return(exception.InvalidArgument("invalid or expired token"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAccountHttpHandler_ResendVerification(t *testing.T) {
+	t.Run("ResendVerification Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/resend-verification", accountHandler.ResendVerification)
+
+		// Mock Data
+		requestBody := &entity.ResendVerificationRequest{Email: "john_doe@example.com"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/resend-verification", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("ResendVerification", mock.Anything, requestBody).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ResendVerification Error - Invalid JSON", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/resend-verification", accountHandler.ResendVerification)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/resend-verification", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ResendVerification Error - Rate Limited", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/resend-verification", accountHandler.ResendVerification)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/resend-verification", bytes.NewBufferString(`{"email":"john_doe@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("ResendVerification", mock.Anything, mock.Anything).
+			Return(exception.TooManyRequests("too many verification emails requested for this address, try again later", 30*time.Minute))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1800", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestAccountHttpHandler_ForgotPassword(t *testing.T) {
+	t.Run("ForgotPassword Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/forgot-password", accountHandler.ForgotPassword)
+
+		// Mock Data
+		requestBody := &entity.ForgotPasswordRequest{Email: "john_doe@example.com"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/forgot-password", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("ForgotPassword", mock.Anything, requestBody).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestAccountHttpHandler_ResetPassword(t *testing.T) {
+	t.Run("ResetPassword Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/reset-password", accountHandler.ResetPassword)
+
+		// Mock Data
+		requestBody := &entity.ResetPasswordRequest{Token: "reset_token", Password: "NewSecurePass123!"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/reset-password", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("ResetPassword", mock.Anything, requestBody).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ResetPassword Invalid Token", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAccountService := new(mocks.AccountService)
+		accountHandler := NewAccountHTTPHandler(mockAccountService)
+
+		r.POST("/auth/reset-password", accountHandler.ResetPassword)
+
+		// Mock Data
+		requestBody := &entity.ResetPasswordRequest{Token: "spent_token", Password: "NewSecurePass123!"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/reset-password", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAccountService.On("ResetPassword", mock.Anything, requestBody).Return(exception.InvalidArgument("invalid or expired token"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type APIKeyHTTPHandler struct {
+	Handler
+	APIKeyService service.APIKeyService
+}
+
+func NewAPIKeyHTTPHandler(apiKey service.APIKeyService) *APIKeyHTTPHandler {
+	return &APIKeyHTTPHandler{
+		APIKeyService: apiKey,
+	}
+}
+
+// Create godoc
+// @Summary Create a personal access token
+// @Description Creates an API key acting as the caller, limited to the given scopes. The key is only shown in this response. Send it as "Authorization: ApiKey <key>" or in the X-API-Key header.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param api-key body entity.APIKeyRequest true "API Key Request"
+// @Success 200 {object} response.DataResponse{data=entity.APIKeySecret} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /auth/api-keys [post]
+func (h APIKeyHTTPHandler) Create(ctx *gin.Context) {
+	request := entity.APIKeyRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	userID := h.GetUserID(ctx)
+	result, errException := h.APIKeyService.Create(ctx, &userID, &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// List godoc
+// @Summary List personal access tokens
+// @Description Lists the caller's API keys, including revoked and expired ones
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=[]entity.APIKey} "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/api-keys [get]
+func (h APIKeyHTTPHandler) List(ctx *gin.Context) {
+	userID := h.GetUserID(ctx)
+	result, errException := h.APIKeyService.List(ctx, &userID)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Revoke godoc
+// @Summary Revoke a personal access token
+// @Description Revokes one of the caller's API keys
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "API Key ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /auth/api-keys/{id} [delete]
+func (h APIKeyHTTPHandler) Revoke(ctx *gin.Context) {
+	userID := h.GetUserID(ctx)
+	if errException := h.APIKeyService.Revoke(ctx, &userID, ctx.Param("id")); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// CreateService godoc
+// @Summary Create a service account API key
+// @Description Creates an API key that belongs to no user and is granted exactly the given scopes. The key is only shown in this response.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param api-key body entity.APIKeyRequest true "API Key Request"
+// @Success 200 {object} response.DataResponse{data=entity.APIKeySecret} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/api-keys [post]
+func (h APIKeyHTTPHandler) CreateService(ctx *gin.Context) {
+	request := entity.APIKeyRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.APIKeyService.Create(ctx, nil, &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// ListService godoc
+// @Summary List service account API keys
+// @Description Lists the API keys that belong to no user
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=[]entity.APIKey} "success"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/api-keys [get]
+func (h APIKeyHTTPHandler) ListService(ctx *gin.Context) {
+	result, errException := h.APIKeyService.List(ctx, nil)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// RevokeService godoc
+// @Summary Revoke a service account API key
+// @Description Revokes an API key that belongs to no user
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "API Key ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/api-keys/{id} [delete]
+func (h APIKeyHTTPHandler) RevokeService(ctx *gin.Context) {
+	if errException := h.APIKeyService.Revoke(ctx, nil, ctx.Param("id")); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
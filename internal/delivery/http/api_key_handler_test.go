@@ -0,0 +1,100 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestAPIKeyHttpHandler_Create(t *testing.T) {
+	t.Run("Create Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAPIKeyService := new(mocks.APIKeyService)
+		apiKeyHandler := NewAPIKeyHTTPHandler(mockAPIKeyService)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		r.POST("/auth/api-keys", func(c *gin.Context) {
+			c.Set("user_id", userID)
+			apiKeyHandler.Create(c)
+		})
+
+		// Mock Data
+		requestBody := &entity.APIKeyRequest{Name: "nightly-export", Scopes: []string{entity.PermissionUsersRead}}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/api-keys", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAPIKeyService.On("Create", mock.Anything, &userID, requestBody).Return(&entity.APIKeySecret{
+			APIKey: entity.APIKey{Name: requestBody.Name, Scopes: requestBody.Scopes},
+			Key:    entity.APIKeyPrefix + "secret",
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), entity.APIKeyPrefix+"secret")
+		mockAPIKeyService.AssertExpectations(t)
+	})
+
+	t.Run("Create Error - Invalid JSON", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAPIKeyService := new(mocks.APIKeyService)
+		apiKeyHandler := NewAPIKeyHTTPHandler(mockAPIKeyService)
+
+		r.POST("/auth/api-keys", apiKeyHandler.Create)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/api-keys", bytes.NewBufferString(`{"invalid_json"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAPIKeyHttpHandler_RevokeService(t *testing.T) {
+	t.Run("RevokeService Not Found", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockAPIKeyService := new(mocks.APIKeyService)
+		apiKeyHandler := NewAPIKeyHTTPHandler(mockAPIKeyService)
+
+		r.DELETE("/admin/api-keys/:id", apiKeyHandler.RevokeService)
+
+		keyID := "223e4567-e89b-12d3-a456-426614174000"
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/admin/api-keys/"+keyID, nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockAPIKeyService.On("Revoke", mock.Anything, (*string)(nil), keyID).Return(exception.NotFound("api key not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockAPIKeyService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	_ "user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+)
+
+type AuditHTTPHandler struct {
+	Handler
+	AuditService service.AuditService
+}
+
+func NewAuditHTTPHandler(audit service.AuditService) *AuditHTTPHandler {
+	return &AuditHTTPHandler{
+		AuditService: audit,
+	}
+}
+
+// List godoc
+// @Summary List audit log entries
+// @Description Retrieves a paginated list of changes made to user and group records, newest first unless sorted otherwise. Password hashes are redacted from the changes.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param pageSize query string false "Number of items per page"
+// @Param page query string false "Page number"
+// @Param filter query string false "Filter rules<br><br>### Rules Filter<br>rule:<br>  * {Name of Field}:{value}:{Symbol}<br><br>Symbols:<br>  * eq (=)<br>  * lt (<)<br>  * gt (>)<br>  * lte (<=)<br>  * gte (>=)<br>  * in (in)<br>  * like (like)<br><br>Field list:<br>  * actor (actor user ID)<br>  * action<br>  * target (target record ID)<br>  * created_at"
+// @Param sort query string false "Sort rules:<br><br>### Rules Sort<br>rule:<br>  * {Name of Field}:{Symbol}<br><br>Symbols:<br>  * asc<br>  * desc<br><br>Field list:<br>  * actor<br>  * action<br>  * target<br>  * created_at"
+// @Success 200 {object} response.PaginationResponse{data=[]entity.AuditLog,pagination=model.Pagination} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/audit [get]
+func (h AuditHTTPHandler) List(ctx *gin.Context) {
+	var req model.ListReq
+	var err error
+	req.Page, req.Order, req.Filter, err = h.ParsePaginationParams(ctx)
+	if err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.AuditService.List(ctx, req)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestAuditHttpHandler_List(t *testing.T) {
+	t.Run("ListAudit Success", func(t *testing.T) {
+		r := gin.Default()
+		mockAuditService := new(mocks.AuditService)
+		auditHandler := NewAuditHTTPHandler(mockAuditService)
+
+		r.GET("/admin/audit", auditHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/admin/audit?filter=action:user.delete:eq&sort=created_at:asc", nil)
+		w := httptest.NewRecorder()
+
+		// Mock the service
+		mockAuditService.On("List", mock.Anything, mock.MatchedBy(func(req model.ListReq) bool {
+			return len(req.Filter) == 1 && req.Filter[0].Field == "action" && req.Filter[0].Value == entity.AuditUserDelete &&
+				req.Order.OrderBy == "created_at" && req.Order.Order == "asc"
+		})).Return(&service.ListAuditResp{}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("ListAudit Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockAuditService := new(mocks.AuditService)
+		auditHandler := NewAuditHTTPHandler(mockAuditService)
+
+		r.GET("/admin/audit", auditHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/admin/audit", nil)
+		w := httptest.NewRecorder()
+
+		// Mock the service
+		mockAuditService.On("List", mock.Anything, mock.Anything).Return(nil, exception.Internal("error", errors.New("test error")))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("ListAudit BadRequest Error", func(t *testing.T) {
+		r := gin.Default()
+		mockAuditService := new(mocks.AuditService)
+		auditHandler := NewAuditHTTPHandler(mockAuditService)
+
+		r.GET("/admin/audit", auditHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/admin/audit?filter=action:user.delete:error", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockAuditService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
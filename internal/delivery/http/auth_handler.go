@@ -0,0 +1,99 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type AuthHTTPHandler struct {
+	Handler
+	TokenService service.TokenService
+}
+
+func NewAuthHTTPHandler(token service.TokenService) *AuthHTTPHandler {
+	return &AuthHTTPHandler{
+		TokenService: token,
+	}
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchanges a refresh token for a new access token and a rotated refresh token. Replaying an already rotated refresh token revokes every token of its family.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param refresh body entity.RefreshTokenRequest true "Refresh Request"
+// @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/refresh [post]
+func (h AuthHTTPHandler) Refresh(ctx *gin.Context) {
+	request := entity.RefreshTokenRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.TokenService.Refresh(ctx, &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revokes the access token used for this request. When a refresh token is sent, its whole token family is revoked as well.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param logout body entity.LogoutRequest false "Logout Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/logout [post]
+func (h AuthHTTPHandler) Logout(ctx *gin.Context) {
+	request := entity.LogoutRequest{}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			h.BadRequestJSON(ctx, err.Error())
+			return
+		}
+	}
+	auth := h.GetAuthentication(ctx)
+	if auth == nil {
+		h.UnauthorizedJSON(ctx, "Invalid token")
+		return
+	}
+	if errException := h.TokenService.Logout(ctx, auth, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// RevokeUserSessions godoc
+// @Summary Revoke all sessions of a user
+// @Description Invalidates every access and refresh token issued to the user so far
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/sessions [delete]
+func (h AuthHTTPHandler) RevokeUserSessions(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if errException := h.TokenService.RevokeUserSessions(ctx, idParam); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestAuthHttpHandler_Refresh(t *testing.T) {
+	t.Run("Refresh Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/refresh", authHandler.Refresh)
+
+		// Mock Data
+		requestBody := &entity.RefreshTokenRequest{RefreshToken: "refresh_token"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		expectedResponse := &service.UserLoginResponse{
+			Username:     "john_doe",
+			Token:        "jwt_token",
+			RefreshToken: "rotated_refresh_token",
+		}
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockTokenService.On("Refresh", mock.Anything, requestBody).Return(expectedResponse, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Refresh Error - Invalid JSON", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/refresh", authHandler.Refresh)
+
+		// Malformed JSON
+		req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBufferString(`{"invalid_json"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Refresh Error - Token Reused", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/refresh", authHandler.Refresh)
+
+		// Mock Data
+		requestBody := &entity.RefreshTokenRequest{RefreshToken: "rotated_refresh_token"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call with error
+		mockTokenService.On("Refresh", mock.Anything, requestBody).Return(nil, exception.Unauthenticated("refresh token reuse detected, please login again"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHttpHandler_Logout(t *testing.T) {
+	auth := &signature.JwtAuthenticationRes{
+		Username: "john_doe",
+		Subject:  "123e4567-e89b-12d3-a456-426614174000",
+		TokenID:  "6f1d2c3b-4a5e-4f60-8a7b-9c0d1e2f3a4b",
+	}
+
+	t.Run("Logout Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/logout", func(ctx *gin.Context) {
+			ctx.Set("authentication", auth)
+		}, authHandler.Logout)
+
+		// Mock Data
+		requestBody := &entity.LogoutRequest{RefreshToken: "refresh_token"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/auth/logout", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockTokenService.On("Logout", mock.Anything, auth, requestBody).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Logout Without Body Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/logout", func(ctx *gin.Context) {
+			ctx.Set("authentication", auth)
+		}, authHandler.Logout)
+
+		req, _ := http.NewRequest("POST", "/auth/logout", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockTokenService.On("Logout", mock.Anything, auth, &entity.LogoutRequest{}).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Logout Error - Unauthenticated", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.POST("/auth/logout", authHandler.Logout)
+
+		req, _ := http.NewRequest("POST", "/auth/logout", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHttpHandler_RevokeUserSessions(t *testing.T) {
+	t.Run("RevokeUserSessions Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.DELETE("/admin/users/:id/sessions", authHandler.RevokeUserSessions)
+
+		req, _ := http.NewRequest("DELETE", "/admin/users/123e4567-e89b-12d3-a456-426614174000/sessions", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockTokenService.On("RevokeUserSessions", mock.Anything, "123e4567-e89b-12d3-a456-426614174000").Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RevokeUserSessions Error - Not Found", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockTokenService := new(mocks.TokenService)
+		authHandler := NewAuthHTTPHandler(mockTokenService)
+
+		r.DELETE("/admin/users/:id/sessions", authHandler.RevokeUserSessions)
+
+		req, _ := http.NewRequest("DELETE", "/admin/users/123e4567-e89b-12d3-a456-426614174000/sessions", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call with error
+		mockTokenService.On("RevokeUserSessions", mock.Anything, "123e4567-e89b-12d3-a456-426614174000").Return(exception.NotFound("user not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+const (
+	// federationStateCookie binds a login to the browser that started it
+	federationStateCookie = "oidc_state"
+	federationCookiePath  = "/auth/oidc"
+	federationCookieAge   = 600
+)
+
+type FederationHTTPHandler struct {
+	Handler
+	FederationService service.FederationService
+}
+
+func NewFederationHTTPHandler(federation service.FederationService) *FederationHTTPHandler {
+	return &FederationHTTPHandler{
+		FederationService: federation,
+	}
+}
+
+// Providers godoc
+// @Summary List identity providers
+// @Description Lists the names of the external OpenID Connect providers users can sign in with
+// @Tags Users
+// @Produce json
+// @Success 200 {object} response.DataResponse{data=[]string} "success"
+// @Router /auth/oidc/providers [get]
+func (h FederationHTTPHandler) Providers(ctx *gin.Context) {
+	h.DataJSON(ctx, h.FederationService.Providers())
+}
+
+// Login godoc
+// @Summary Sign in with an identity provider
+// @Description Redirects the browser to the provider's login page. The login must be completed by the same browser, which is tracked with a short lived cookie.
+// @Tags Users
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 302 "redirect to the provider"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /auth/oidc/{provider}/login [get]
+func (h FederationHTTPHandler) Login(ctx *gin.Context) {
+	location, state, errException := h.FederationService.Begin(ctx, ctx.Param("provider"))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	h.setStateCookie(ctx, state, federationCookieAge)
+
+	ctx.Redirect(http.StatusFound, location)
+}
+
+// Callback godoc
+// @Summary Identity provider callback
+// @Description Completes a login started at /auth/oidc/{provider}/login. The provider account is linked to the user with the same verified email, or a new user is created for it, and tokens are issued as on a password login.
+// @Tags Users
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param state query string true "State sent to the provider"
+// @Param code query string false "Authorization code"
+// @Param error query string false "Error returned by the provider"
+// @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /auth/oidc/{provider}/callback [get]
+func (h FederationHTTPHandler) Callback(ctx *gin.Context) {
+	request := entity.FederatedCallbackRequest{}
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	state, _ := ctx.Cookie(federationStateCookie)
+	h.setStateCookie(ctx, "", -1)
+	result, errException := h.FederationService.Complete(ctx, ctx.Param("provider"), &request, state, h.GetClientInfo(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// setStateCookie uses SameSite=Lax so the cookie is still sent when the
+// provider redirects the browser back.
+func (h FederationHTTPHandler) setStateCookie(ctx *gin.Context, value string, maxAge int) {
+	secure := ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https"
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(federationStateCookie, value, maxAge, federationCookiePath, "", secure, true)
+}
@@ -0,0 +1,296 @@
+package http
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/oidc"
+	"user-simple-crud/pkg/signature"
+)
+
+// federationHandlerMocks are the dependencies of a real federation service,
+// so the tests cover the state cookie round trip end to end.
+type federationHandlerMocks struct {
+	sql            sqlmock.Sqlmock
+	userRepo       *mocks.UserRepository
+	federationRepo *mocks.FederationRepository
+	tokenService   *mocks.TokenService
+	provider       *mocksSignature.Provider
+}
+
+func setupFederationHandler(t *testing.T) (*gin.Engine, *federationHandlerMocks) {
+	db, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	m := &federationHandlerMocks{
+		sql:            mockSql,
+		userRepo:       new(mocks.UserRepository),
+		federationRepo: new(mocks.FederationRepository),
+		tokenService:   new(mocks.TokenService),
+		provider:       new(mocksSignature.Provider),
+	}
+	federationService := service.NewFederationService(gormDB, m.userRepo, m.federationRepo, m.tokenService,
+		map[string]oidc.Provider{"corp": m.provider}, 10*time.Minute)
+	federationHandler := NewFederationHTTPHandler(federationService)
+
+	r := gin.Default()
+	r.GET("/auth/oidc/:provider/login", federationHandler.Login)
+	r.GET("/auth/oidc/:provider/callback", federationHandler.Callback)
+	return r, m
+}
+
+// callbackRequest returns the request the provider redirects the browser to,
+// carrying cookieState in the state cookie when it is set.
+func callbackRequest(queryState, cookieState string) *http.Request {
+	query := url.Values{"state": {queryState}, "code": {"authorization_code"}}
+	req, _ := http.NewRequest("GET", "/auth/oidc/corp/callback?"+query.Encode(), nil)
+	if cookieState != "" {
+		req.AddCookie(&http.Cookie{Name: federationStateCookie, Value: cookieState})
+	}
+	return req
+}
+
+func stateCookie(w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == federationStateCookie {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestFederationHttpHandler_Login(t *testing.T) {
+	t.Run("Login Success", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+		var stored *entity.FederatedLoginState
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/oidc/corp/login", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		m.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return("https://login.example.com/authorize?client_id=user-simple-crud", nil)
+		m.federationRepo.On("CreateStateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(state *entity.FederatedLoginState) bool {
+			stored = state
+			return true
+		})).Return(nil)
+		m.sql.ExpectBegin()
+		m.sql.ExpectCommit()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://login.example.com/authorize?client_id=user-simple-crud", w.Header().Get("Location"))
+		cookie := stateCookie(w)
+		require.NotNil(t, cookie)
+		assert.True(t, cookie.HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		assert.Equal(t, federationCookiePath, cookie.Path)
+		require.NotNil(t, stored)
+		assert.Equal(t, signature.HashToken(cookie.Value), stored.StateHash)
+	})
+
+	t.Run("Login Error - Unknown Provider", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/oidc/other/login", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Nil(t, stateCookie(w))
+		m.federationRepo.AssertNotCalled(t, "CreateStateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFederationHttpHandler_Callback(t *testing.T) {
+	state := "kq3V8nX0bW2rT5yL9cE1mH4pJ7sA6dF0gK2zU8iO3wQ"
+	newLoginState := func() *entity.FederatedLoginState {
+		return &entity.FederatedLoginState{
+			Id:           "6f1d2c3b-4a5e-4f60-8b7a-9c0d1e2f3a4b",
+			StateHash:    signature.HashToken(state),
+			Provider:     "corp",
+			Nonce:        "n-0S6_WzA2Mj",
+			CodeVerifier: "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+			ExpiresAt:    time.Now().Add(5 * time.Minute),
+		}
+	}
+	claims := &oidc.IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "corp-248289761001"},
+		Email:            "jane@example.com",
+		EmailVerified:    true,
+	}
+
+	t.Run("Callback Links Existing Account", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+		loginState := newLoginState()
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "jane_doe", Email: claims.Email}
+
+		// Create HTTP GET request
+		req := callbackRequest(state, state)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		m.federationRepo.On("FindStateByHash", mock.Anything, mock.Anything, loginState.StateHash).Return(loginState, nil)
+		m.federationRepo.On("DeleteStateTx", mock.Anything, mock.Anything, loginState.Id).Return(true, nil)
+		m.provider.On("Exchange", mock.Anything, "authorization_code", loginState.CodeVerifier, loginState.Nonce).Return(claims, nil)
+		m.federationRepo.On("FindIdentity", mock.Anything, mock.Anything, "corp", claims.Subject).Return(nil, nil)
+		m.userRepo.On("FindByName", mock.Anything, mock.Anything, "email", claims.Email).Return(user, nil)
+		m.federationRepo.On("CreateTx", mock.Anything, mock.Anything, mock.MatchedBy(func(identity *entity.FederatedIdentity) bool {
+			return identity.UserId == user.Id && identity.Provider == "corp" && identity.Subject == claims.Subject
+		})).Return(nil)
+		m.tokenService.On("Issue", mock.Anything, user, mock.Anything).
+			Return(&service.UserLoginResponse{Username: user.Username, Token: "jwt_token"}, nil)
+		m.sql.ExpectBegin()
+		m.sql.ExpectCommit()
+		m.sql.ExpectBegin()
+		m.sql.ExpectCommit()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"jwt_token"`)
+		m.federationRepo.AssertExpectations(t)
+		assert.NoError(t, m.sql.ExpectationsWereMet())
+		m.userRepo.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+		cookie := stateCookie(w)
+		require.NotNil(t, cookie)
+		assert.Empty(t, cookie.Value)
+		assert.Negative(t, cookie.MaxAge)
+	})
+
+	t.Run("Callback Error - Missing State Cookie", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+
+		// Create HTTP GET request
+		req := callbackRequest(state, "")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		m.federationRepo.AssertNotCalled(t, "FindStateByHash", mock.Anything, mock.Anything, mock.Anything)
+		m.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback Error - State From Another Browser", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+
+		// Create HTTP GET request
+		req := callbackRequest(state, "Zx9Lm2Qw8Er4Ty6Ui1Op3As5Df7Gh0Jk2Lz4Xc6Vb8N")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		m.federationRepo.AssertNotCalled(t, "FindStateByHash", mock.Anything, mock.Anything, mock.Anything)
+		m.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback Error - Expired State", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+		loginState := newLoginState()
+		loginState.ExpiresAt = time.Now().Add(-time.Minute)
+
+		// Create HTTP GET request
+		req := callbackRequest(state, state)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		m.federationRepo.On("FindStateByHash", mock.Anything, mock.Anything, loginState.StateHash).Return(loginState, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		m.federationRepo.AssertNotCalled(t, "DeleteStateTx", mock.Anything, mock.Anything, mock.Anything)
+		m.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback Error - State Already Used", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+		loginState := newLoginState()
+
+		// Create HTTP GET request
+		req := callbackRequest(state, state)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		m.federationRepo.On("FindStateByHash", mock.Anything, mock.Anything, loginState.StateHash).Return(loginState, nil)
+		m.federationRepo.On("DeleteStateTx", mock.Anything, mock.Anything, loginState.Id).Return(false, nil)
+		m.sql.ExpectBegin()
+		m.sql.ExpectRollback()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		m.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Callback Error - Nonce Mismatch", func(t *testing.T) {
+		// Setup
+		r, m := setupFederationHandler(t)
+		loginState := newLoginState()
+
+		// Create HTTP GET request
+		req := callbackRequest(state, state)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		m.federationRepo.On("FindStateByHash", mock.Anything, mock.Anything, loginState.StateHash).Return(loginState, nil)
+		m.federationRepo.On("DeleteStateTx", mock.Anything, mock.Anything, loginState.Id).Return(true, nil)
+		m.provider.On("Exchange", mock.Anything, "authorization_code", loginState.CodeVerifier, loginState.Nonce).
+			Return(nil, errors.New("oidc: ID token nonce does not match"))
+		m.sql.ExpectBegin()
+		m.sql.ExpectCommit()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotContains(t, w.Body.String(), "jwt_token")
+		m.provider.AssertExpectations(t)
+		m.federationRepo.AssertNotCalled(t, "FindIdentity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.tokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
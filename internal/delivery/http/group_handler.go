@@ -0,0 +1,203 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+)
+
+type GroupHTTPHandler struct {
+	Handler
+	GroupService service.GroupService
+}
+
+func NewGroupHTTPHandler(group service.GroupService) *GroupHTTPHandler {
+	return &GroupHTTPHandler{
+		GroupService: group,
+	}
+}
+
+// Create godoc
+// @Summary Create a group
+// @Description Creates a group of users. Group names are unique regardless of case.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param group body entity.GroupRequest true "Group Request"
+// @Success 200 {object} response.DataResponse{data=entity.Group} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 409 {object} response.DataResponse "error"
+// @Router /groups [post]
+func (h GroupHTTPHandler) Create(ctx *gin.Context) {
+	request := entity.GroupRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.GroupService.Create(ctx, &request, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// List godoc
+// @Summary List groups
+// @Description Retrieves a paginated list of groups with optional ordering and filtering. The members of a group are listed by /users with the filter group:{id}:eq.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param pageSize query string false "Number of items per page"
+// @Param page query string false "Page number"
+// @Param filter query string false "Filter rules<br><br>### Rules Filter<br>rule:<br>  * {Name of Field}:{value}:{Symbol}<br><br>Symbols:<br>  * eq (=)<br>  * lt (<)<br>  * gt (>)<br>  * lte (<=)<br>  * gte (>=)<br>  * in (in)<br>  * like (like)<br><br>Field list:<br>  * id<br>  * name<br>  * created_at"
+// @Param sort query string false "Sort rules:<br><br>### Rules Sort<br>rule:<br>  * {Name of Field}:{Symbol}<br><br>Symbols:<br>  * asc<br>  * desc<br><br>Field list:<br>  * id<br>  * name<br>  * created_at"
+// @Success 200 {object} response.PaginationResponse{data=[]entity.Group,pagination=model.Pagination} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /groups [get]
+func (h GroupHTTPHandler) List(ctx *gin.Context) {
+	var req model.ListReq
+	var err error
+	req.Page, req.Order, req.Filter, err = h.ParsePaginationParams(ctx)
+	if err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.GroupService.List(ctx, req)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// FindOne godoc
+// @Summary Get a group
+// @Description Retrieves a group by ID
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Group ID (UUID format)"
+// @Success 200 {object} response.DataResponse{data=entity.Group} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /groups/{id} [get]
+func (h GroupHTTPHandler) FindOne(ctx *gin.Context) {
+	result, errException := h.GroupService.FindOne(ctx, ctx.Param("id"))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Update godoc
+// @Summary Update a group
+// @Description Replaces the name and description of a group
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Group ID (UUID format)"
+// @Param group body entity.GroupRequest true "Group Request"
+// @Success 200 {object} response.DataResponse{data=entity.Group} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Failure 409 {object} response.DataResponse "error"
+// @Router /groups/{id} [put]
+func (h GroupHTTPHandler) Update(ctx *gin.Context) {
+	request := entity.GroupRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.GroupService.Update(ctx, ctx.Param("id"), &request, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Delete godoc
+// @Summary Delete a group
+// @Description Deletes a group and its memberships. The members themselves are kept.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Group ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.SuccessResponse "error"
+// @Failure 404 {object} response.SuccessResponse "error"
+// @Router /groups/{id} [delete]
+func (h GroupHTTPHandler) Delete(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if errException := h.GroupService.Delete(ctx, idParam, h.GetAuditActor(ctx)); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessMessageJSON(ctx, idParam+" has been deleted")
+}
+
+// AddMember godoc
+// @Summary Add a user to a group
+// @Description Makes the user a member of the group. Adding a member twice changes nothing.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Group ID (UUID format)"
+// @Param member body entity.GroupMemberRequest true "Group Member Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.SuccessResponse "error"
+// @Failure 404 {object} response.SuccessResponse "error"
+// @Router /groups/{id}/members [post]
+func (h GroupHTTPHandler) AddMember(ctx *gin.Context) {
+	request := entity.GroupMemberRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.GroupService.AddMember(ctx, ctx.Param("id"), &request, h.GetAuditActor(ctx)); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// RemoveMember godoc
+// @Summary Remove a user from a group
+// @Description Ends the user's membership of the group. Removing a user that isn't a member changes nothing.
+// @Tags Groups
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Group ID (UUID format)"
+// @Param userId path string true "User ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.SuccessResponse "error"
+// @Failure 404 {object} response.SuccessResponse "error"
+// @Router /groups/{id}/members/{userId} [delete]
+func (h GroupHTTPHandler) RemoveMember(ctx *gin.Context) {
+	errException := h.GroupService.RemoveMember(ctx, ctx.Param("id"), ctx.Param("userId"), h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestGroupHttpHandler_Create(t *testing.T) {
+	t.Run("CreateGroup Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.POST("/groups", groupHandler.Create)
+
+		// Prepare request data
+		requestBody := &entity.GroupRequest{Name: "engineering", Description: "Everyone working on the product"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/groups", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockGroupService.On("Create", mock.Anything, requestBody, mock.Anything).
+			Return(&entity.Group{Id: "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", Name: requestBody.Name}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockGroupService.AssertExpectations(t)
+	})
+
+	t.Run("CreateGroup Name Taken", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.POST("/groups", groupHandler.Create)
+
+		// Prepare request data
+		requestBody := &entity.GroupRequest{Name: "engineering"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/groups", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockGroupService.On("Create", mock.Anything, requestBody, mock.Anything).Return(nil, exception.Conflict("group name already exists"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestGroupHttpHandler_List(t *testing.T) {
+	t.Run("ListGroup Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.GET("/groups", groupHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/groups?filter=name:eng:like&sort=name:asc", nil)
+		w := httptest.NewRecorder()
+
+		// Mock the service
+		mockGroupService.On("List", mock.Anything, mock.MatchedBy(func(req model.ListReq) bool {
+			return len(req.Filter) == 1 && req.Filter[0].Field == "name" && req.Order.OrderBy == "name"
+		})).Return(&service.ListGroupResp{}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockGroupService.AssertExpectations(t)
+	})
+}
+
+func TestGroupHttpHandler_FindOne(t *testing.T) {
+	t.Run("FindOneGroup Not Found", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.GET("/groups/:id", groupHandler.FindOne)
+
+		// Mock the service
+		id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+		mockGroupService.On("FindOne", mock.Anything, id).Return(nil, exception.NotFound("group not found"))
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/groups/"+id, nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGroupHttpHandler_Update(t *testing.T) {
+	t.Run("UpdateGroup Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.PUT("/groups/:id", groupHandler.Update)
+
+		// Prepare request data
+		id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+		requestBody := &entity.GroupRequest{Name: "platform"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("PUT", "/groups/"+id, bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockGroupService.On("Update", mock.Anything, id, requestBody, mock.Anything).Return(&entity.Group{Id: id, Name: "platform"}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UpdateGroup Binding JSON Error", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.PUT("/groups/:id", groupHandler.Update)
+
+		req, _ := http.NewRequest("PUT", "/groups/3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockGroupService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGroupHttpHandler_Delete(t *testing.T) {
+	t.Run("DeleteGroup Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.DELETE("/groups/:id", groupHandler.Delete)
+
+		// Mock the service
+		id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+		mockGroupService.On("Delete", mock.Anything, id, mock.Anything).Return(nil)
+
+		req, _ := http.NewRequest("DELETE", "/groups/"+id, nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockGroupService.AssertExpectations(t)
+	})
+}
+
+func TestGroupHttpHandler_Members(t *testing.T) {
+	id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("AddMember Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.POST("/groups/:id/members", groupHandler.AddMember)
+
+		// Prepare request data
+		requestBody := &entity.GroupMemberRequest{UserId: userID}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/groups/"+id+"/members", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockGroupService.On("AddMember", mock.Anything, id, requestBody, mock.Anything).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockGroupService.AssertExpectations(t)
+	})
+
+	t.Run("AddMember User Not Found", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.POST("/groups/:id/members", groupHandler.AddMember)
+
+		// Prepare request data
+		requestBody := &entity.GroupMemberRequest{UserId: userID}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/groups/"+id+"/members", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockGroupService.On("AddMember", mock.Anything, id, requestBody, mock.Anything).Return(exception.NotFound("user not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("RemoveMember Success", func(t *testing.T) {
+		r := gin.Default()
+		mockGroupService := new(mocks.GroupService)
+		groupHandler := NewGroupHTTPHandler(mockGroupService)
+
+		r.DELETE("/groups/:id/members/:userId", groupHandler.RemoveMember)
+
+		// Mock the service
+		mockGroupService.On("RemoveMember", mock.Anything, id, userID, mock.Anything).Return(nil)
+
+		req, _ := http.NewRequest("DELETE", "/groups/"+id+"/members/"+userID, nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockGroupService.AssertExpectations(t)
+	})
+}
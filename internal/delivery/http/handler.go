@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	sloggin "github.com/samber/slog-gin"
 	"io"
+	"math"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 	"user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/model"
 	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
 )
 
 const (
@@ -108,6 +112,9 @@ func (h *Handler) DataJSON(c *gin.Context, data any) {
 	})
 }
 func (h *Handler) ExceptionJSON(e *gin.Context, exc *exception.Exception) {
+	if exc.RetryAfter > 0 {
+		e.Header("Retry-After", strconv.Itoa(int(math.Ceil(exc.RetryAfter.Seconds()))))
+	}
 	h.AbortJSON(e, &response.ErrorResponse{
 		ResponseCode:    exc.GetHttpCode(),
 		ResponseMessage: exc.Message,
@@ -135,11 +142,11 @@ func (h *Handler) UnauthorizedJSON(e *gin.Context, msg any, err ...any) {
 	h.ErrorJSON(e, 401, msg, err)
 }
 
-func (h *Handler) SignatureJSON(c *gin.Context, signature string) {
+func (h *Handler) SignatureJSON(c *gin.Context, signature *entity.RequestSignature) {
 	h.JSON(c, &response.DataResponse{
 		ResponseCode:    http.StatusOK,
 		ResponseMessage: "success",
-		Data:            &model.Signature{Signature: signature},
+		Data:            &model.Signature{Signature: signature.Signature, StringToSign: signature.StringToSign},
 	})
 }
 
@@ -156,7 +163,7 @@ func (h *Handler) ParseHTTPMethod(c *gin.Context) (string, string, error) {
 
 	// Validate HTTP method
 	if httpMethod != http.MethodPost && httpMethod != http.MethodGet &&
-		httpMethod != http.MethodPut && httpMethod != http.MethodDelete {
+		httpMethod != http.MethodPut && httpMethod != http.MethodPatch && httpMethod != http.MethodDelete {
 		return "", "", errors.New("http method invalid")
 	}
 
@@ -229,6 +236,66 @@ func (h *Handler) GetToken(c *gin.Context) string {
 	return c.GetString("access_token")
 }
 
+func (h *Handler) GetUserID(c *gin.Context) string {
+	return c.GetString("user_id")
+}
+
+// GetActorID returns the admin behind an impersonation token, or "" when the
+// caller acts as themselves.
+func (h *Handler) GetActorID(c *gin.Context) string {
+	return c.GetString("actor_id")
+}
+
+func (h *Handler) GetAuthentication(c *gin.Context) *signature.JwtAuthenticationRes {
+	auth, ok := c.Get("authentication")
+	if !ok {
+		return nil
+	}
+	res, _ := auth.(*signature.JwtAuthenticationRes)
+	return res
+}
+
+// GetClientInfo describes the client a login request comes from.
+func (h *Handler) GetClientInfo(c *gin.Context) entity.ClientInfo {
+	return entity.ClientInfo{
+		IpAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// GetAuditActor describes the caller of a request that changes a record. The
+// request ID is the one the request logger assigned and returned as X-Request-Id.
+func (h *Handler) GetAuditActor(c *gin.Context) entity.AuditActor {
+	return entity.AuditActor{
+		UserId:         h.GetUserID(c),
+		Username:       c.GetString("username"),
+		ImpersonatorId: h.GetActorID(c),
+		RequestId:      sloggin.GetRequestID(c),
+		ClientInfo:     h.GetClientInfo(c),
+	}
+}
+
+// SetETag sends a record's version as the strong entity tag of the response.
+func (h *Handler) SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ParseIfMatch returns the version a write is conditional on, 0 when If-Match
+// is missing or "*". Only a single entity tag as sent by SetETag is understood,
+// a list or a weak tag fails as a precondition that can never hold.
+func (h *Handler) ParseIfMatch(c *gin.Context) (int64, *exception.Exception) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+	if len(ifMatch) > 2 && strings.HasPrefix(ifMatch, `"`) && strings.HasSuffix(ifMatch, `"`) {
+		if version, err := strconv.ParseInt(ifMatch[1:len(ifMatch)-1], 10, 64); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, exception.PreconditionFailed("If-Match must be the ETag the record was read with")
+}
+
 func (h *Handler) ParseNameParam(c *gin.Context) (string, string) {
 	nameQuery := c.Query("name")
 	if nameQuery == "" {
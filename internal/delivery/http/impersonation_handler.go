@@ -0,0 +1,46 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	service "user-simple-crud/internal/services"
+)
+
+type ImpersonationHTTPHandler struct {
+	Handler
+	ImpersonationService service.ImpersonationService
+}
+
+func NewImpersonationHTTPHandler(impersonation service.ImpersonationService) *ImpersonationHTTPHandler {
+	return &ImpersonationHTTPHandler{
+		ImpersonationService: impersonation,
+	}
+}
+
+// Impersonate godoc
+// @Summary Impersonate a user
+// @Description Issues a short-lived access token that acts as the user, with the user's permissions and an act claim naming the admin. Every request made with it is logged. It can't change the user's password or MFA, mint API keys or authorize OAuth clients, and admins can't be impersonated.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Success 200 {object} response.DataResponse{data=service.ImpersonationResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/impersonate [post]
+func (h ImpersonationHTTPHandler) Impersonate(ctx *gin.Context) {
+	auth := h.GetAuthentication(ctx)
+	if auth == nil {
+		h.UnauthorizedJSON(ctx, "Invalid token")
+		return
+	}
+	result, errException := h.ImpersonationService.Impersonate(ctx, auth, ctx.Param("id"))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
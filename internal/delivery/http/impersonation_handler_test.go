@@ -0,0 +1,79 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestImpersonationHttpHandler_Impersonate(t *testing.T) {
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	auth := &signature.JwtAuthenticationRes{Subject: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33", Username: "admin"}
+
+	t.Run("Impersonate Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockImpersonationService := new(mocks.ImpersonationService)
+		impersonationHandler := NewImpersonationHTTPHandler(mockImpersonationService)
+
+		r.POST("/admin/users/:id/impersonate", func(c *gin.Context) {
+			c.Set("authentication", auth)
+			impersonationHandler.Impersonate(c)
+		})
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/admin/users/"+userID+"/impersonate", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockImpersonationService.On("Impersonate", mock.Anything, auth, userID).Return(&service.ImpersonationResponse{
+			UserId:    userID,
+			Username:  "john_doe",
+			ActorId:   auth.Subject,
+			Token:     "impersonation_token",
+			ExpiresAt: time.Now().Add(15 * time.Minute),
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"impersonation_token"`)
+		mockImpersonationService.AssertExpectations(t)
+	})
+
+	t.Run("Impersonate Error - Admin Target", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockImpersonationService := new(mocks.ImpersonationService)
+		impersonationHandler := NewImpersonationHTTPHandler(mockImpersonationService)
+
+		r.POST("/admin/users/:id/impersonate", func(c *gin.Context) {
+			c.Set("authentication", auth)
+			impersonationHandler.Impersonate(c)
+		})
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/admin/users/"+userID+"/impersonate", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockImpersonationService.On("Impersonate", mock.Anything, auth, userID).
+			Return(nil, exception.PermissionDenied("admins can't be impersonated"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
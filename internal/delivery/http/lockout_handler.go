@@ -0,0 +1,41 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	service "user-simple-crud/internal/services"
+)
+
+type LockoutHTTPHandler struct {
+	Handler
+	LockoutService service.LockoutService
+}
+
+func NewLockoutHTTPHandler(lockout service.LockoutService) *LockoutHTTPHandler {
+	return &LockoutHTTPHandler{
+		LockoutService: lockout,
+	}
+}
+
+// Unlock godoc
+// @Summary Unlock a user's account
+// @Description Lifts a lock caused by failed logins and clears the account's failure count. Throttling of the client IP is left in place.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/lock [delete]
+func (h LockoutHTTPHandler) Unlock(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if errException := h.LockoutService.Unlock(ctx, idParam); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestLockoutHttpHandler_Unlock(t *testing.T) {
+	t.Run("Unlock Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockLockoutService := new(mocks.LockoutService)
+		lockoutHandler := NewLockoutHTTPHandler(mockLockoutService)
+
+		r.DELETE("/admin/users/:id/lock", lockoutHandler.Unlock)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/admin/users/"+userID+"/lock", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockLockoutService.On("Unlock", mock.Anything, userID).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockLockoutService.AssertExpectations(t)
+	})
+
+	t.Run("Unlock Error - User Not Found", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockLockoutService := new(mocks.LockoutService)
+		lockoutHandler := NewLockoutHTTPHandler(mockLockoutService)
+
+		r.DELETE("/admin/users/:id/lock", lockoutHandler.Unlock)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/admin/users/"+userID+"/lock", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockLockoutService.On("Unlock", mock.Anything, userID).Return(exception.NotFound("user not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
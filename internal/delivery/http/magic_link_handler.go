@@ -0,0 +1,69 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type MagicLinkHTTPHandler struct {
+	Handler
+	MagicLinkService service.MagicLinkService
+}
+
+func NewMagicLinkHTTPHandler(magicLink service.MagicLinkService) *MagicLinkHTTPHandler {
+	return &MagicLinkHTTPHandler{
+		MagicLinkService: magicLink,
+	}
+}
+
+// Send godoc
+// @Summary Request a sign-in link
+// @Description Emails a single-use, time-limited sign-in link. The response is the same whether or not the address belongs to an account. Each address may only request a few links per window.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param magicLink body entity.MagicLinkRequest true "Magic Link Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 429 {object} response.DataResponse "error"
+// @Router /auth/magic-link [post]
+func (h MagicLinkHTTPHandler) Send(ctx *gin.Context) {
+	request := entity.MagicLinkRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.MagicLinkService.Send(ctx, &request); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// Consume godoc
+// @Summary Sign in with a link
+// @Description Redeems the token from a sign-in link. The response is the same as a password login: tokens, or an mfa_token when the user has MFA.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param consume body entity.ConsumeMagicLinkRequest true "Consume Magic Link Request"
+// @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Router /auth/magic-link/consume [post]
+func (h MagicLinkHTTPHandler) Consume(ctx *gin.Context) {
+	request := entity.ConsumeMagicLinkRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.MagicLinkService.Consume(ctx, &request, h.GetClientInfo(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestMagicLinkHttpHandler_Send(t *testing.T) {
+	t.Run("Send Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMagicLinkService := new(mocks.MagicLinkService)
+		magicLinkHandler := NewMagicLinkHTTPHandler(mockMagicLinkService)
+
+		r.POST("/auth/magic-link", magicLinkHandler.Send)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/magic-link", bytes.NewBufferString(`{"email":"john_doe@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMagicLinkService.On("Send", mock.Anything, &entity.MagicLinkRequest{Email: "john_doe@example.com"}).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockMagicLinkService.AssertExpectations(t)
+	})
+
+	t.Run("Send Error - Rate Limited", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMagicLinkService := new(mocks.MagicLinkService)
+		magicLinkHandler := NewMagicLinkHTTPHandler(mockMagicLinkService)
+
+		r.POST("/auth/magic-link", magicLinkHandler.Send)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/magic-link", bytes.NewBufferString(`{"email":"john_doe@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMagicLinkService.On("Send", mock.Anything, mock.Anything).
+			Return(exception.TooManyRequests("too many sign-in links requested for this address, try again later", 30*time.Minute))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "1800", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestMagicLinkHttpHandler_Consume(t *testing.T) {
+	t.Run("Consume Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMagicLinkService := new(mocks.MagicLinkService)
+		magicLinkHandler := NewMagicLinkHTTPHandler(mockMagicLinkService)
+
+		r.POST("/auth/magic-link/consume", magicLinkHandler.Consume)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/magic-link/consume", bytes.NewBufferString(`{"token":"Jm6cXl2pV0xq0E3q2"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMagicLinkService.On("Consume", mock.Anything, &entity.ConsumeMagicLinkRequest{Token: "Jm6cXl2pV0xq0E3q2"}, mock.Anything).
+			Return(&service.UserLoginResponse{Username: "john_doe", Token: "jwt_token"}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"jwt_token"`)
+		mockMagicLinkService.AssertExpectations(t)
+	})
+}
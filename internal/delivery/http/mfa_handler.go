@@ -0,0 +1,115 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type MFAHTTPHandler struct {
+	Handler
+	MFAService service.MFAService
+}
+
+func NewMFAHTTPHandler(mfa service.MFAService) *MFAHTTPHandler {
+	return &MFAHTTPHandler{
+		MFAService: mfa,
+	}
+}
+
+// Enroll godoc
+// @Summary Start MFA enrollment
+// @Description Creates a TOTP secret for the caller and returns it as an otpauth:// URI and a QR code PNG. MFA stays off until the first code is confirmed.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=entity.MFAEnrollment} "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Failure 409 {object} response.DataResponse "error"
+// @Router /auth/mfa/enroll [post]
+func (h MFAHTTPHandler) Enroll(ctx *gin.Context) {
+	result, errException := h.MFAService.Enroll(ctx, h.GetUserID(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Confirm godoc
+// @Summary Confirm MFA enrollment
+// @Description Enables MFA with the first code from the authenticator app and returns one-time recovery codes. The codes are not shown again.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param confirm body entity.MFACodeRequest true "MFA Code"
+// @Success 200 {object} response.DataResponse{data=entity.MFARecoveryCodes} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 409 {object} response.DataResponse "error"
+// @Router /auth/mfa/confirm [post]
+func (h MFAHTTPHandler) Confirm(ctx *gin.Context) {
+	request := entity.MFACodeRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.MFAService.Confirm(ctx, h.GetUserID(ctx), &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Verify godoc
+// @Summary Complete an MFA login
+// @Description Exchanges the mfa_token from /auth/login and a TOTP or recovery code for access and refresh tokens. The mfa_token is spent on the first attempt.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param verify body entity.MFAVerifyRequest true "MFA Verify Request"
+// @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/mfa/verify [post]
+func (h MFAHTTPHandler) Verify(ctx *gin.Context) {
+	request := entity.MFAVerifyRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.MFAService.Verify(ctx, &request, h.GetClientInfo(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Reset godoc
+// @Summary Reset a user's MFA
+// @Description Removes the user's TOTP secret and recovery codes so they can sign in with their password and enroll again
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/mfa [delete]
+func (h MFAHTTPHandler) Reset(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if errException := h.MFAService.Reset(ctx, idParam); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestMFAHttpHandler_Enroll(t *testing.T) {
+	t.Run("Enroll Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMFAService := new(mocks.MFAService)
+		mfaHandler := NewMFAHTTPHandler(mockMFAService)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		r.POST("/auth/mfa/enroll", func(c *gin.Context) { c.Set("user_id", userID) }, mfaHandler.Enroll)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/mfa/enroll", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMFAService.On("Enroll", mock.Anything, userID).Return(&entity.MFAEnrollment{
+			Secret:     "JBSWY3DPEHPK3PXP",
+			OtpauthURL: "otpauth://totp/user-simple-crud:john_doe?issuer=user-simple-crud&secret=JBSWY3DPEHPK3PXP",
+			QRCode:     "data:image/png;base64,iVBORw0KGgo=",
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "otpauth://")
+	})
+}
+
+func TestMFAHttpHandler_Confirm(t *testing.T) {
+	t.Run("Confirm Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMFAService := new(mocks.MFAService)
+		mfaHandler := NewMFAHTTPHandler(mockMFAService)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		r.POST("/auth/mfa/confirm", func(c *gin.Context) { c.Set("user_id", userID) }, mfaHandler.Confirm)
+
+		// Mock Data
+		requestBody := &entity.MFACodeRequest{Code: "123456"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/mfa/confirm", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMFAService.On("Confirm", mock.Anything, userID, requestBody).Return(&entity.MFARecoveryCodes{
+			RecoveryCodes: []string{"k7qzm-p2x4d"},
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestMFAHttpHandler_Verify(t *testing.T) {
+	t.Run("Verify Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMFAService := new(mocks.MFAService)
+		mfaHandler := NewMFAHTTPHandler(mockMFAService)
+
+		r.POST("/auth/mfa/verify", mfaHandler.Verify)
+
+		// Mock Data
+		requestBody := &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/mfa/verify", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMFAService.On("Verify", mock.Anything, requestBody, mock.Anything).Return(&service.UserLoginResponse{
+			Username:     "john_doe",
+			Token:        "jwt_token",
+			RefreshToken: "refresh_token",
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Verify Invalid Code", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMFAService := new(mocks.MFAService)
+		mfaHandler := NewMFAHTTPHandler(mockMFAService)
+
+		r.POST("/auth/mfa/verify", mfaHandler.Verify)
+
+		// Mock Data
+		requestBody := &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: "000000"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/mfa/verify", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMFAService.On("Verify", mock.Anything, requestBody, mock.Anything).Return(nil, exception.Unauthenticated("invalid code"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestMFAHttpHandler_Reset(t *testing.T) {
+	t.Run("Reset Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockMFAService := new(mocks.MFAService)
+		mfaHandler := NewMFAHTTPHandler(mockMFAService)
+
+		r.DELETE("/admin/users/:id/mfa", mfaHandler.Reset)
+
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/admin/users/"+userID+"/mfa", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockMFAService.On("Reset", mock.Anything, userID).Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockMFAService.AssertExpectations(t)
+	})
+}
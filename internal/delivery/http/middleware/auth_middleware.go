@@ -4,16 +4,44 @@ import (
 	"github.com/gin-gonic/gin"
 	"log/slog"
 	"strings"
-	"user-simple-crud/pkg/signature"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
 )
 
 type AuthMiddleware struct {
 	Middleware
-	signaturer signature.Signaturer
+	tokenService  service.TokenService
+	apiKeyService service.APIKeyService
 }
 
-func NewAuthMiddleware(signaturer signature.Signaturer) *AuthMiddleware {
-	return &AuthMiddleware{signaturer: signaturer}
+func NewAuthMiddleware(tokenService service.TokenService, apiKeyService service.APIKeyService) *AuthMiddleware {
+	return &AuthMiddleware{tokenService: tokenService, apiKeyService: apiKeyService}
+}
+
+// Authentication accepts an API key, sent as "Authorization: ApiKey <key>" or
+// in the X-API-Key header, and otherwise falls back to JWTAuthentication.
+func (m *AuthMiddleware) Authentication(c *gin.Context) {
+	key := c.GetHeader("X-API-Key")
+	authFields := strings.Fields(c.GetHeader("Authorization"))
+	if len(authFields) == 2 && strings.ToLower(authFields[0]) == "apikey" {
+		key = authFields[1]
+	}
+	if key == "" {
+		m.JWTAuthentication(c)
+		return
+	}
+
+	res, exception := m.apiKeyService.Authenticate(c, key)
+	if exception != nil {
+		m.ExceptionJSON(c, exception)
+		return
+	}
+
+	c.Set("username", res.Username)
+	c.Set("user_id", res.Subject)
+	c.Set("authentication", res)
+
+	c.Next()
 }
 
 func (m *AuthMiddleware) JWTAuthentication(c *gin.Context) {
@@ -25,16 +53,119 @@ func (m *AuthMiddleware) JWTAuthentication(c *gin.Context) {
 	}
 	token := authFields[1]
 
-	res, exception := m.signaturer.JWTCheck(token)
+	res, exception := m.tokenService.Authenticate(c, token)
 	if exception != nil {
 		m.ExceptionJSON(c, exception)
 		return
 	}
 
 	c.Set("username", res.Username)
+	c.Set("user_id", res.Subject)
 	c.Set("access_token", res.Token)
+	c.Set("session_id", res.SessionID)
+	c.Set("authentication", res)
+	if res.IsImpersonated() {
+		c.Set("actor_id", res.Actor.Subject)
+		c.Set("actor_username", res.Actor.Username)
+	}
 
 	c.Next()
+
+	if res.IsImpersonated() {
+		slog.Info("impersonated request",
+			"actor_id", res.Actor.Subject, "actor_username", res.Actor.Username,
+			"user_id", res.Subject, "token_id", res.TokenID,
+			"method", c.Request.Method, "path", c.Request.URL.Path,
+			"status", c.Writer.Status(), "client_ip", c.ClientIP())
+	}
+}
+
+// RequirePermission only lets the request through when the authenticated token
+// grants every listed permission. It must run after Authentication or
+// JWTAuthentication.
+func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := m.GetAuthentication(c)
+		if auth == nil {
+			m.UnauthorizedJSON(c, "Invalid token")
+			return
+		}
+		for _, permission := range permissions {
+			if !auth.HasPermission(permission) {
+				m.ExceptionJSON(c, exception.PermissionDenied("missing permission "+permission))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// FirstParty rejects access tokens issued to an OAuth client and API keys.
+// Their scopes may be narrower than the user's, so they must not reach
+// endpoints that mint or change the user's credentials. It must run after
+// Authentication or JWTAuthentication.
+func (m *AuthMiddleware) FirstParty(c *gin.Context) {
+	auth := m.GetAuthentication(c)
+	if auth == nil {
+		m.UnauthorizedJSON(c, "Invalid token")
+		return
+	}
+	if !auth.IsFirstParty() {
+		m.ExceptionJSON(c, exception.PermissionDenied("OAuth client tokens and API keys can't be used here"))
+		return
+	}
+	c.Next()
+}
+
+// NotImpersonating keeps impersonation tokens away from endpoints that change
+// the user's credentials or mint new ones that would outlive the
+// impersonation. It must run after Authentication or JWTAuthentication.
+func (m *AuthMiddleware) NotImpersonating(c *gin.Context) {
+	auth := m.GetAuthentication(c)
+	if auth == nil {
+		m.UnauthorizedJSON(c, "Invalid token")
+		return
+	}
+	if auth.IsImpersonated() {
+		m.ExceptionJSON(c, exception.PermissionDenied("not allowed while impersonating a user"))
+		return
+	}
+	c.Next()
+}
+
+// RequireSelf only lets the request through when the :id path parameter is the
+// authenticated user. It must run after Authentication or JWTAuthentication.
+func (m *AuthMiddleware) RequireSelf(c *gin.Context) {
+	auth := m.GetAuthentication(c)
+	if auth == nil {
+		m.UnauthorizedJSON(c, "Invalid token")
+		return
+	}
+	if auth.Subject == "" || auth.Subject != c.Param("id") {
+		m.ExceptionJSON(c, exception.PermissionDenied("only allowed on your own account"))
+		return
+	}
+	c.Next()
+}
+
+// RequireSelfOrPermission lets the authenticated user act on its own :id with
+// a first-party token, and anyone else only with permission. OAuth clients
+// and API keys always need the permission, so their scopes still apply. It
+// must run after Authentication or JWTAuthentication.
+func (m *AuthMiddleware) RequireSelfOrPermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth := m.GetAuthentication(c)
+		if auth == nil {
+			m.UnauthorizedJSON(c, "Invalid token")
+			return
+		}
+		self := auth.Subject != "" && auth.Subject == c.Param("id") && auth.IsFirstParty()
+		if !self && !auth.HasPermission(permission) {
+			m.ExceptionJSON(c, exception.PermissionDenied("missing permission "+permission))
+			return
+		}
+		c.Next()
+	}
 }
 
 func (m *AuthMiddleware) ErrorHandler(c *gin.Context) {
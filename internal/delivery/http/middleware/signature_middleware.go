@@ -0,0 +1,46 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type SignatureMiddleware struct {
+	Middleware
+	signatureService service.RequestSignatureService
+}
+
+func NewSignatureMiddleware(signatureService service.RequestSignatureService) *SignatureMiddleware {
+	return &SignatureMiddleware{signatureService: signatureService}
+}
+
+// VerifySignature only lets through requests signed with a partner secret,
+// sent as the X-Client-Id, X-Timestamp, X-Nonce and X-Signature headers. The
+// body is left in place for the handler.
+func (m *SignatureMiddleware) VerifySignature(c *gin.Context) {
+	method, body, err := m.ParseSignatureHTTPMethod(c)
+	if err != nil {
+		m.BadRequestJSON(c, err.Error())
+		return
+	}
+
+	clientID := c.GetHeader(entity.SignatureHeaderClientID)
+	exception := m.signatureService.Verify(c, &entity.SignedRequest{
+		ClientID:  clientID,
+		Timestamp: c.GetHeader(entity.SignatureHeaderTimestamp),
+		Nonce:     c.GetHeader(entity.SignatureHeaderNonce),
+		Signature: c.GetHeader(entity.SignatureHeaderSignature),
+		Method:    method,
+		Path:      c.Request.URL.RequestURI(),
+		Body:      []byte(body),
+	})
+	if exception != nil {
+		m.ExceptionJSON(c, exception)
+		return
+	}
+
+	c.Set("signature_client_id", clientID)
+
+	c.Next()
+}
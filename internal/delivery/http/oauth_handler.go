@@ -0,0 +1,253 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+type OAuthHTTPHandler struct {
+	Handler
+	OAuthService service.OAuthService
+}
+
+func NewOAuthHTTPHandler(oauth service.OAuthService) *OAuthHTTPHandler {
+	return &OAuthHTTPHandler{
+		OAuthService: oauth,
+	}
+}
+
+// Authorize godoc
+// @Summary OAuth2 authorization endpoint
+// @Description Checks the authorization request and redirects the browser to the consent page, which signs the user in and asks them to approve the client. The request is passed on in the query together with client_name, with scope resolved. Only the code response type with PKCE (S256) is supported. Errors about the client or redirect_uri are returned as JSON, every other error is sent to the redirect_uri.
+// @Tags OAuth
+// @Produce json
+// @Param response_type query string true "Must be code"
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string false "Registered redirect URI, optional when the client has only one"
+// @Param scope query string false "Space separated scopes, defaults to every scope of the client"
+// @Param state query string false "Opaque value returned to the client"
+// @Param code_challenge query string true "base64url SHA-256 of the code verifier"
+// @Param code_challenge_method query string true "Must be S256"
+// @Param nonce query string false "Value copied into the ID token"
+// @Success 302 "redirect to the consent page, or to the client with an error"
+// @Failure 400 {object} entity.OAuthError "error"
+// @Router /oauth/authorize [get]
+func (h OAuthHTTPHandler) Authorize(ctx *gin.Context) {
+	request := entity.AuthorizeRequest{}
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	location, errException := h.OAuthService.Authorize(ctx, &request)
+	if errException != nil {
+		h.OAuthErrorJSON(ctx, errException)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, location)
+}
+
+// Consent godoc
+// @Summary Answer an OAuth2 authorization request
+// @Description Called by the consent page with the signed in user's access token and the parameters it was opened with. Approving issues an authorization code, denying answers the client with access_denied. The page should send the browser to the returned redirect_uri either way. Errors about the client or redirect_uri are returned as JSON.
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param consent body entity.OAuthConsentRequest true "Authorization request and the user's answer"
+// @Success 200 {object} response.DataResponse{data=entity.OAuthConsentResponse} "success"
+// @Failure 400 {object} entity.OAuthError "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /oauth/authorize [post]
+func (h OAuthHTTPHandler) Consent(ctx *gin.Context) {
+	request := entity.OAuthConsentRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	location, errException := h.OAuthService.Consent(ctx, h.GetAuthentication(ctx), &request)
+	if errException != nil {
+		h.OAuthErrorJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, entity.OAuthConsentResponse{RedirectURI: location})
+}
+
+// Token godoc
+// @Summary OAuth2 token endpoint
+// @Description Exchanges an authorization code, a refresh token or the client credentials for tokens. Confidential clients authenticate with HTTP Basic or client_id and client_secret form fields, public clients send only client_id. Responses follow RFC 6749.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code, refresh_token or client_credentials"
+// @Param code formData string false "Authorization code"
+// @Param redirect_uri formData string false "Redirect URI the code was issued for, required when the authorization request included it"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Param refresh_token formData string false "Refresh token"
+// @Param scope formData string false "Space separated scopes"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200 {object} entity.OAuthTokenResponse "success"
+// @Failure 400 {object} entity.OAuthError "error"
+// @Failure 401 {object} entity.OAuthError "error"
+// @Router /oauth/token [post]
+func (h OAuthHTTPHandler) Token(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+	request := entity.OAuthTokenRequest{}
+	if err := ctx.ShouldBind(&request); err != nil {
+		h.OAuthErrorJSON(ctx, invalidOAuthRequest(err.Error()))
+		return
+	}
+	if clientID, clientSecret, ok := ctx.Request.BasicAuth(); ok {
+		// RFC 6749 section 2.3.1 form-encodes the credentials before Basic encoding
+		id, errID := url.QueryUnescape(clientID)
+		secret, errSecret := url.QueryUnescape(clientSecret)
+		if errID != nil || errSecret != nil {
+			h.OAuthErrorJSON(ctx, invalidOAuthRequest("malformed client credentials"))
+			return
+		}
+		if request.ClientSecret != "" || (request.ClientId != "" && request.ClientId != id) {
+			h.OAuthErrorJSON(ctx, invalidOAuthRequest("client credentials were sent in more than one way"))
+			return
+		}
+		request.ClientId, request.ClientSecret = id, secret
+	}
+	result, errException := h.OAuthService.Token(ctx, &request)
+	if errException != nil {
+		h.OAuthErrorJSON(ctx, errException)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// UserInfo godoc
+// @Summary OpenID Connect userinfo endpoint
+// @Description Returns the claims of the user the access token was issued to, for the scopes it was granted. The token must have the openid scope. The body is a plain claims object.
+// @Tags OAuth
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} entity.UserInfo "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /oauth/userinfo [get]
+// @Router /oauth/userinfo [post]
+func (h OAuthHTTPHandler) UserInfo(ctx *gin.Context) {
+	result, errException := h.OAuthService.UserInfo(ctx, h.GetAuthentication(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Discovery godoc
+// @Summary OpenID Connect discovery document
+// @Description Describes the endpoints, scopes and algorithms of this provider. The body is a plain OpenID Provider Metadata document.
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} entity.OpenIDConfiguration "success"
+// @Router /.well-known/openid-configuration [get]
+func (h OAuthHTTPHandler) Discovery(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.OAuthService.Discovery())
+}
+
+// RegisterClient godoc
+// @Summary Register an OAuth client
+// @Description Registers an application allowed to obtain tokens. Confidential clients get a client_secret that is only shown in this response; public clients get none and must use PKCE.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param client body entity.OAuthClientRequest true "OAuth Client Request"
+// @Success 200 {object} response.DataResponse{data=entity.OAuthClientSecret} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/oauth/clients [post]
+func (h OAuthHTTPHandler) RegisterClient(ctx *gin.Context) {
+	request := entity.OAuthClientRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.OAuthService.RegisterClient(ctx, &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// ListClients godoc
+// @Summary List OAuth clients
+// @Description Lists the registered OAuth clients
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=[]entity.OAuthClient} "success"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/oauth/clients [get]
+func (h OAuthHTTPHandler) ListClients(ctx *gin.Context) {
+	result, errException := h.OAuthService.ListClients(ctx)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// DeleteClient godoc
+// @Summary Delete an OAuth client
+// @Description Deletes an OAuth client and revokes the refresh tokens issued to it
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Client ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/oauth/clients/{id} [delete]
+func (h OAuthHTTPHandler) DeleteClient(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if errException := h.OAuthService.DeleteClient(ctx, idParam); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessMessageJSON(ctx, idParam+" has been deleted")
+}
+
+// OAuthErrorJSON answers with the RFC 6749 error body when the exception
+// carries one, and with the usual error response otherwise.
+func (h OAuthHTTPHandler) OAuthErrorJSON(ctx *gin.Context, exc *exception.Exception) {
+	var oauthErr *entity.OAuthError
+	if !errors.As(exc.Error, &oauthErr) {
+		h.ExceptionJSON(ctx, exc)
+		return
+	}
+	if oauthErr.Code == entity.OAuthInvalidClient {
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	ctx.AbortWithStatusJSON(exc.GetHttpCode(), oauthErr)
+}
+
+func invalidOAuthRequest(description string) *exception.Exception {
+	exc := exception.InvalidArgument(description)
+	exc.Error = &entity.OAuthError{Code: entity.OAuthInvalidRequest, Description: description}
+	return exc
+}
@@ -0,0 +1,227 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestOAuthHttpHandler_Authorize(t *testing.T) {
+	t.Run("Authorize Redirects To Client", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.GET("/oauth/authorize", oauthHandler.Authorize)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/oauth/authorize?response_type=code&client_id=0f8fad5b-d9cb-469f-a165-70867728950e&state=xyz", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockOAuthService.On("Authorize", mock.Anything, mock.MatchedBy(func(model *entity.AuthorizeRequest) bool {
+			return model.ResponseType == "code" && model.State == "xyz"
+		})).Return("https://id.example.com/oauth/consent?client_name=billing-portal&state=xyz", nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://id.example.com/oauth/consent?client_name=billing-portal&state=xyz", w.Header().Get("Location"))
+		mockOAuthService.AssertExpectations(t)
+	})
+}
+
+func TestOAuthHttpHandler_Consent(t *testing.T) {
+	t.Run("Consent Returns Client Redirect", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.POST("/oauth/authorize", oauthHandler.Consent)
+
+		// Create HTTP POST request
+		body := `{"response_type":"code","client_id":"0f8fad5b-d9cb-469f-a165-70867728950e","state":"xyz","approve":true}`
+		req, _ := http.NewRequest("POST", "/oauth/authorize", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockOAuthService.On("Consent", mock.Anything, mock.Anything, mock.MatchedBy(func(model *entity.OAuthConsentRequest) bool {
+			return model.Approve && model.ClientId == "0f8fad5b-d9cb-469f-a165-70867728950e" && model.State == "xyz"
+		})).Return("https://billing.example.com/callback?code=abc&state=xyz", nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code and body
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data entity.OAuthConsentResponse `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "https://billing.example.com/callback?code=abc&state=xyz", response.Data.RedirectURI)
+		mockOAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Consent Unknown Client", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.POST("/oauth/authorize", oauthHandler.Consent)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/oauth/authorize", strings.NewReader(`{"client_id":"unknown","approve":true}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		exc := exception.InvalidArgument("unknown client_id")
+		exc.Error = &entity.OAuthError{Code: entity.OAuthInvalidRequest, Description: "unknown client_id"}
+		mockOAuthService.On("Consent", mock.Anything, mock.Anything, mock.Anything).Return("", exc)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code and body
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var oauthErr entity.OAuthError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &oauthErr))
+		assert.Equal(t, entity.OAuthInvalidRequest, oauthErr.Code)
+	})
+}
+
+func TestOAuthHttpHandler_Token(t *testing.T) {
+	t.Run("Token Success With Basic Authentication", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.POST("/oauth/token", oauthHandler.Token)
+
+		// Create HTTP POST request
+		form := url.Values{"grant_type": {entity.GrantTypeClientCredentials}}
+		req, _ := http.NewRequest("POST", "/oauth/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("0f8fad5b-d9cb-469f-a165-70867728950e", url.QueryEscape("s3cr3t+/="))
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockOAuthService.On("Token", mock.Anything, mock.MatchedBy(func(model *entity.OAuthTokenRequest) bool {
+			return model.ClientId == "0f8fad5b-d9cb-469f-a165-70867728950e" && model.ClientSecret == "s3cr3t+/="
+		})).Return(&entity.OAuthTokenResponse{AccessToken: "access_token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		var body entity.OAuthTokenResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "access_token", body.AccessToken)
+		mockOAuthService.AssertExpectations(t)
+	})
+
+	t.Run("Token Error - OAuth Error Format", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.POST("/oauth/token", oauthHandler.Token)
+
+		// Create HTTP POST request
+		form := url.Values{
+			"grant_type":    {entity.GrantTypeAuthorizationCode},
+			"client_id":     {"0f8fad5b-d9cb-469f-a165-70867728950e"},
+			"client_secret": {"wrong"},
+		}
+		req, _ := http.NewRequest("POST", "/oauth/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		exc := exception.Unauthenticated("client authentication failed")
+		exc.Error = &entity.OAuthError{Code: entity.OAuthInvalidClient, Description: "client authentication failed"}
+		mockOAuthService.On("Token", mock.Anything, mock.Anything).Return(nil, exc)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+		assert.JSONEq(t, `{"error":"invalid_client","error_description":"client authentication failed"}`, w.Body.String())
+	})
+
+	t.Run("Token Error - Credentials Sent Twice", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.POST("/oauth/token", oauthHandler.Token)
+
+		// Create HTTP POST request
+		form := url.Values{"grant_type": {entity.GrantTypeClientCredentials}, "client_secret": {"s3cr3t"}}
+		req, _ := http.NewRequest("POST", "/oauth/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("0f8fad5b-d9cb-469f-a165-70867728950e", "s3cr3t")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), entity.OAuthInvalidRequest)
+		mockOAuthService.AssertNotCalled(t, "Token", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthHttpHandler_Discovery(t *testing.T) {
+	t.Run("Discovery Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockOAuthService := new(mocks.OAuthService)
+		oauthHandler := NewOAuthHTTPHandler(mockOAuthService)
+
+		r.GET("/.well-known/openid-configuration", oauthHandler.Discovery)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/.well-known/openid-configuration", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockOAuthService.On("Discovery").Return(&entity.OpenIDConfiguration{
+			Issuer:        "https://id.example.com",
+			TokenEndpoint: "https://id.example.com/oauth/token",
+		})
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "https://id.example.com", body["issuer"])
+		assert.Equal(t, "https://id.example.com/oauth/token", body["token_endpoint"])
+	})
+}
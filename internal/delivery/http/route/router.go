@@ -4,31 +4,142 @@ import (
 	"github.com/gin-gonic/gin"
 	"user-simple-crud/internal/delivery/http"
 	api "user-simple-crud/internal/delivery/http/middleware"
+	"user-simple-crud/internal/entity"
 )
 
 type Router struct {
-	App            *gin.Engine
-	UserHandler    *http.UserHTTPHandler
-	AuthMiddleware *api.AuthMiddleware
+	App                  *gin.Engine
+	UserHandler          *http.UserHTTPHandler
+	AuthHandler          *http.AuthHTTPHandler
+	MagicLinkHandler     *http.MagicLinkHTTPHandler
+	AccountHandler       *http.AccountHTTPHandler
+	MFAHandler           *http.MFAHTTPHandler
+	LockoutHandler       *http.LockoutHTTPHandler
+	APIKeyHandler        *http.APIKeyHTTPHandler
+	OAuthHandler         *http.OAuthHTTPHandler
+	FederationHandler    *http.FederationHTTPHandler
+	SessionHandler       *http.SessionHTTPHandler
+	ImpersonationHandler *http.ImpersonationHTTPHandler
+	SignatureHandler     *http.SignatureHTTPHandler
+	WebAuthnHandler      *http.WebAuthnHTTPHandler
+	AuditHandler         *http.AuditHTTPHandler
+	GroupHandler         *http.GroupHTTPHandler
+	WellKnown            *http.WellKnownHTTPHandler
+	AuthMiddleware       *api.AuthMiddleware
+	SignatureMiddleware  *api.SignatureMiddleware
 }
 
 func (h *Router) Setup() {
 	h.App.Use(h.AuthMiddleware.ErrorHandler)
+	can := h.AuthMiddleware.RequirePermission
+	selfOr := h.AuthMiddleware.RequireSelfOrPermission
+	h.App.GET("/.well-known/jwks.json", h.WellKnown.JWKS)
+	h.App.GET("/.well-known/openid-configuration", h.OAuthHandler.Discovery)
+	oauthApi := h.App.Group("/oauth")
+	{
+		oauthApi.GET("/authorize", h.OAuthHandler.Authorize)
+		oauthApi.POST("/authorize", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.OAuthHandler.Consent)
+		oauthApi.POST("/token", h.OAuthHandler.Token)
+		oauthApi.GET("/userinfo", h.AuthMiddleware.JWTAuthentication, h.OAuthHandler.UserInfo)
+		oauthApi.POST("/userinfo", h.AuthMiddleware.JWTAuthentication, h.OAuthHandler.UserInfo)
+	}
+	signatureApi := h.App.Group("/signature")
+	{
+		signatureApi.POST("/sandbox", h.SignatureHandler.Sandbox)
+		signatureApi.Any("/verify", h.SignatureMiddleware.VerifySignature, h.SignatureHandler.Verify)
+	}
 	guestApi := h.App.Group("/auth")
 	{
 		guestApi.POST("/register", h.UserHandler.Register)
 		guestApi.POST("/login", h.UserHandler.Login)
+		guestApi.GET("/verify-email", h.AccountHandler.VerifyEmail)
+		guestApi.POST("/verify-email", h.AccountHandler.VerifyEmail)
+		guestApi.POST("/resend-verification", h.AccountHandler.ResendVerification)
+		guestApi.POST("/forgot-password", h.AccountHandler.ForgotPassword)
+		guestApi.POST("/reset-password", h.AccountHandler.ResetPassword)
+		guestApi.POST("/change-expired-password", h.AccountHandler.ChangeExpiredPassword)
+		guestApi.POST("/magic-link", h.MagicLinkHandler.Send)
+		guestApi.POST("/magic-link/consume", h.MagicLinkHandler.Consume)
+		guestApi.POST("/refresh", h.AuthHandler.Refresh)
+		guestApi.POST("/logout", h.AuthMiddleware.JWTAuthentication, h.AuthHandler.Logout)
+		guestApi.GET("/me", h.AuthMiddleware.JWTAuthentication, h.UserHandler.Me)
+		oidcApi := guestApi.Group("/oidc")
+		{
+			oidcApi.GET("/providers", h.FederationHandler.Providers)
+			oidcApi.GET("/:provider/login", h.FederationHandler.Login)
+			oidcApi.GET("/:provider/callback", h.FederationHandler.Callback)
+		}
+		mfaApi := guestApi.Group("/mfa")
+		{
+			mfaApi.POST("/verify", h.MFAHandler.Verify)
+			mfaApi.POST("/enroll", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.MFAHandler.Enroll)
+			mfaApi.POST("/confirm", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.MFAHandler.Confirm)
+		}
+		sessionApi := guestApi.Group("/sessions")
+		sessionApi.Use(h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty)
+		{
+			sessionApi.GET("", h.SessionHandler.List)
+			sessionApi.DELETE("", h.SessionHandler.RevokeOthers)
+			sessionApi.DELETE("/:id", h.SessionHandler.Revoke)
+		}
+		apiKeyApi := guestApi.Group("/api-keys")
+		apiKeyApi.Use(h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty)
+		{
+			apiKeyApi.POST("", h.AuthMiddleware.NotImpersonating, h.APIKeyHandler.Create)
+			apiKeyApi.GET("", h.APIKeyHandler.List)
+			apiKeyApi.DELETE("/:id", h.APIKeyHandler.Revoke)
+		}
+		webAuthnApi := guestApi.Group("/webauthn")
+		{
+			webAuthnApi.POST("/login/begin", h.WebAuthnHandler.BeginLogin)
+			webAuthnApi.POST("/login/finish", h.WebAuthnHandler.FinishLogin)
+			webAuthnApi.POST("/register/begin", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.WebAuthnHandler.BeginRegistration)
+			webAuthnApi.POST("/register/finish", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.WebAuthnHandler.FinishRegistration)
+			webAuthnApi.GET("/credentials", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.WebAuthnHandler.List)
+			webAuthnApi.DELETE("/credentials/:id", h.AuthMiddleware.JWTAuthentication, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.WebAuthnHandler.Remove)
+		}
 	}
 	coreApi := h.App.Group("")
-	coreApi.Use(h.AuthMiddleware.JWTAuthentication)
+	coreApi.Use(h.AuthMiddleware.Authentication)
 	{
 		userApi := coreApi.Group("/users")
 		{
-			userApi.POST("", h.UserHandler.Create)
-			userApi.GET("", h.UserHandler.List)
-			userApi.GET("/:id", h.UserHandler.FindOne)
-			userApi.PUT("/:id", h.UserHandler.Update)
-			userApi.DELETE("/:id", h.UserHandler.Delete)
+			userApi.POST("", can(entity.PermissionUsersCreate), h.UserHandler.Create)
+			userApi.GET("", can(entity.PermissionUsersRead), h.UserHandler.List)
+			userApi.GET("/:id", can(entity.PermissionUsersRead), h.UserHandler.FindOne)
+			userApi.PUT("/:id", selfOr(entity.PermissionUsersUpdate), h.UserHandler.Update)
+			userApi.PATCH("/:id", selfOr(entity.PermissionUsersUpdate), h.UserHandler.Patch)
+			userApi.POST("/:id/password", h.AuthMiddleware.RequireSelf, h.AuthMiddleware.FirstParty, h.AuthMiddleware.NotImpersonating, h.UserHandler.ChangePassword)
+			userApi.DELETE("/:id", can(entity.PermissionUsersDelete), h.UserHandler.Delete)
+		}
+		groupApi := coreApi.Group("/groups")
+		{
+			groupApi.POST("", can(entity.PermissionGroupsManage), h.GroupHandler.Create)
+			groupApi.GET("", can(entity.PermissionGroupsRead), h.GroupHandler.List)
+			groupApi.GET("/:id", can(entity.PermissionGroupsRead), h.GroupHandler.FindOne)
+			groupApi.PUT("/:id", can(entity.PermissionGroupsManage), h.GroupHandler.Update)
+			groupApi.DELETE("/:id", can(entity.PermissionGroupsManage), h.GroupHandler.Delete)
+			groupApi.POST("/:id/members", can(entity.PermissionGroupsManage), h.GroupHandler.AddMember)
+			groupApi.DELETE("/:id/members/:userId", can(entity.PermissionGroupsManage), h.GroupHandler.RemoveMember)
+		}
+		adminApi := coreApi.Group("/admin")
+		{
+			adminApi.DELETE("/users/:id/sessions", can(entity.PermissionSessionsRevoke), h.AuthHandler.RevokeUserSessions)
+			adminApi.POST("/users/:id/roles", can(entity.PermissionRolesManage), h.UserHandler.AssignRole)
+			adminApi.DELETE("/users/:id/roles/:role", can(entity.PermissionRolesManage), h.UserHandler.RevokeRole)
+			adminApi.DELETE("/users/:id/mfa", can(entity.PermissionMFAReset), h.AuthMiddleware.NotImpersonating, h.MFAHandler.Reset)
+			adminApi.POST("/users/:id/impersonate", can(entity.PermissionUsersImpersonate), h.AuthMiddleware.FirstParty, h.ImpersonationHandler.Impersonate)
+			adminApi.DELETE("/users/:id/lock", can(entity.PermissionUsersUnlock), h.LockoutHandler.Unlock)
+			adminApi.POST("/users/:id/restore", can(entity.PermissionUsersRestore), h.UserHandler.Restore)
+			adminApi.POST("/users/:id/suspend", can(entity.PermissionUsersSuspend), h.AuthMiddleware.NotImpersonating, h.UserHandler.Suspend)
+			adminApi.POST("/users/:id/reactivate", can(entity.PermissionUsersSuspend), h.AuthMiddleware.NotImpersonating, h.UserHandler.Reactivate)
+			adminApi.GET("/audit", can(entity.PermissionAuditRead), h.AuditHandler.List)
+			adminApi.POST("/api-keys", can(entity.PermissionAPIKeysManage), h.APIKeyHandler.CreateService)
+			adminApi.GET("/api-keys", can(entity.PermissionAPIKeysManage), h.APIKeyHandler.ListService)
+			adminApi.DELETE("/api-keys/:id", can(entity.PermissionAPIKeysManage), h.APIKeyHandler.RevokeService)
+			adminApi.POST("/oauth/clients", can(entity.PermissionOAuthClientsManage), h.OAuthHandler.RegisterClient)
+			adminApi.GET("/oauth/clients", can(entity.PermissionOAuthClientsManage), h.OAuthHandler.ListClients)
+			adminApi.DELETE("/oauth/clients/:id", can(entity.PermissionOAuthClientsManage), h.OAuthHandler.DeleteClient)
 		}
 	}
 }
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	_ "user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type SessionHTTPHandler struct {
+	Handler
+	SessionService service.SessionService
+}
+
+func NewSessionHTTPHandler(session service.SessionService) *SessionHTTPHandler {
+	return &SessionHTTPHandler{
+		SessionService: session,
+	}
+}
+
+// List godoc
+// @Summary List sessions
+// @Description Lists the devices the caller is signed in from. The session of the token used for this request is marked as current.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=[]entity.Session} "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/sessions [get]
+func (h SessionHTTPHandler) List(ctx *gin.Context) {
+	auth := h.GetAuthentication(ctx)
+	if auth == nil {
+		h.UnauthorizedJSON(ctx, "Invalid token")
+		return
+	}
+	result, errException := h.SessionService.List(ctx, auth)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Revoke godoc
+// @Summary Revoke a session
+// @Description Signs the caller out of one device. Its access and refresh tokens stop working immediately.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Session ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /auth/sessions/{id} [delete]
+func (h SessionHTTPHandler) Revoke(ctx *gin.Context) {
+	auth := h.GetAuthentication(ctx)
+	if auth == nil {
+		h.UnauthorizedJSON(ctx, "Invalid token")
+		return
+	}
+	if errException := h.SessionService.Revoke(ctx, auth, ctx.Param("id")); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
+
+// RevokeOthers godoc
+// @Summary Revoke all other sessions
+// @Description Signs the caller out of every device except the one making this request
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/sessions [delete]
+func (h SessionHTTPHandler) RevokeOthers(ctx *gin.Context) {
+	auth := h.GetAuthentication(ctx)
+	if auth == nil {
+		h.UnauthorizedJSON(ctx, "Invalid token")
+		return
+	}
+	if errException := h.SessionService.RevokeOthers(ctx, auth); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestSessionHttpHandler_List(t *testing.T) {
+	t.Run("List Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSessionService := new(mocks.SessionService)
+		sessionHandler := NewSessionHTTPHandler(mockSessionService)
+
+		auth := &signature.JwtAuthenticationRes{
+			Subject:   "123e4567-e89b-12d3-a456-426614174000",
+			SessionID: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		}
+		r.GET("/auth/sessions", func(c *gin.Context) {
+			c.Set("authentication", auth)
+			sessionHandler.List(c)
+		})
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/sessions", nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockSessionService.On("List", mock.Anything, auth).Return([]*entity.Session{
+			{Id: auth.SessionID, UserId: auth.Subject, UserAgent: "Mozilla/5.0", Current: true},
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"current":true`)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("List Error - Unauthenticated", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSessionService := new(mocks.SessionService)
+		sessionHandler := NewSessionHTTPHandler(mockSessionService)
+
+		r.GET("/auth/sessions", sessionHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/sessions", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockSessionService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionHttpHandler_Revoke(t *testing.T) {
+	t.Run("Revoke Not Found", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSessionService := new(mocks.SessionService)
+		sessionHandler := NewSessionHTTPHandler(mockSessionService)
+
+		auth := &signature.JwtAuthenticationRes{Subject: "123e4567-e89b-12d3-a456-426614174000"}
+		r.DELETE("/auth/sessions/:id", func(c *gin.Context) {
+			c.Set("authentication", auth)
+			sessionHandler.Revoke(c)
+		})
+
+		sessionID := "5d41402a-bc4b-4a76-b971-9d911017c592"
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/auth/sessions/"+sessionID, nil)
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockSessionService.On("Revoke", mock.Anything, auth, sessionID).Return(exception.NotFound("session not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockSessionService.AssertExpectations(t)
+	})
+}
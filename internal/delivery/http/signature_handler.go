@@ -0,0 +1,75 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	_ "user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+)
+
+type SignatureHTTPHandler struct {
+	Handler
+	SignatureService service.RequestSignatureService
+}
+
+func NewSignatureHTTPHandler(signature service.RequestSignatureService) *SignatureHTTPHandler {
+	return &SignatureHTTPHandler{
+		SignatureService: signature,
+	}
+}
+
+// Sandbox godoc
+// @Summary Compute a request signature
+// @Description Returns the signature the server expects for a request, along with the string it signs, so sandbox clients can compare it with their own. Send the exact body of the request being debugged, its method in httpMethod and its path with the query string in httpPath. Only clients flagged as sandbox may use it.
+// @Tags Signature
+// @Accept json
+// @Produce json
+// @Param X-Client-Id header string true "Partner client ID"
+// @Param X-Timestamp header string true "Unix time in seconds"
+// @Param X-Nonce header string true "Unique value per request"
+// @Param httpMethod header string true "Method of the request being signed"
+// @Param httpPath header string true "Path and query of the request being signed"
+// @Success 200 {object} response.DataResponse{data=model.Signature} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /signature/sandbox [post]
+func (h SignatureHTTPHandler) Sandbox(ctx *gin.Context) {
+	method, body, err := h.ParseHTTPMethod(ctx)
+	if err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.SignatureService.Sign(ctx, &entity.SignedRequest{
+		ClientID:  ctx.GetHeader(entity.SignatureHeaderClientID),
+		Timestamp: ctx.GetHeader(entity.SignatureHeaderTimestamp),
+		Nonce:     ctx.GetHeader(entity.SignatureHeaderNonce),
+		Method:    method,
+		Path:      ctx.GetHeader("httpPath"),
+		Body:      []byte(body),
+	})
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SignatureJSON(ctx, result)
+}
+
+// Verify godoc
+// @Summary Check a signed request
+// @Description Accepts any method and body and succeeds only when the request is correctly signed, so partners can test their signing before calling real endpoints. The string to sign is METHOD, path with query, X-Timestamp, X-Nonce and the hex SHA-256 of the body, joined by newlines. X-Signature is its hex HMAC-SHA256 under the client secret.
+// @Tags Signature
+// @Accept json
+// @Produce json
+// @Param X-Client-Id header string true "Partner client ID"
+// @Param X-Timestamp header string true "Unix time in seconds"
+// @Param X-Nonce header string true "Unique value per request"
+// @Param X-Signature header string true "Hex HMAC-SHA256 of the string to sign"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /signature/verify [post]
+func (h SignatureHTTPHandler) Verify(ctx *gin.Context) {
+	h.SuccessJSON(ctx)
+}
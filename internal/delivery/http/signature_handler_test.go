@@ -0,0 +1,100 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestSignatureHttpHandler_Sandbox(t *testing.T) {
+	t.Run("Sandbox Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSignatureService := new(mocks.RequestSignatureService)
+		signatureHandler := NewSignatureHTTPHandler(mockSignatureService)
+
+		r.POST("/signature/sandbox", signatureHandler.Sandbox)
+
+		// Create HTTP POST request
+		body := `{"order_id":42}`
+		req, _ := http.NewRequest("POST", "/signature/sandbox", bytes.NewBufferString(body))
+		req.Header.Set("X-Client-Id", "sandbox")
+		req.Header.Set("X-Timestamp", "1700000000")
+		req.Header.Set("X-Nonce", "5f1c2e9a")
+		req.Header.Set("httpMethod", "PUT")
+		req.Header.Set("httpPath", "/orders/42")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockSignatureService.On("Sign", mock.Anything, &entity.SignedRequest{
+			ClientID:  "sandbox",
+			Timestamp: "1700000000",
+			Nonce:     "5f1c2e9a",
+			Method:    "PUT",
+			Path:      "/orders/42",
+			Body:      []byte(body),
+		}).Return(&entity.RequestSignature{Signature: "abc123", StringToSign: "PUT\n/orders/42"}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"signature":"abc123"`)
+		mockSignatureService.AssertExpectations(t)
+	})
+
+	t.Run("Sandbox Error - Not A Sandbox Client", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSignatureService := new(mocks.RequestSignatureService)
+		signatureHandler := NewSignatureHTTPHandler(mockSignatureService)
+
+		r.POST("/signature/sandbox", signatureHandler.Sandbox)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/signature/sandbox", bytes.NewBufferString(""))
+		req.Header.Set("X-Client-Id", "acme")
+		req.Header.Set("httpMethod", "GET")
+		req.Header.Set("httpPath", "/orders")
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockSignatureService.On("Sign", mock.Anything, mock.Anything).
+			Return(nil, exception.PermissionDenied("signatures can only be computed for sandbox clients"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Sandbox Error - Invalid Method", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSignatureService := new(mocks.RequestSignatureService)
+		signatureHandler := NewSignatureHTTPHandler(mockSignatureService)
+
+		r.POST("/signature/sandbox", signatureHandler.Sandbox)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/signature/sandbox", bytes.NewBufferString(""))
+		req.Header.Set("httpMethod", "TRACE")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSignatureService.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything)
+	})
+}
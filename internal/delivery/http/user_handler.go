@@ -2,10 +2,16 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 	_ "user-simple-crud/internal/delivery/http/response"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/model"
 	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/mergepatch"
 )
 
 type UserHTTPHandler struct {
@@ -21,7 +27,7 @@ func NewUserHTTPHandler(user service.UserService) *UserHTTPHandler {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Registers a new user with the provided username and password
+// @Description Registers a new user with the provided username and password. Password policy violations are listed under "password", each with its rule and message.
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -35,7 +41,7 @@ func (h UserHTTPHandler) Register(ctx *gin.Context) {
 		h.BadRequestJSON(ctx, err.Error())
 		return
 	}
-	if errException := h.UserService.Create(ctx, &request); errException != nil {
+	if errException := h.UserService.Create(ctx, &request, h.GetAuditActor(ctx)); errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
 	}
@@ -45,13 +51,16 @@ func (h UserHTTPHandler) Register(ctx *gin.Context) {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticates the user and returns an access token
+// @Description Authenticates the user, starts a session for the calling device and returns an access token bound to it. An expired password returns password_change_required and a password_change_token instead of tokens.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param login body entity.UserLogin true "Login Request"
 // @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
 // @Failure 400 {object} response.DataResponse "error"
+// @Failure 423 {object} response.DataResponse "error"
+// @Failure 429 {object} response.DataResponse "error"
+// @Header 423,429 {integer} Retry-After "Seconds to wait before trying again"
 // @Router /auth/login [post]
 func (h UserHTTPHandler) Login(ctx *gin.Context) {
 	request := entity.UserLogin{}
@@ -59,7 +68,7 @@ func (h UserHTTPHandler) Login(ctx *gin.Context) {
 		h.BadRequestJSON(ctx, err.Error())
 		return
 	}
-	result, errException := h.UserService.Login(ctx, &request)
+	result, errException := h.UserService.Login(ctx, &request, h.GetClientInfo(ctx))
 	if errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
@@ -86,7 +95,7 @@ func (h UserHTTPHandler) Create(ctx *gin.Context) {
 		h.BadRequestJSON(ctx, err.Error())
 		return
 	}
-	if errException := h.UserService.Create(ctx, &request); errException != nil {
+	if errException := h.UserService.Create(ctx, &request, h.GetAuditActor(ctx)); errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
 	}
@@ -103,10 +112,12 @@ func (h UserHTTPHandler) Create(ctx *gin.Context) {
 // @Param Authorization header string true "format: Bearer <JWT TOKEN>"
 // @Param pageSize query string false "Number of items per page"
 // @Param page query string false "Page number"
-// @Param filter query string false "Filter rules<br><br>### Rules Filter<br>rule:<br>  * {Name of Field}:{value}:{Symbol}<br><br>Symbols:<br>  * eq (=)<br>  * lt (<)<br>  * gt (>)<br>  * lte (<=)<br>  * gte (>=)<br>  * in (in)<br>  * like (like)<br><br>Field list:<br>  * id<br>  * username<br>  * email"
-// @Param sort query string false "Sort rules:<br><br>### Rules Sort<br>rule:<br>  * {Name of Field}:{Symbol}<br><br>Symbols:<br>  * asc<br>  * desc<br><br>Field list:<br>  * id<br>  * title<br>  * isbn<br>  * author_id"
+// @Param filter query string false "Filter rules<br><br>### Rules Filter<br>rule:<br>  * {Name of Field}:{value}:{Symbol}<br><br>Symbols:<br>  * eq (=)<br>  * lt (<)<br>  * gt (>)<br>  * lte (<=)<br>  * gte (>=)<br>  * in (in)<br>  * like (like)<br><br>Field list:<br>  * id<br>  * username<br>  * email<br>  * status (active, suspended, banned or pending)<br>  * group (group ID, eq or in only)"
+// @Param includeDeleted query bool false "Also list soft deleted users, needs the users:restore permission"
+// @Param sort query string false "Sort rules:<br><br>### Rules Sort<br>rule:<br>  * {Name of Field}:{Symbol}<br><br>Symbols:<br>  * asc<br>  * desc<br><br>Field list:<br>  * id<br>  * username<br>  * email<br>  * status"
 // @Success 200 {object} response.PaginationResponse{data=[]entity.User,pagination=model.Pagination} "success"
 // @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
 // @Router /users [get]
 func (h UserHTTPHandler) List(ctx *gin.Context) {
 	var req model.ListReq
@@ -116,6 +127,18 @@ func (h UserHTTPHandler) List(ctx *gin.Context) {
 		h.BadRequestJSON(ctx, err.Error())
 		return
 	}
+	if includeDeleted := ctx.Query("includeDeleted"); includeDeleted != "" {
+		if req.IncludeDeleted, err = strconv.ParseBool(includeDeleted); err != nil {
+			h.BadRequestJSON(ctx, "includeDeleted must be true or false")
+			return
+		}
+	}
+	if req.IncludeDeleted {
+		if auth := h.GetAuthentication(ctx); auth == nil || !auth.HasPermission(entity.PermissionUsersRestore) {
+			h.ExceptionJSON(ctx, exception.PermissionDenied("missing permission "+entity.PermissionUsersRestore))
+			return
+		}
+	}
 	result, errException := h.UserService.List(ctx, req)
 	if errException != nil {
 		h.ExceptionJSON(ctx, errException)
@@ -133,66 +156,328 @@ func (h UserHTTPHandler) List(ctx *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "format: Bearer <JWT TOKEN>"
 // @Param id path string true "User ID (UUID format)"
+// @Param include query string false "Comma separated relations to load along: groups"
 // @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Header 200 {string} ETag "Version of the user, send it back as If-Match to update or delete it"
 // @Failure 400 {object} response.DataResponse "error"
 // @Router /users/{id} [get]
 func (h UserHTTPHandler) FindOne(ctx *gin.Context) {
 	idParam := ctx.Param("id")
-	result, errException := h.UserService.FindOne(ctx, idParam)
+	var include []string
+	if param := ctx.Query("include"); param != "" {
+		include = strings.Split(param, ",")
+	}
+	result, errException := h.UserService.FindOne(ctx, idParam, include...)
 	if errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
 	}
+	if result != nil {
+		h.SetETag(ctx, result.Version)
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Me godoc
+// @Summary Get the caller's profile
+// @Description Retrieves the profile of the user the access token was issued to
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Header 200 {string} ETag "Version of the user"
+// @Failure 401 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /auth/me [get]
+func (h UserHTTPHandler) Me(ctx *gin.Context) {
+	result, errException := h.UserService.FindOne(ctx, h.GetUserID(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	if result == nil {
+		h.ExceptionJSON(ctx, exception.NotFound("user not found"))
+		return
+	}
+	h.SetETag(ctx, result.Version)
+
+	h.DataJSON(ctx, result)
+}
+
+// Patch godoc
+// @Summary Update part of a user
+// @Description Applies a JSON Merge Patch (RFC 7396) to the user's username and email. Members left out keep their value and null clears one. Only changed values are checked for uniqueness, and a changed email has to be verified again. Passwords are changed through /users/{id}/password. Send the ETag the user was read with as If-Match to fail with 412 instead of overwriting someone else's change.
+// @Tags Users
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param If-Match header string false "ETag of the user the patch is based on"
+// @Param patch body entity.UserProfile true "Merge patch of the user profile"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Header 200 {string} ETag "Version of the updated user"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Failure 412 {object} response.DataResponse "error"
+// @Failure 415 {object} response.DataResponse "error"
+// @Router /users/{id} [patch]
+func (h UserHTTPHandler) Patch(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	if contentType := ctx.ContentType(); contentType != mergepatch.ContentType && contentType != gin.MIMEJSON {
+		h.ErrorJSON(ctx, http.StatusUnsupportedMediaType, "content type must be "+mergepatch.ContentType)
+		return
+	}
+	version, errException := h.ParseIfMatch(ctx)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	patch, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.UserService.Patch(ctx, idParam, patch, version, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	h.SetETag(ctx, result.Version)
 
 	h.DataJSON(ctx, result)
 }
 
 // Update godoc
-// @Summary Update an existing book
-// @Description Updates an existing book with the provided details
+// @Summary Replace a user's profile
+// @Description Replaces the user's username and email, an empty value clears it. A changed email has to be verified again. Passwords are no longer accepted here, change them through /users/{id}/password. Prefer PATCH, which only touches the members it is sent. Send the ETag the user was read with as If-Match to fail with 412 instead of overwriting someone else's change.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "format: Bearer <JWT TOKEN>"
 // @Param id path string true "User ID (UUID format)"
-// @Param book body entity.UserLogin true "Updated User details"
-// @Success 200 {object} response.DataResponse{data=entity.UserLogin} "success"
+// @Param If-Match header string false "ETag of the user the update is based on"
+// @Param user body entity.UserProfile true "User profile"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Header 200 {string} ETag "Version of the updated user"
 // @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Failure 412 {object} response.DataResponse "error"
 // @Router /users/{id} [put]
 func (h UserHTTPHandler) Update(ctx *gin.Context) {
-	// Get Info
 	idParam := ctx.Param("id")
-	request := entity.UserLogin{}
+	version, errException := h.ParseIfMatch(ctx)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	request := entity.UserUpdateRequest{}
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		h.BadRequestJSON(ctx, err.Error())
 		return
 	}
+	result, errException := h.UserService.Update(ctx, idParam, &request, version, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	h.SetETag(ctx, result.Version)
+
+	h.DataJSON(ctx, result)
+}
 
-	if errException := h.UserService.Update(ctx, idParam, &request); errException != nil {
+// ChangePassword godoc
+// @Summary Change the caller's password
+// @Description Replaces the password after checking the current one. Wrong current passwords count toward the account lockout. All of the user's sessions are ended on success.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param password body entity.ChangePasswordRequest true "Change Password Request"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 423 {object} response.DataResponse "error"
+// @Router /users/{id}/password [post]
+func (h UserHTTPHandler) ChangePassword(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	request := entity.ChangePasswordRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	if errException := h.UserService.ChangePassword(ctx, idParam, &request, h.GetAuditActor(ctx)); errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
 	}
 
-	h.DataJSON(ctx, request)
+	h.SuccessMessageJSON(ctx, "password has been changed")
 }
 
 // Delete godoc
-// @Summary Delete an existing book
-// @Description Deletes an existing book by ID
+// @Summary Delete a user
+// @Description Soft deletes the user and ends its sessions. The user can be restored through /admin/users/{id}/restore until the retention window passes and it is purged. With If-Match the user is only deleted if it hasn't changed since.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "format: Bearer <JWT TOKEN>"
 // @Param id path string true "User ID (UUID format)"
+// @Param If-Match header string false "ETag of the user the delete is based on"
 // @Success 200 {object} response.SuccessResponse "success"
 // @Failure 400 {object} response.SuccessResponse "error"
+// @Failure 404 {object} response.SuccessResponse "error"
+// @Failure 412 {object} response.SuccessResponse "error"
 // @Router /users/{id} [delete]
 func (h UserHTTPHandler) Delete(ctx *gin.Context) {
 	idParam := ctx.Param("id")
-	if errException := h.UserService.Delete(ctx, idParam); errException != nil {
+	version, errException := h.ParseIfMatch(ctx)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+	if errException := h.UserService.Delete(ctx, idParam, version, h.GetAuditActor(ctx)); errException != nil {
 		h.ExceptionJSON(ctx, errException)
 		return
 	}
 
 	h.SuccessMessageJSON(ctx, idParam+" has been deleted")
 }
+
+// Restore godoc
+// @Summary Restore a deleted user
+// @Description Brings back a soft deleted user that hasn't been purged yet. Fails when another user has taken its username or email since.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/restore [post]
+func (h UserHTTPHandler) Restore(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	result, errException := h.UserService.Restore(ctx, idParam, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Suspend godoc
+// @Summary Suspend or ban a user
+// @Description Stops the user from signing in and ends its sessions. A suspension lasts until expires_at, or until the user is reactivated when it has none. With ban the user stays banned until reactivated. Admins can't suspend themselves.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param suspension body entity.SuspendRequest true "Suspend Request"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/suspend [post]
+func (h UserHTTPHandler) Suspend(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	request := entity.SuspendRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.UserService.Suspend(ctx, idParam, &request, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Reactivate godoc
+// @Summary Reactivate a user
+// @Description Lets a suspended, banned or pending user sign in again.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param reactivation body entity.ReactivateRequest true "Reactivate Request"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/reactivate [post]
+func (h UserHTTPHandler) Reactivate(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	request := entity.ReactivateRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.UserService.Reactivate(ctx, idParam, &request, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// AssignRole godoc
+// @Summary Assign a role to a user
+// @Description Grants a role to the user. The user's current access tokens are revoked so the new claims apply on the next refresh.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param role body entity.RoleRequest true "Role Request"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/roles [post]
+func (h UserHTTPHandler) AssignRole(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	request := entity.RoleRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.UserService.AssignRole(ctx, idParam, &request, h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// RevokeRole godoc
+// @Summary Revoke a role from a user
+// @Description Removes a role from the user. The user's current access tokens are revoked so the new claims apply on the next refresh.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "User ID (UUID format)"
+// @Param role path string true "Role name"
+// @Success 200 {object} response.DataResponse{data=entity.User} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /admin/users/{id}/roles/{role} [delete]
+func (h UserHTTPHandler) RevokeRole(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	result, errException := h.UserService.RevokeRole(ctx, idParam, ctx.Param("role"), h.GetAuditActor(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
@@ -10,10 +10,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/mocks"
+	"user-simple-crud/internal/model"
 	service "user-simple-crud/internal/services"
 	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
 )
 
 func TestUserHttpHandler_Register(t *testing.T) {
@@ -42,7 +45,7 @@ func TestUserHttpHandler_Register(t *testing.T) {
 		ginCtx.Request = req
 
 		// Mock service call
-		mockUserService.On("Create", mock.Anything, requestBody).Return(nil)
+		mockUserService.On("Create", mock.Anything, requestBody, mock.Anything).Return(nil)
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -101,7 +104,7 @@ func TestUserHttpHandler_Register(t *testing.T) {
 		ginCtx.Request = req
 
 		// Mock service call with error
-		mockUserService.On("Create", mock.Anything, requestBody).Return(exception.Internal("error", errors.New("registration failed")))
+		mockUserService.On("Create", mock.Anything, requestBody, mock.Anything).Return(exception.Internal("error", errors.New("registration failed")))
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -142,7 +145,7 @@ func TestUserHttpHandler_Login(t *testing.T) {
 		ginCtx.Request = req
 
 		// Mock service call
-		mockUserService.On("Login", mock.Anything, requestBody).Return(expectedResponse, nil)
+		mockUserService.On("Login", mock.Anything, requestBody, mock.Anything).Return(expectedResponse, nil)
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -201,7 +204,7 @@ func TestUserHttpHandler_Login(t *testing.T) {
 		ginCtx.Request = req
 
 		// Mock service call with error
-		mockUserService.On("Login", mock.Anything, requestBody).Return(nil, exception.Internal("error", errors.New("login failed")))
+		mockUserService.On("Login", mock.Anything, requestBody, mock.Anything).Return(nil, exception.Internal("error", errors.New("login failed")))
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -209,6 +212,41 @@ func TestUserHttpHandler_Login(t *testing.T) {
 		// Check status code
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("Login Error - Account Locked", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/auth/login", userHandler.Login)
+
+		// Mock Data
+		requestBody := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "SecurePass123!",
+		}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		req.RemoteAddr = "203.0.113.7:52100"
+
+		// Mock service call with error
+		mockUserService.On("Login", mock.Anything, requestBody, entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}).
+			Return(nil, exception.Locked("account is temporarily locked, try again later", 90*time.Second+time.Millisecond))
+
+		// Perform request
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusLocked, w.Code)
+		assert.Equal(t, "91", w.Header().Get("Retry-After"))
+		mockUserService.AssertExpectations(t)
+	})
 }
 
 func TestUserHttpHandler_Create(t *testing.T) {
@@ -237,7 +275,7 @@ func TestUserHttpHandler_Create(t *testing.T) {
 		ginCtx.Request = req
 
 		// Set up the expectation on the mock service
-		mockUserService.On("Create", mock.Anything, requestBody).Return(nil)
+		mockUserService.On("Create", mock.Anything, requestBody, mock.Anything).Return(nil)
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -269,7 +307,7 @@ func TestUserHttpHandler_Create(t *testing.T) {
 		ginCtx.Request = req
 
 		// Set up the expectation on the mock service
-		mockUserService.On("Create", mock.Anything, requestBody).Return(exception.Internal("error", errors.New("test error")))
+		mockUserService.On("Create", mock.Anything, requestBody, mock.Anything).Return(exception.Internal("error", errors.New("test error")))
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -332,6 +370,37 @@ func TestUserHttpHandler_List(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("ListUsers Hides Password Hashes", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.GET("/users", userHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/users", nil)
+		w := httptest.NewRecorder()
+
+		// Mock the service
+		mockUserService.On("List", mock.Anything, mock.Anything).Return(&service.ListUserResp{
+			Pagination: &model.Pagination{Page: 1, PageSize: 10, TotalData: 1},
+			Data: []*entity.User{{
+				Id:       "123e4567-e89b-12d3-a456-426614174000",
+				Username: "john_doe",
+				Password: "$argon2id$v=19$m=65536,t=3,p=2$c2FsdHNhbHQ$aGFzaGhhc2g",
+			}},
+		}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "john_doe")
+		assert.NotContains(t, w.Body.String(), `"password"`)
+		assert.NotContains(t, w.Body.String(), "$argon2id$")
+	})
+
 	t.Run("ListUsers Service Error", func(t *testing.T) {
 		r := gin.Default()
 		mockUserService := new(mocks.UserService)
@@ -382,6 +451,63 @@ func TestUserHttpHandler_List(t *testing.T) {
 	})
 }
 
+func TestUserHttpHandler_List_IncludeDeleted(t *testing.T) {
+	t.Run("ListUsers Include Deleted", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		auth := &signature.JwtAuthenticationRes{
+			Subject:     "123e4567-e89b-12d3-a456-426614174000",
+			Permissions: []string{entity.PermissionUsersRead, entity.PermissionUsersRestore},
+		}
+		r.GET("/users", func(c *gin.Context) {
+			c.Set("authentication", auth)
+		}, userHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/users?includeDeleted=true", nil)
+		w := httptest.NewRecorder()
+
+		// Mock the service
+		mockUserService.On("List", mock.Anything, mock.MatchedBy(func(req model.ListReq) bool {
+			return req.IncludeDeleted
+		})).Return(&service.ListUserResp{}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("ListUsers Include Deleted Without Permission", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		auth := &signature.JwtAuthenticationRes{
+			Subject:     "123e4567-e89b-12d3-a456-426614174000",
+			Permissions: []string{entity.PermissionUsersRead},
+		}
+		r.GET("/users", func(c *gin.Context) {
+			c.Set("authentication", auth)
+		}, userHandler.List)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/users?includeDeleted=true", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockUserService.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUserHttpHandler_FindOne(t *testing.T) {
 	t.Run("FindOneUser Success", func(t *testing.T) {
 		r := gin.Default()
@@ -396,6 +522,7 @@ func TestUserHttpHandler_FindOne(t *testing.T) {
 			Id:       userID,
 			Username: "john_doe",
 			Email:    "john_doe@example.com",
+			Version:  3,
 		}
 
 		// Mock the service
@@ -415,6 +542,7 @@ func TestUserHttpHandler_FindOne(t *testing.T) {
 
 		// Check status code
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
 	})
 
 	t.Run("FindOneUser Not Found", func(t *testing.T) {
@@ -440,6 +568,98 @@ func TestUserHttpHandler_FindOne(t *testing.T) {
 		// Perform request
 		r.ServeHTTP(w, req)
 
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("FindOneUser Include Groups", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.GET("/users/:id", userHandler.FindOne)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		expectedUser := &entity.User{
+			Id:     userID,
+			Groups: []*entity.Group{{Id: "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", Name: "engineering"}},
+		}
+
+		// Mock the service
+		mockUserService.On("FindOne", mock.Anything, userID, "groups").Return(expectedUser, nil)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/users/"+userID+"?include=groups", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"engineering"`)
+		mockUserService.AssertExpectations(t)
+	})
+}
+
+func TestUserHttpHandler_Me(t *testing.T) {
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	withUser := func(c *gin.Context) {
+		c.Set("user_id", userID)
+	}
+
+	t.Run("Me Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.GET("/auth/me", withUser, userHandler.Me)
+
+		// Mock the service
+		mockUserService.On("FindOne", mock.Anything, userID).Return(&entity.User{
+			Id:       userID,
+			Username: "john_doe",
+			Email:    "john_doe@example.com",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu",
+		}, nil)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/me", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "john_doe")
+		// The password hash never leaves the server
+		var body struct {
+			Data map[string]any `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.NotContains(t, body.Data, "password")
+		assert.NotContains(t, w.Body.String(), "$2a$12$")
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Me User Deleted", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.GET("/auth/me", withUser, userHandler.Me)
+
+		// Mock the service
+		mockUserService.On("FindOne", mock.Anything, userID).Return(nil, nil)
+
+		// Create HTTP GET request
+		req, _ := http.NewRequest("GET", "/auth/me", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
 		// Check status code
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
@@ -453,52 +673,51 @@ func TestUserHttpHandler_Update(t *testing.T) {
 
 		r.PUT("/users/:id", userHandler.Update)
 
-		// Mock Data
+		// Prepare request data
 		userID := "123e4567-e89b-12d3-a456-426614174000"
-		requestBody := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Password: "NewSecurePass123!",
-		}
+		requestBody := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe", Email: "john_doe_updated@example.com"}}
 		requestBodyBytes, _ := json.Marshal(requestBody)
 
-		// Mock the service
-		mockUserService.On("Update", mock.Anything, userID, requestBody).Return(nil)
-
-		// Create HTTP PUT request
 		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBuffer(requestBodyBytes))
 		req.Header.Set("Content-Type", "application/json")
-
-		// Create gin context
 		w := httptest.NewRecorder()
-		ginCtx, _ := gin.CreateTestContext(w)
-		ginCtx.Request = req
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, userID, requestBody, int64(0), mock.Anything).Return(&entity.User{
+			Id:       userID,
+			Username: "john_doe",
+			Email:    "john_doe_updated@example.com",
+			Version:  2,
+		}, nil)
 
 		// Perform request
 		r.ServeHTTP(w, req)
 
 		// Check status code
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+		mockUserService.AssertExpectations(t)
 	})
 
-	t.Run("UpdateUser Binding JSON Error", func(t *testing.T) {
+	t.Run("UpdateUser Password Refused", func(t *testing.T) {
 		r := gin.Default()
 		mockUserService := new(mocks.UserService)
 		userHandler := NewUserHTTPHandler(mockUserService)
 
 		r.PUT("/users/:id", userHandler.Update)
 
-		// Malformed JSON
-		malformedJSON := `{"invalid_json"}`
+		// Prepare request data
 		userID := "123e4567-e89b-12d3-a456-426614174000"
+		body := `{"username":"john_doe","password":"NewSecurePass123!"}`
 
-		// Create HTTP PUT request
-		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBufferString(malformedJSON))
+		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBufferString(body))
 		req.Header.Set("Content-Type", "application/json")
-
-		// Create gin context
 		w := httptest.NewRecorder()
-		ginCtx, _ := gin.CreateTestContext(w)
-		ginCtx.Request = req
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, userID, mock.MatchedBy(func(request *entity.UserUpdateRequest) bool {
+			return request.Password == "NewSecurePass123!"
+		}), int64(0), mock.Anything).Return(nil, exception.InvalidArgument("the password can't be updated here, use /users/"+userID+"/password"))
 
 		// Perform request
 		r.ServeHTTP(w, req)
@@ -507,97 +726,713 @@ func TestUserHttpHandler_Update(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	t.Run("UpdateUser Service Error", func(t *testing.T) {
+	t.Run("UpdateUser Stale If-Match", func(t *testing.T) {
 		r := gin.Default()
 		mockUserService := new(mocks.UserService)
 		userHandler := NewUserHTTPHandler(mockUserService)
 
 		r.PUT("/users/:id", userHandler.Update)
 
-		// Mock Data
+		// Prepare request data
 		userID := "123e4567-e89b-12d3-a456-426614174000"
-		requestBody := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Password: "NewSecurePass123!",
-		}
+		requestBody := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "jane_doe"}}
 		requestBodyBytes, _ := json.Marshal(requestBody)
 
-		// Mock the service
-		mockUserService.On("Update", mock.Anything, userID, requestBody).Return(exception.Internal("error", errors.New("update failed")))
-
-		// Create HTTP PUT request
 		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBuffer(requestBodyBytes))
 		req.Header.Set("Content-Type", "application/json")
-
-		// Create gin context
+		req.Header.Set("If-Match", `"2"`)
 		w := httptest.NewRecorder()
-		ginCtx, _ := gin.CreateTestContext(w)
-		ginCtx.Request = req
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, userID, requestBody, int64(2), mock.Anything).
+			Return(nil, exception.PreconditionFailed("user was changed since it was read, reload it and try again"))
 
 		// Perform request
 		r.ServeHTTP(w, req)
 
 		// Check status code
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockUserService.AssertExpectations(t)
 	})
-}
 
-func TestUserHttpHandler_Delete(t *testing.T) {
-	t.Run("DeleteUser Success", func(t *testing.T) {
+	t.Run("UpdateUser Binding JSON Error", func(t *testing.T) {
 		r := gin.Default()
 		mockUserService := new(mocks.UserService)
 		userHandler := NewUserHTTPHandler(mockUserService)
 
-		r.DELETE("/users/:id", userHandler.Delete)
+		r.PUT("/users/:id", userHandler.Update)
 
-		// Mock Data
+		// Malformed JSON
+		malformedJSON := `{"invalid_json"}`
 		userID := "123e4567-e89b-12d3-a456-426614174000"
 
-		// Mock the service
-		mockUserService.On("Delete", mock.Anything, userID).Return(nil)
-
-		// Create HTTP DELETE request
-		req, _ := http.NewRequest("DELETE", "/users/"+userID, nil)
+		// Create HTTP PUT request
+		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBufferString(malformedJSON))
 		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
 
-		// Create gin context
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUserService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateUser Invalid UUID", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PUT("/users/:id", userHandler.Update)
+
+		// Prepare request data
+		requestBody := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe"}}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("PUT", "/users/invalid-uuid", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
-		ginCtx, _ := gin.CreateTestContext(w)
-		ginCtx.Request = req
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, "invalid-uuid", requestBody, int64(0), mock.Anything).Return(nil, exception.InvalidArgument("invalid user id, must be uuid"))
 
 		// Perform request
 		r.ServeHTTP(w, req)
 
 		// Check status code
-		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
-	t.Run("DeleteUser Service Error", func(t *testing.T) {
+	t.Run("UpdateUser Username Exists", func(t *testing.T) {
 		r := gin.Default()
 		mockUserService := new(mocks.UserService)
 		userHandler := NewUserHTTPHandler(mockUserService)
 
-		r.DELETE("/users/:id", userHandler.Delete)
+		r.PUT("/users/:id", userHandler.Update)
 
-		// Mock Data
+		// Prepare request data
 		userID := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "jane_doe"}}
+		requestBodyBytes, _ := json.Marshal(requestBody)
 
-		// Mock the service
-		mockUserService.On("Delete", mock.Anything, userID).Return(exception.Internal("error", errors.New("delete failed")))
-
-		// Create HTTP DELETE request
-		req, _ := http.NewRequest("DELETE", "/users/"+userID, nil)
+		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBuffer(requestBodyBytes))
 		req.Header.Set("Content-Type", "application/json")
-
-		// Create gin context
 		w := httptest.NewRecorder()
-		ginCtx, _ := gin.CreateTestContext(w)
-		ginCtx.Request = req
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, userID, requestBody, int64(0), mock.Anything).Return(nil, exception.PermissionDenied("username already exists"))
 
 		// Perform request
 		r.ServeHTTP(w, req)
 
 		// Check status code
-		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("UpdateUser Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PUT("/users/:id", userHandler.Update)
+
+		// Prepare request data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe_updated"}}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("PUT", "/users/"+userID, bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Update", mock.Anything, userID, requestBody, int64(0), mock.Anything).Return(nil, exception.Internal("error", errors.New("update failed")))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestUserHttpHandler_Patch(t *testing.T) {
+	t.Run("PatchUser Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		patch := `{"email":"john_doe_updated@example.com"}`
+
+		// Mock the service
+		mockUserService.On("Patch", mock.Anything, userID, []byte(patch), int64(0), mock.Anything).Return(&entity.User{
+			Id:       userID,
+			Username: "john_doe",
+			Email:    "john_doe_updated@example.com",
+		}, nil)
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/"+userID, bytes.NewBufferString(patch))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email":"john_doe_updated@example.com"`)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("PatchUser If-Match", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		patch := `{"username":"jane_doe"}`
+
+		// Mock the service
+		mockUserService.On("Patch", mock.Anything, userID, []byte(patch), int64(3), mock.Anything).
+			Return(&entity.User{Id: userID, Username: "jane_doe", Version: 4}, nil)
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/"+userID, bytes.NewBufferString(patch))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("PatchUser Stale If-Match", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		patch := `{"username":"jane_doe"}`
+
+		// Mock the service
+		mockUserService.On("Patch", mock.Anything, userID, []byte(patch), int64(2), mock.Anything).
+			Return(nil, exception.PreconditionFailed("user was changed since it was read, reload it and try again"))
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/"+userID, bytes.NewBufferString(patch))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("PatchUser Weak If-Match", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/123e4567-e89b-12d3-a456-426614174000", bytes.NewBufferString(`{"username":"jane_doe"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `W/"3"`)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		mockUserService.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Unsupported Media Type", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/123e4567-e89b-12d3-a456-426614174000", bytes.NewBufferString(`[{"op":"remove","path":"/email"}]`))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		mockUserService.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.PATCH("/users/:id", userHandler.Patch)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		patch := `{"username":"jane_doe"}`
+
+		// Mock the service
+		mockUserService.On("Patch", mock.Anything, userID, []byte(patch), int64(0), mock.Anything).Return(nil, exception.PermissionDenied("username already exists"))
+
+		// Create HTTP PATCH request
+		req, _ := http.NewRequest("PATCH", "/users/"+userID, bytes.NewBufferString(patch))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestUserHttpHandler_ChangePassword(t *testing.T) {
+	t.Run("ChangePassword Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/users/:id/password", userHandler.ChangePassword)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.ChangePasswordRequest{
+			CurrentPassword: "SecurePass123!",
+			NewPassword:     "NewSecurePass123!",
+		}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Mock the service
+		mockUserService.On("ChangePassword", mock.Anything, userID, requestBody, mock.Anything).Return(nil)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/users/"+userID+"/password", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword Binding JSON Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/users/:id/password", userHandler.ChangePassword)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/users/123e4567-e89b-12d3-a456-426614174000/password", bytes.NewBufferString(`{"invalid_json"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ChangePassword Wrong Current Password", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/users/:id/password", userHandler.ChangePassword)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.ChangePasswordRequest{
+			CurrentPassword: "WrongPass123!",
+			NewPassword:     "NewSecurePass123!",
+		}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		// Mock the service
+		mockUserService.On("ChangePassword", mock.Anything, userID, requestBody, mock.Anything).
+			Return(exception.PermissionDenied("current password is incorrect"))
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/users/"+userID+"/password", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestUserHttpHandler_Delete(t *testing.T) {
+	t.Run("DeleteUser Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.DELETE("/users/:id", userHandler.Delete)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mock the service
+		mockUserService.On("Delete", mock.Anything, userID, int64(0), mock.Anything).Return(nil)
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/users/"+userID, nil)
+		req.Header.Set("Content-Type", "application/json")
+
+		// Create gin context
+		w := httptest.NewRecorder()
+		ginCtx, _ := gin.CreateTestContext(w)
+		ginCtx.Request = req
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("DeleteUser If-Match", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.DELETE("/users/:id", userHandler.Delete)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mock the service
+		mockUserService.On("Delete", mock.Anything, userID, int64(5), mock.Anything).Return(nil)
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/users/"+userID, nil)
+		req.Header.Set("If-Match", `"5"`)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteUser Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.DELETE("/users/:id", userHandler.Delete)
+
+		// Mock Data
+		userID := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mock the service
+		mockUserService.On("Delete", mock.Anything, userID, int64(0), mock.Anything).Return(exception.Internal("error", errors.New("delete failed")))
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/users/"+userID, nil)
+		req.Header.Set("Content-Type", "application/json")
+
+		// Create gin context
+		w := httptest.NewRecorder()
+		ginCtx, _ := gin.CreateTestContext(w)
+		ginCtx.Request = req
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestUserHttpHandler_Restore(t *testing.T) {
+	t.Run("Restore Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/restore", userHandler.Restore)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/restore", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Restore", mock.Anything, id, mock.Anything).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"deleted_at":null`)
+	})
+
+	t.Run("Restore Not Found", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/restore", userHandler.Restore)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/restore", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Restore", mock.Anything, id, mock.Anything).Return(nil, exception.NotFound("user not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUserHttpHandler_Suspend(t *testing.T) {
+	t.Run("Suspend Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/suspend", userHandler.Suspend)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.SuspendRequest{Reason: "Fraud", Ban: true}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/suspend", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Suspend", mock.Anything, id, requestBody, mock.Anything).Return(&entity.User{Id: id, Status: entity.UserStatusBanned}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"banned"`)
+	})
+
+	t.Run("Suspend Binding JSON Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/suspend", userHandler.Suspend)
+
+		req, _ := http.NewRequest("POST", "/admin/users/123e4567-e89b-12d3-a456-426614174000/suspend", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUserService.AssertNotCalled(t, "Suspend", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Suspend Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/suspend", userHandler.Suspend)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.SuspendRequest{Reason: "Spam"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/suspend", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Suspend", mock.Anything, id, requestBody, mock.Anything).Return(nil, exception.PermissionDenied("you can't suspend your own account"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestUserHttpHandler_Reactivate(t *testing.T) {
+	t.Run("Reactivate Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/reactivate", userHandler.Reactivate)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.ReactivateRequest{Reason: "Appeal accepted"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/reactivate", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Reactivate", mock.Anything, id, requestBody, mock.Anything).Return(&entity.User{Id: id, Status: entity.UserStatusActive}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Reactivate Not Found", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/reactivate", userHandler.Reactivate)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.ReactivateRequest{Reason: "Appeal accepted"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/reactivate", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("Reactivate", mock.Anything, id, requestBody, mock.Anything).Return(nil, exception.NotFound("user not found"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUserHttpHandler_AssignRole(t *testing.T) {
+	t.Run("AssignRole Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/roles", userHandler.AssignRole)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.RoleRequest{Role: entity.RoleAdmin}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/roles", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("AssignRole", mock.Anything, id, requestBody, mock.Anything).Return(&entity.User{Id: id, Roles: []string{entity.RoleAdmin}}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("AssignRole Service Error", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.POST("/admin/users/:id/roles", userHandler.AssignRole)
+
+		// Prepare request data
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		requestBody := &entity.RoleRequest{Role: "superuser"}
+		requestBodyBytes, _ := json.Marshal(requestBody)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+id+"/roles", bytes.NewBuffer(requestBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("AssignRole", mock.Anything, id, requestBody, mock.Anything).Return(nil, exception.InvalidArgument("unknown role superuser"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUserHttpHandler_RevokeRole(t *testing.T) {
+	t.Run("RevokeRole Success", func(t *testing.T) {
+		r := gin.Default()
+		mockUserService := new(mocks.UserService)
+		userHandler := NewUserHTTPHandler(mockUserService)
+
+		r.DELETE("/admin/users/:id/roles/:role", userHandler.RevokeRole)
+
+		id := "123e4567-e89b-12d3-a456-426614174000"
+		req, _ := http.NewRequest("DELETE", "/admin/users/"+id+"/roles/admin", nil)
+		w := httptest.NewRecorder()
+
+		// Set up the expectation on the mock service
+		mockUserService.On("RevokeRole", mock.Anything, id, entity.RoleAdmin, mock.Anything).Return(&entity.User{Id: id, Roles: []string{entity.RoleUser}}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	_ "user-simple-crud/internal/delivery/http/response"
+	"user-simple-crud/internal/entity"
+	service "user-simple-crud/internal/services"
+)
+
+type WebAuthnHTTPHandler struct {
+	Handler
+	WebAuthnService service.WebAuthnService
+}
+
+func NewWebAuthnHTTPHandler(webAuthn service.WebAuthnService) *WebAuthnHTTPHandler {
+	return &WebAuthnHTTPHandler{
+		WebAuthnService: webAuthn,
+	}
+}
+
+// BeginRegistration godoc
+// @Summary Start registering a passkey
+// @Description Returns the options to pass to navigator.credentials.create. Binary values are base64url encoded.
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=service.WebAuthnRegistrationOptions} "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/webauthn/register/begin [post]
+func (h WebAuthnHTTPHandler) BeginRegistration(ctx *gin.Context) {
+	result, errException := h.WebAuthnService.BeginRegistration(ctx, h.GetUserID(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// FinishRegistration godoc
+// @Summary Finish registering a passkey
+// @Description Verifies the credential returned by navigator.credentials.create and adds it to the caller's account
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param registration body entity.WebAuthnRegistrationRequest true "WebAuthn Registration Request"
+// @Success 200 {object} response.DataResponse{data=entity.WebAuthnCredential} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 403 {object} response.DataResponse "error"
+// @Router /auth/webauthn/register/finish [post]
+func (h WebAuthnHTTPHandler) FinishRegistration(ctx *gin.Context) {
+	request := entity.WebAuthnRegistrationRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.WebAuthnService.FinishRegistration(ctx, h.GetUserID(ctx), &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// BeginLogin godoc
+// @Summary Start a passkey login
+// @Description Returns the options to pass to navigator.credentials.get. Without a username any discoverable credential may be used.
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param login body entity.WebAuthnLoginRequest false "WebAuthn Login Request"
+// @Success 200 {object} response.DataResponse{data=service.WebAuthnLoginOptions} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Router /auth/webauthn/login/begin [post]
+func (h WebAuthnHTTPHandler) BeginLogin(ctx *gin.Context) {
+	request := entity.WebAuthnLoginRequest{}
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.WebAuthnService.BeginLogin(ctx, &request)
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// FinishLogin godoc
+// @Summary Finish a passkey login
+// @Description Verifies the assertion returned by navigator.credentials.get. The response is the same as a password login.
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param assertion body entity.WebAuthnAssertion true "WebAuthn Assertion"
+// @Success 200 {object} response.DataResponse{data=service.UserLoginResponse} "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/webauthn/login/finish [post]
+func (h WebAuthnHTTPHandler) FinishLogin(ctx *gin.Context) {
+	request := entity.WebAuthnAssertion{}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		h.BadRequestJSON(ctx, err.Error())
+		return
+	}
+	result, errException := h.WebAuthnService.FinishLogin(ctx, &request, h.GetClientInfo(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// List godoc
+// @Summary List passkeys
+// @Description Lists the WebAuthn credentials registered to the caller
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Success 200 {object} response.DataResponse{data=[]entity.WebAuthnCredential} "success"
+// @Failure 401 {object} response.DataResponse "error"
+// @Router /auth/webauthn/credentials [get]
+func (h WebAuthnHTTPHandler) List(ctx *gin.Context) {
+	result, errException := h.WebAuthnService.List(ctx, h.GetUserID(ctx))
+	if errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.DataJSON(ctx, result)
+}
+
+// Remove godoc
+// @Summary Remove a passkey
+// @Description Removes one of the caller's WebAuthn credentials so it can no longer be used to log in
+// @Tags WebAuthn
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "format: Bearer <JWT TOKEN>"
+// @Param id path string true "Credential ID (UUID format)"
+// @Success 200 {object} response.SuccessResponse "success"
+// @Failure 400 {object} response.DataResponse "error"
+// @Failure 404 {object} response.DataResponse "error"
+// @Router /auth/webauthn/credentials/{id} [delete]
+func (h WebAuthnHTTPHandler) Remove(ctx *gin.Context) {
+	if errException := h.WebAuthnService.Remove(ctx, h.GetUserID(ctx), ctx.Param("id")); errException != nil {
+		h.ExceptionJSON(ctx, errException)
+		return
+	}
+
+	h.SuccessJSON(ctx)
+}
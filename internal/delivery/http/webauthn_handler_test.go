@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/webauthn"
+)
+
+func TestWebAuthnHttpHandler_BeginLogin(t *testing.T) {
+	t.Run("BeginLogin Without Body", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockWebAuthnService := new(mocks.WebAuthnService)
+		webAuthnHandler := NewWebAuthnHTTPHandler(mockWebAuthnService)
+
+		r.POST("/auth/webauthn/login/begin", webAuthnHandler.BeginLogin)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/webauthn/login/begin", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockWebAuthnService.On("BeginLogin", mock.Anything, &entity.WebAuthnLoginRequest{}).
+			Return(&service.WebAuthnLoginOptions{PublicKey: &webauthn.RequestOptions{Challenge: "3q2-7wYl", RPID: "localhost"}}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"challenge":"3q2-7wYl"`)
+		mockWebAuthnService.AssertExpectations(t)
+	})
+}
+
+func TestWebAuthnHttpHandler_FinishLogin(t *testing.T) {
+	body := `{"id":"mT3eGz3b","rawId":"mT3eGz3b","type":"public-key","response":{"clientDataJSON":"e30","authenticatorData":"SZYN","signature":"MEUC"}}`
+
+	t.Run("FinishLogin Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockWebAuthnService := new(mocks.WebAuthnService)
+		webAuthnHandler := NewWebAuthnHTTPHandler(mockWebAuthnService)
+
+		r.POST("/auth/webauthn/login/finish", webAuthnHandler.FinishLogin)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/webauthn/login/finish", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockWebAuthnService.On("FinishLogin", mock.Anything, mock.MatchedBy(func(assertion *entity.WebAuthnAssertion) bool {
+			return assertion.RawId == "mT3eGz3b" && assertion.Response.Signature == "MEUC"
+		}), mock.Anything).Return(&service.UserLoginResponse{Username: "john_doe", Token: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"}, nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"`)
+	})
+
+	t.Run("FinishLogin Error - Cloned Credential", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockWebAuthnService := new(mocks.WebAuthnService)
+		webAuthnHandler := NewWebAuthnHTTPHandler(mockWebAuthnService)
+
+		r.POST("/auth/webauthn/login/finish", webAuthnHandler.FinishLogin)
+
+		// Create HTTP POST request
+		req, _ := http.NewRequest("POST", "/auth/webauthn/login/finish", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockWebAuthnService.On("FinishLogin", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, exception.Unauthenticated("credential sign count did not increase"))
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestWebAuthnHttpHandler_Remove(t *testing.T) {
+	t.Run("Remove Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockWebAuthnService := new(mocks.WebAuthnService)
+		webAuthnHandler := NewWebAuthnHTTPHandler(mockWebAuthnService)
+
+		r.DELETE("/auth/webauthn/credentials/:id", func(c *gin.Context) {
+			c.Set("user_id", "123e4567-e89b-12d3-a456-426614174000")
+		}, webAuthnHandler.Remove)
+
+		// Create HTTP DELETE request
+		req, _ := http.NewRequest("DELETE", "/auth/webauthn/credentials/7c9e6679-7425-40de-944b-e07fc1f90ae7", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+
+		// Mock service call
+		mockWebAuthnService.On("Remove", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", "7c9e6679-7425-40de-944b-e07fc1f90ae7").Return(nil)
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check status code
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockWebAuthnService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"user-simple-crud/pkg/signature"
+)
+
+type WellKnownHTTPHandler struct {
+	Handler
+	Signaturer signature.Signaturer
+}
+
+func NewWellKnownHTTPHandler(signaturer signature.Signaturer) *WellKnownHTTPHandler {
+	return &WellKnownHTTPHandler{
+		Signaturer: signaturer,
+	}
+}
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Public keys used to verify access tokens, including keys scheduled to become active and retired keys that still verify. The body is a plain RFC 7517 key set.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} signature.JSONWebKeySet "success"
+// @Router /.well-known/jwks.json [get]
+func (h WellKnownHTTPHandler) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.Signaturer.JWKS())
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestWellKnownHttpHandler_JWKS(t *testing.T) {
+	t.Run("JWKS Success", func(t *testing.T) {
+		// Setup
+		r := gin.Default()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		wellKnownHandler := NewWellKnownHTTPHandler(mockSignaturer)
+
+		r.GET("/.well-known/jwks.json", wellKnownHandler.JWKS)
+
+		// Mock Data
+		keySet := &signature.JSONWebKeySet{Keys: []signature.JSONWebKey{
+			{Kty: "OKP", Kid: "2024-01", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		}}
+		mockSignaturer.On("JWKS").Return(keySet)
+
+		req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+		w := httptest.NewRecorder()
+
+		// Perform request
+		r.ServeHTTP(w, req)
+
+		// Check response is a plain key set
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body signature.JSONWebKeySet
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, *keySet, body)
+	})
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// APIKeyPrefix starts every API key so leaked keys are easy to recognise.
+const APIKeyPrefix = "usc_"
+
+// APIKey is a long-lived credential for scripts and other services. A key
+// with a UserId is a personal access token acting as that user, limited to
+// its scopes; a key without one belongs to a service account and is granted
+// exactly its scopes.
+type APIKey struct {
+	Id     string  `json:"id" gorm:"primaryKey;type:uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserId *string `json:"user_id,omitempty" gorm:"type:uuid;index" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name   string  `json:"name" example:"nightly-export"`
+	// Prefix is the start of the key, kept so owners can tell their keys apart
+	Prefix     string     `json:"prefix" gorm:"size:16" example:"usc_3q2-7wYl"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex;size:64"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json;type:text" example:"users:read"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyRequest is the body accepted when creating an API key. ExpiresAt
+// defaults to, and may not exceed, the configured maximum lifetime.
+type APIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100" example:"nightly-export"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required" example:"users:read"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2025-12-31T23:59:59Z"`
+}
+
+// APIKeySecret is returned once, on creation. The key cannot be shown again.
+type APIKeySecret struct {
+	APIKey
+	Key string `json:"key" example:"usc_3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+}
+
+func (model *APIKey) TableName() string {
+	return os.Getenv("DB_PREFIX") + "api_key"
+}
+
+// IsUsable reports whether the key is neither revoked nor expired at now.
+func (model *APIKey) IsUsable(now time.Time) bool {
+	return model.RevokedAt == nil && now.Before(model.ExpiresAt)
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// Audit actions recorded for user and group records.
+const (
+	AuditUserCreate         = "user.create"
+	AuditUserUpdate         = "user.update"
+	AuditUserPasswordChange = "user.password_change"
+	AuditUserDelete         = "user.delete"
+	AuditUserRestore        = "user.restore"
+	AuditUserRoleAssign     = "user.role_assign"
+	AuditUserRoleRevoke     = "user.role_revoke"
+	AuditUserSuspend        = "user.suspend"
+	AuditUserBan            = "user.ban"
+	AuditUserReactivate     = "user.reactivate"
+	AuditGroupCreate        = "group.create"
+	AuditGroupUpdate        = "group.update"
+	AuditGroupDelete        = "group.delete"
+	AuditGroupMemberAdd     = "group.member_add"
+	AuditGroupMemberRemove  = "group.member_remove"
+)
+
+// AuditRedacted replaces the values of secret fields, such as the password
+// hash, in an audit diff.
+const AuditRedacted = "[REDACTED]"
+
+// AuditLog is one change made to a record. Changes holds the fields that
+// differ, keyed by their JSON name.
+type AuditLog struct {
+	Id        string `json:"id" gorm:"primaryKey;type:uuid" example:"0b8d3f3d-d343-4390-964c-4f05c4c803d6"`
+	ActorId   string `json:"actor_id" gorm:"size:36;index" example:"8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"`
+	ActorName string `json:"actor_name" example:"admin"`
+	// ImpersonatorId is the admin behind an impersonation token that made the change
+	ImpersonatorId string                 `json:"impersonator_id,omitempty" gorm:"size:36"`
+	Action         string                 `json:"action" gorm:"size:64;index" example:"user.update"`
+	TargetId       string                 `json:"target_id" gorm:"size:36;index" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Changes        map[string]AuditChange `json:"changes" gorm:"serializer:json;type:text"`
+	RequestId      string                 `json:"request_id" gorm:"size:64" example:"5f0c6a1e-9a4b-4c8e-8a43-0e2f4b7f5d21"`
+	IpAddress      string                 `json:"ip_address" gorm:"size:45" example:"203.0.113.7"`
+	CreatedAt      time.Time              `json:"created_at" gorm:"index"`
+}
+
+func (model *AuditLog) TableName() string {
+	return os.Getenv("DB_PREFIX") + "audit_log"
+}
+
+// AuditChange is a field's value before and after a change, null when the
+// record didn't exist before or doesn't after.
+type AuditChange struct {
+	From any `json:"from" swaggertype:"string" example:"john_doe"`
+	To   any `json:"to" swaggertype:"string" example:"john_doe_updated"`
+}
+
+// AuditActor is who makes a change and where the request came from. UserId
+// is empty for changes nobody was signed in for, such as registration.
+type AuditActor struct {
+	UserId         string
+	Username       string
+	ImpersonatorId string
+	RequestId      string
+	ClientInfo
+}
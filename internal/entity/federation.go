@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// FederatedIdentity links a user to their account at an external OpenID
+// Connect provider. The provider's subject identifies the account; the email
+// is only kept for reference as providers may let users change it.
+type FederatedIdentity struct {
+	Id          string     `json:"id" gorm:"primaryKey;type:uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserId      string     `json:"user_id" gorm:"type:uuid;index" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Provider    string     `json:"provider" gorm:"uniqueIndex:idx_federated_identity_subject;size:64" example:"corp"`
+	Subject     string     `json:"subject" gorm:"uniqueIndex:idx_federated_identity_subject;size:255" example:"248289761001"`
+	Email       string     `json:"email" example:"john_doe@example.com"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+func (model *FederatedIdentity) TableName() string {
+	return os.Getenv("DB_PREFIX") + "federated_identity"
+}
+
+// FederatedLoginState remembers a login sent to a provider until the user
+// comes back. The state itself is only stored hashed.
+type FederatedLoginState struct {
+	Id           string    `json:"id" gorm:"primaryKey;type:uuid"`
+	StateHash    string    `json:"-" gorm:"uniqueIndex;size:64"`
+	Provider     string    `json:"provider" gorm:"size:64"`
+	Nonce        string    `json:"-"`
+	CodeVerifier string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (model *FederatedLoginState) TableName() string {
+	return os.Getenv("DB_PREFIX") + "federated_login_state"
+}
+
+// FederatedCallbackRequest is what the provider sends back to the callback.
+type FederatedCallbackRequest struct {
+	State            string `form:"state"`
+	Code             string `form:"code"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
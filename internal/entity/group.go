@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// UserGroupTable is the join table of the User.Groups many2many relation.
+const UserGroupTable = "user_group"
+
+type Group struct {
+	Id          string    `json:"id" gorm:"primaryKey;type:uuid" example:"3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"`
+	Name        string    `json:"name" gorm:"size:64;not null;uniqueIndex" example:"engineering"`
+	Description string    `json:"description" example:"Everyone working on the product"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (model *Group) TableName() string {
+	return os.Getenv("DB_PREFIX") + "group"
+}
+
+// GroupRequest creates a group or replaces its name and description. Names are
+// unique regardless of case.
+type GroupRequest struct {
+	Name        string `json:"name" validate:"required,max=64" example:"engineering"`
+	Description string `json:"description" validate:"max=255" example:"Everyone working on the product"`
+}
+
+// GroupMemberRequest adds a user to a group.
+type GroupMemberRequest struct {
+	UserId string `json:"user_id" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
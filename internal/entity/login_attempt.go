@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// LoginAttempt counts the recent failed logins of one subject, either an
+// account ("user:<id>") or a client address ("ip:<address>").
+type LoginAttempt struct {
+	Subject      string     `json:"subject" gorm:"primaryKey;size:191"`
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+}
+
+func (model *LoginAttempt) TableName() string {
+	return os.Getenv("DB_PREFIX") + "login_attempt"
+}
+
+// IsLocked reports whether the subject is locked out at now.
+func (model *LoginAttempt) IsLocked(now time.Time) bool {
+	return model != nil && model.LockedUntil != nil && now.Before(*model.LockedUntil)
+}
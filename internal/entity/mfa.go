@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// TokenPurposeMFAChallenge marks the token handed out by login when a second
+// factor is still owed.
+const TokenPurposeMFAChallenge = "mfa_challenge"
+
+// UserMFA holds a user's TOTP secret. The factor only counts once ConfirmedAt
+// is set, which happens after the user proves their authenticator works.
+type UserMFA struct {
+	Id          string     `json:"id" gorm:"primaryKey;type:uuid"`
+	UserId      string     `json:"user_id" gorm:"type:uuid;uniqueIndex"`
+	Secret      string     `json:"-"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	// LastUsedStep is the TOTP time step of the last accepted code, so a code can't be replayed
+	LastUsedStep int64     `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	Version      int64     `json:"-" gorm:"not null;default:1"`
+}
+
+func (model *UserMFA) TableName() string {
+	return os.Getenv("DB_PREFIX") + "user_mfa"
+}
+
+// IsEnabled reports whether enrollment has been confirmed.
+func (model *UserMFA) IsEnabled() bool {
+	return model != nil && model.ConfirmedAt != nil
+}
+
+// MFARecoveryCode is a hashed, one-time code that stands in for a TOTP code
+// when the authenticator is lost.
+type MFARecoveryCode struct {
+	Id        string     `json:"id" gorm:"primaryKey;type:uuid"`
+	UserId    string     `json:"user_id" gorm:"type:uuid;index"`
+	CodeHash  string     `json:"-" gorm:"uniqueIndex;size:64"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (model *MFARecoveryCode) TableName() string {
+	return os.Getenv("DB_PREFIX") + "mfa_recovery_code"
+}
+
+// MFAEnrollment is returned when a user starts enrolling an authenticator app.
+type MFAEnrollment struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OtpauthURL string `json:"otpauth_url" example:"otpauth://totp/user-simple-crud:john_doe?issuer=user-simple-crud&secret=JBSWY3DPEHPK3PXP"`
+	// QRCode is a PNG of OtpauthURL encoded as a data URI
+	QRCode string `json:"qr_code" example:"data:image/png;base64,iVBORw0KGgo="`
+}
+
+// MFACodeRequest carries a code from the user's authenticator app.
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6" example:"123456"`
+}
+
+// MFARecoveryCodes lists freshly generated recovery codes. They are shown only once.
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7qzm-p2x4d"`
+}
+
+// MFAVerifyRequest completes a login that answered with mfa_required. Exactly
+// one of Code or RecoveryCode must be given.
+type MFAVerifyRequest struct {
+	MFAToken     string `json:"mfa_token" validate:"required" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+	Code         string `json:"code" validate:"omitempty,numeric,len=6" example:"123456"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code,excluded_with=Code" example:"k7qzm-p2x4d"`
+}
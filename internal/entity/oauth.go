@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"os"
+	"slices"
+	"time"
+)
+
+// OAuth2 grant types a client may be registered for.
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// OpenID Connect scopes. Every other scope a client asks for is a permission.
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// Error codes of RFC 6749 section 5.2 and 4.1.2.1.
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthAccessDenied            = "access_denied"
+	OAuthServerError             = "server_error"
+)
+
+// PKCEMethodS256 is the only code_challenge_method accepted; plain is not.
+const PKCEMethodS256 = "S256"
+
+// OAuthClient is an application allowed to obtain tokens from this service.
+// Public clients, such as single page and mobile apps, have no secret and
+// must use PKCE; confidential clients authenticate with their secret.
+type OAuthClient struct {
+	Id           string    `json:"client_id" gorm:"primaryKey;type:uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name         string    `json:"name" example:"billing-portal"`
+	SecretHash   string    `json:"-" gorm:"size:64"`
+	Public       bool      `json:"public" example:"false"`
+	RedirectURIs []string  `json:"redirect_uris" gorm:"serializer:json;type:text" example:"https://billing.example.com/callback"`
+	GrantTypes   []string  `json:"grant_types" gorm:"serializer:json;type:text" example:"authorization_code"`
+	Scopes       []string  `json:"scopes" gorm:"serializer:json;type:text" example:"openid"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OAuthClientRequest is the body accepted when registering a client.
+type OAuthClientRequest struct {
+	Name         string   `json:"name" validate:"required,max=100" example:"billing-portal"`
+	Public       bool     `json:"public" example:"false"`
+	RedirectURIs []string `json:"redirect_uris" validate:"dive,required,url" example:"https://billing.example.com/callback"`
+	GrantTypes   []string `json:"grant_types" validate:"required,min=1,dive,oneof=authorization_code refresh_token client_credentials" example:"authorization_code"`
+	Scopes       []string `json:"scopes" validate:"required,min=1,dive,required" example:"openid"`
+}
+
+// OAuthClientSecret is returned once, on registration. The secret cannot be
+// shown again.
+type OAuthClientSecret struct {
+	OAuthClient
+	ClientSecret string `json:"client_secret,omitempty" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+}
+
+func (model *OAuthClient) TableName() string {
+	return os.Getenv("DB_PREFIX") + "oauth_client"
+}
+
+// AllowsGrant reports whether the client is registered for grantType.
+func (model *OAuthClient) AllowsGrant(grantType string) bool {
+	return slices.Contains(model.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI reports whether uri exactly matches a registered one.
+func (model *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(model.RedirectURIs, uri)
+}
+
+// OAuthAuthorizationCode is a hashed, single use authorization code bound to
+// the client, redirect URI and PKCE challenge of the request that created it.
+// RedirectURIGiven records whether the request named the redirect URI, only
+// then does the token request have to repeat it.
+type OAuthAuthorizationCode struct {
+	Id               string     `json:"id" gorm:"primaryKey;type:uuid"`
+	CodeHash         string     `json:"-" gorm:"uniqueIndex;size:64"`
+	ClientId         string     `json:"client_id" gorm:"type:uuid;index"`
+	UserId           string     `json:"user_id" gorm:"type:uuid;index"`
+	RedirectURI      string     `json:"redirect_uri"`
+	RedirectURIGiven bool       `json:"-"`
+	Scopes           []string   `json:"scopes" gorm:"serializer:json;type:text"`
+	CodeChallenge    string     `json:"-" gorm:"size:128"`
+	Nonce            string     `json:"-"`
+	AuthTime         time.Time  `json:"auth_time"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	ConsumedAt       *time.Time `json:"consumed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func (model *OAuthAuthorizationCode) TableName() string {
+	return os.Getenv("DB_PREFIX") + "oauth_authorization_code"
+}
+
+// AuthorizeRequest holds the query parameters of the authorization endpoint.
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" form:"response_type" example:"code"`
+	ClientId            string `json:"client_id" form:"client_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri" example:"https://billing.example.com/callback"`
+	Scope               string `json:"scope" form:"scope" example:"openid profile users:read"`
+	State               string `json:"state" form:"state" example:"af0ifjsldkj"`
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge" example:"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"`
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method" example:"S256"`
+	Nonce               string `json:"nonce" form:"nonce" example:"n-0S6_WzA2Mj"`
+}
+
+// OAuthConsentRequest is the user's answer to an authorization request, sent
+// by the consent page with the parameters it was opened with.
+type OAuthConsentRequest struct {
+	AuthorizeRequest
+	Approve bool `json:"approve" example:"true"`
+}
+
+// OAuthConsentResponse tells the consent page where to send the browser.
+type OAuthConsentResponse struct {
+	RedirectURI string `json:"redirect_uri" example:"https://billing.example.com/callback?code=SplxlOBeZQQYbYS6WxSbIA&state=af0ifjsldkj"`
+}
+
+// OAuthTokenRequest holds the form parameters of the token endpoint. Client
+// credentials may be sent here or with HTTP Basic authentication.
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type" example:"authorization_code"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// OAuthTokenResponse is the successful token endpoint response of RFC 6749.
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjQtMDEifQ..."`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"3600"`
+	RefreshToken string `json:"refresh_token,omitempty" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+	Scope        string `json:"scope" example:"openid users:read"`
+	IdToken      string `json:"id_token,omitempty" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6IjIwMjQtMDEifQ..."`
+}
+
+// OAuthError is the error body of RFC 6749. It travels as the Error of an
+// exception so handlers can answer in the format clients expect.
+type OAuthError struct {
+	Code        string `json:"error" example:"invalid_grant"`
+	Description string `json:"error_description,omitempty" example:"authorization code has expired"`
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// UserInfo holds the standard claims released for the granted scopes.
+type UserInfo struct {
+	Subject           string `json:"sub" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PreferredUsername string `json:"preferred_username,omitempty" example:"john_doe"`
+	Email             string `json:"email,omitempty" example:"john_doe@example.com"`
+	EmailVerified     *bool  `json:"email_verified,omitempty" example:"true"`
+}
+
+// OpenIDConfiguration is the OpenID Connect discovery document.
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
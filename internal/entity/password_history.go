@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// PasswordHistory keeps the hash of a password a user has set, so it can't be
+// picked again while it is among their most recent ones.
+type PasswordHistory struct {
+	Id           string    `json:"id" gorm:"primaryKey;type:uuid"`
+	UserId       string    `json:"user_id" gorm:"type:uuid;index"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (model *PasswordHistory) TableName() string {
+	return os.Getenv("DB_PREFIX") + "password_history"
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// RateLimit counts the requests made under one key, such as "magic:<hash>"
+// for the sign-in links sent to an address, until the window ends at
+// ExpiresAt.
+type RateLimit struct {
+	Key       string    `json:"key" gorm:"primaryKey;size:191"`
+	Count     int       `json:"count"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+}
+
+func (model *RateLimit) TableName() string {
+	return os.Getenv("DB_PREFIX") + "rate_limit"
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// RefreshToken is a persisted, hashed refresh token. Every token minted by a
+// rotation shares the FamilyId of the login that started the chain, so a
+// replayed token can revoke the whole family at once. Tokens issued to an
+// OAuth client carry its ClientId and the Scopes granted to it, and can only
+// be redeemed by that client at the token endpoint.
+type RefreshToken struct {
+	Id         string     `json:"id" gorm:"primaryKey;type:uuid"`
+	UserId     string     `json:"user_id" gorm:"type:uuid;index"`
+	FamilyId   string     `json:"family_id" gorm:"type:uuid;index"`
+	ClientId   string     `json:"client_id,omitempty" gorm:"index"`
+	Scopes     []string   `json:"scopes,omitempty" gorm:"serializer:json;type:text"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;size:64"`
+	ReplacedBy string     `json:"replaced_by,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// RefreshTokenRequest is the body accepted by the refresh endpoint.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+}
+
+func (model *RefreshToken) TableName() string {
+	return os.Getenv("DB_PREFIX") + "refresh_token"
+}
+
+// IsActive reports whether the token can still be exchanged.
+func (model *RefreshToken) IsActive(now time.Time) bool {
+	return model.RevokedAt == nil && now.Before(model.ExpiresAt)
+}
+
+// LogoutRequest optionally carries the refresh token of the session being closed
+// so its whole family is revoked together with the access token.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// Headers carried by a signed server-to-server request.
+const (
+	SignatureHeaderClientID  = "X-Client-Id"
+	SignatureHeaderTimestamp = "X-Timestamp"
+	SignatureHeaderNonce     = "X-Nonce"
+	SignatureHeaderSignature = "X-Signature"
+)
+
+// SignedRequest is what a partner signs. Timestamp is in unix seconds and
+// Path includes the query string.
+type SignedRequest struct {
+	ClientID  string `validate:"required"`
+	Timestamp string `validate:"required,number"`
+	Nonce     string `validate:"required,max=128"`
+	Signature string
+	Method    string `validate:"required"`
+	Path      string `validate:"required"`
+	Body      []byte
+}
+
+// RequestSignature is the signature the server expects for a SignedRequest,
+// along with the string it was computed over.
+type RequestSignature struct {
+	Signature    string
+	StringToSign string
+}
+
+// RequestNonce remembers a nonce that a client has already used, until its
+// timestamp falls out of the accepted window.
+type RequestNonce struct {
+	ClientId  string    `json:"client_id" gorm:"primaryKey;size:64"`
+	Nonce     string    `json:"nonce" gorm:"primaryKey;size:128"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+}
+
+func (model *RequestNonce) TableName() string {
+	return os.Getenv("DB_PREFIX") + "request_nonce"
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// RevokedToken blacklists a single access token by its jti until it expires.
+type RevokedToken struct {
+	Jti       string    `json:"jti" gorm:"primaryKey;size:64"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevokedSubject invalidates every access token of a subject issued at or before RevokedAt.
+type RevokedSubject struct {
+	Subject   string    `json:"subject" gorm:"primaryKey;size:64"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+func (model *RevokedToken) TableName() string {
+	return os.Getenv("DB_PREFIX") + "revoked_token"
+}
+
+func (model *RevokedSubject) TableName() string {
+	return os.Getenv("DB_PREFIX") + "revoked_subject"
+}
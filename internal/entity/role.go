@@ -0,0 +1,93 @@
+package entity
+
+import "sort"
+
+// Built-in roles. A user without any stored role is treated as RoleUser.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
+// Permissions checked by the HTTP routes, formatted as "<resource>:<action>".
+const (
+	PermissionUsersCreate        = "users:create"
+	PermissionUsersRead          = "users:read"
+	PermissionUsersUpdate        = "users:update"
+	PermissionUsersDelete        = "users:delete"
+	PermissionRolesManage        = "roles:manage"
+	PermissionSessionsRevoke     = "sessions:revoke"
+	PermissionMFAReset           = "mfa:reset"
+	PermissionUsersUnlock        = "users:unlock"
+	PermissionAPIKeysManage      = "api_keys:manage"
+	PermissionOAuthClientsManage = "oauth_clients:manage"
+	PermissionUsersImpersonate   = "users:impersonate"
+	PermissionUsersRestore       = "users:restore"
+	PermissionAuditRead          = "audit:read"
+	PermissionUsersSuspend       = "users:suspend"
+	PermissionGroupsRead         = "groups:read"
+	PermissionGroupsManage       = "groups:manage"
+)
+
+// RolePermissions maps every assignable role to the permissions it grants.
+var RolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionUsersCreate,
+		PermissionUsersRead,
+		PermissionUsersUpdate,
+		PermissionUsersDelete,
+		PermissionRolesManage,
+		PermissionSessionsRevoke,
+		PermissionMFAReset,
+		PermissionUsersUnlock,
+		PermissionAPIKeysManage,
+		PermissionOAuthClientsManage,
+		PermissionUsersImpersonate,
+		PermissionUsersRestore,
+		PermissionAuditRead,
+		PermissionUsersSuspend,
+		PermissionGroupsRead,
+		PermissionGroupsManage,
+	},
+	RoleUser: {
+		PermissionUsersRead,
+		PermissionGroupsRead,
+	},
+}
+
+type RoleRequest struct {
+	Role string `json:"role" validate:"required" example:"admin"`
+}
+
+// IsValidRole reports whether role is one of the assignable roles.
+func IsValidRole(role string) bool {
+	_, ok := RolePermissions[role]
+	return ok
+}
+
+// IsValidPermission reports whether any role grants permission.
+func IsValidPermission(permission string) bool {
+	for _, permissions := range RolePermissions {
+		for _, p := range permissions {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermissionsFor returns the sorted, de-duplicated permissions granted by roles.
+func PermissionsFor(roles []string) []string {
+	set := make(map[string]struct{})
+	for _, role := range roles {
+		for _, permission := range RolePermissions[role] {
+			set[permission] = struct{}{}
+		}
+	}
+	permissions := make([]string, 0, len(set))
+	for permission := range set {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
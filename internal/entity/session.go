@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// Session is one device or browser a user signed in from. Its Id is the
+// FamilyId of the refresh tokens started by that login and the sid claim of
+// every access token issued for it, so ending a session ends both.
+type Session struct {
+	Id         string     `json:"id" gorm:"primaryKey;type:uuid" example:"8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"`
+	UserId     string     `json:"user_id" gorm:"type:uuid;index" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserAgent  string     `json:"user_agent" gorm:"size:512" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IpAddress  string     `json:"ip_address" gorm:"size:45" example:"203.0.113.7"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// Current marks the session the listing request was made from
+	Current bool `json:"current" gorm:"-"`
+}
+
+func (model *Session) TableName() string {
+	return os.Getenv("DB_PREFIX") + "session"
+}
+
+// IsActive reports whether the session is neither revoked nor expired at now.
+func (model *Session) IsActive(now time.Time) bool {
+	return model.RevokedAt == nil && now.Before(model.ExpiresAt)
+}
+
+// ClientInfo describes where a login comes from.
+type ClientInfo struct {
+	IpAddress string
+	UserAgent string
+}
@@ -1,22 +1,155 @@
 package entity
 
 import (
+	"gorm.io/gorm"
 	"os"
+	"time"
 )
 
+// Account statuses. Only an active user can sign in or use its tokens.
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusBanned    = "banned"
+	// UserStatusPending is a registration waiting for its email to be verified
+	UserStatusPending = "pending"
+)
+
+// userStatusTransitions lists the statuses each status may move to. A
+// suspension can be suspended again to change its reason or expiry.
+var userStatusTransitions = map[string][]string{
+	UserStatusPending:   {UserStatusActive, UserStatusBanned},
+	UserStatusActive:    {UserStatusSuspended, UserStatusBanned},
+	UserStatusSuspended: {UserStatusActive, UserStatusSuspended, UserStatusBanned},
+	UserStatusBanned:    {UserStatusActive},
+}
+
 type User struct {
 	Id       string `json:"id" gorm:"primaryKey;type:uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
 	Username string `json:"username" example:"john_doe"`
 	Email    string `json:"email" example:"john_doe@example.com"`
-	Password string `json:"password" example:"$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu"` // Example of bcrypt-hashed password
+	// Password is the hash, never sent to clients; the audit log only notes that it changed
+	Password        string     `json:"-" audit:"password"`
+	Roles           []string   `json:"roles" gorm:"serializer:json;type:text" example:"user"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// PasswordChangedAt starts the password expiry clock, accounts from before it was tracked never expire
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+	// DeletedAt hides the user from queries until it is restored or purged after the retention window
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index" swaggertype:"string" format:"date-time" example:"2024-01-02T15:04:05Z"`
+	// Status is the stored account status, StatusAt tells the one in effect
+	Status string `json:"status" gorm:"size:16;not null;default:active;index" example:"active"`
+	// StatusReason is the reason an admin gave for the last suspension, ban or reactivation
+	StatusReason string `json:"status_reason,omitempty" example:"Chargeback on the last invoice"`
+	// StatusExpiresAt ends a suspension, without it the user stays suspended until reactivated
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	// Version is bumped by every update and sent as the ETag; an update carrying an older one fails
+	Version int64 `json:"version" gorm:"not null;default:1" example:"1"`
+	// Groups is only loaded when FindByID is asked to preload UserGroupsAssociation
+	Groups []*Group `json:"groups,omitempty" gorm:"many2many:user_group;constraint:OnDelete:CASCADE"`
 }
 
+// UserGroupsAssociation names the User.Groups relation for preloading.
+const UserGroupsAssociation = "Groups"
+
 type UserLogin struct {
 	Username string `json:"username" example:"john_doe"`
-	Email    string `json:"email" example:"john_doe@example.com"`
-	Password string `json:"password" validate:"required,password,gte=8" example:"SecurePass123!"` // "password" custom validation assumed
+	Email    string `json:"email" validate:"omitempty,email" example:"john_doe@example.com"`
+	Password string `json:"password" validate:"required" example:"SecurePass123!"` // checked against the password policy on register
+}
+
+// UserProfile is the part of a user PATCH /users/{id} edits. The request is a
+// JSON Merge Patch of it: a member left out keeps its value, null clears it.
+type UserProfile struct {
+	Username string `json:"username" example:"john_doe"`
+	Email    string `json:"email" validate:"omitempty,email" example:"john_doe@example.com"`
+}
+
+// UserUpdateRequest replaces a user's profile through PUT /users/{id}. Password
+// is no longer accepted there; it is only read to turn such requests away.
+type UserUpdateRequest struct {
+	UserProfile
+	Password string `json:"password,omitempty" swaggerignore:"true"`
+}
+
+// ChangePasswordRequest replaces a password. NewPassword is checked against the password policy.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"SecurePass123!"`
+	NewPassword     string `json:"new_password" validate:"required" example:"NewSecurePass123!"`
+}
+
+// SuspendRequest stops a user from signing in. Ban makes it last until an
+// admin reactivates the user, so it can't have an expiry.
+type SuspendRequest struct {
+	Reason    string     `json:"reason" validate:"required,max=255" example:"Chargeback on the last invoice"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"excluded_with=Ban" example:"2024-12-31T23:59:59Z"`
+	Ban       bool       `json:"ban" example:"false"`
+}
+
+// ReactivateRequest lets a suspended, banned or pending user sign in again.
+type ReactivateRequest struct {
+	Reason string `json:"reason" validate:"required,max=255" example:"Payment received"`
 }
 
 func (model *User) TableName() string {
 	return os.Getenv("DB_PREFIX") + "user"
 }
+
+// RoleNames returns the stored roles, falling back to RoleUser for accounts
+// created before roles existed.
+func (model *User) RoleNames() []string {
+	if len(model.Roles) == 0 {
+		return []string{RoleUser}
+	}
+	return model.Roles
+}
+
+// HasRole reports whether the user holds role.
+func (model *User) HasRole(role string) bool {
+	for _, r := range model.RoleNames() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyEmail records that the user owns its email address, which also
+// activates a registration that was waiting for it.
+func (model *User) VerifyEmail(now time.Time) {
+	model.EmailVerifiedAt = &now
+	if model.Status == UserStatusPending {
+		model.Status = UserStatusActive
+	}
+}
+
+// StatusAt returns the user's status at now. A suspension that has run out
+// counts as active, as does an account from before statuses were stored.
+func (model *User) StatusAt(now time.Time) string {
+	switch {
+	case model.Status == "":
+		return UserStatusActive
+	case model.Status == UserStatusSuspended && model.StatusExpiresAt != nil && !now.Before(*model.StatusExpiresAt):
+		return UserStatusActive
+	}
+	return model.Status
+}
+
+// CanTransition reports whether the user's stored status may move to status.
+func (model *User) CanTransition(status string) bool {
+	current := model.Status
+	if current == "" {
+		current = UserStatusActive
+	}
+	for _, next := range userStatusTransitions[current] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidUserStatus reports whether status is one of the account statuses.
+func IsValidUserStatus(status string) bool {
+	_, ok := userStatusTransitions[status]
+	return ok
+}
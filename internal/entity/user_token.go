@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// Purposes a UserToken can be issued for. A token only redeems for the
+// purpose it was issued with.
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposePasswordChange    = "password_change"
+	TokenPurposeMagicLink         = "magic_link"
+)
+
+// UserToken is a single-use, expiring secret sent to a user out of band. Only
+// the hash of the token is stored.
+type UserToken struct {
+	Id         string     `json:"id" gorm:"primaryKey;type:uuid"`
+	UserId     string     `json:"user_id" gorm:"type:uuid;index"`
+	Purpose    string     `json:"purpose" gorm:"size:32;index"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;size:64"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (model *UserToken) TableName() string {
+	return os.Getenv("DB_PREFIX") + "user_token"
+}
+
+// IsUsable reports whether the token can still be redeemed for purpose.
+func (model *UserToken) IsUsable(purpose string, now time.Time) bool {
+	return model.Purpose == purpose && model.ConsumedAt == nil && now.Before(model.ExpiresAt)
+}
+
+// VerifyEmailRequest carries the token from a verification email.
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" validate:"required" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+}
+
+// ResendVerificationRequest asks for a new verification email.
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email" example:"john_doe@example.com"`
+}
+
+// ForgotPasswordRequest asks for a password reset email.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email" example:"john_doe@example.com"`
+}
+
+// ResetPasswordRequest redeems a reset token. Password is checked against the password policy.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+	Password string `json:"password" validate:"required" example:"NewSecurePass123!"`
+}
+
+// ChangeExpiredPasswordRequest redeems the password_change_token a login
+// returned for an expired password.
+type ChangeExpiredPasswordRequest struct {
+	PasswordChangeToken string `json:"password_change_token" validate:"required" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+	Password            string `json:"password" validate:"required" example:"NewSecurePass123!"`
+}
+
+// MagicLinkRequest asks for a sign-in link to be emailed.
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email" example:"john_doe@example.com"`
+}
+
+// ConsumeMagicLinkRequest redeems the token from a sign-in link.
+type ConsumeMagicLinkRequest struct {
+	Token string `json:"token" validate:"required" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+}
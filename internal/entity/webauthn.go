@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"os"
+	"time"
+)
+
+// Purposes of a WebAuthn challenge.
+const (
+	WebAuthnPurposeRegistration = "registration"
+	WebAuthnPurposeLogin        = "login"
+)
+
+// WebAuthnCredential is a passkey or security key registered by a user.
+// SignCount is the authenticator's counter at its last use; it must keep
+// increasing, otherwise the credential may have been cloned.
+type WebAuthnCredential struct {
+	Id     string `json:"id" gorm:"primaryKey;type:uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserId string `json:"user_id" gorm:"type:uuid;index" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name   string `json:"name" example:"MacBook Touch ID"`
+	// CredentialId is the authenticator's credential ID, base64url encoded
+	CredentialId string `json:"credential_id" gorm:"uniqueIndex;size:1400" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	// PublicKey is PKIX encoded
+	PublicKey      []byte     `json:"-"`
+	Algorithm      int        `json:"algorithm" example:"-7"`
+	SignCount      uint32     `json:"sign_count" example:"12"`
+	AAGUID         string     `json:"aaguid" gorm:"size:36" example:"00000000-0000-0000-0000-000000000000"`
+	Transports     []string   `json:"transports" gorm:"serializer:json;type:text" example:"internal"`
+	BackupEligible bool       `json:"backup_eligible"`
+	BackedUp       bool       `json:"backed_up"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+}
+
+func (model *WebAuthnCredential) TableName() string {
+	return os.Getenv("DB_PREFIX") + "webauthn_credential"
+}
+
+// WebAuthnChallenge remembers a challenge handed to the browser until the
+// ceremony completes. It is only stored hashed and can be used once. UserId
+// is set for registrations and for logins that named an account.
+type WebAuthnChallenge struct {
+	Id            string    `json:"id" gorm:"primaryKey;type:uuid"`
+	ChallengeHash string    `json:"-" gorm:"uniqueIndex;size:64"`
+	UserId        *string   `json:"user_id" gorm:"type:uuid"`
+	Purpose       string    `json:"purpose" gorm:"size:32"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (model *WebAuthnChallenge) TableName() string {
+	return os.Getenv("DB_PREFIX") + "webauthn_challenge"
+}
+
+// WebAuthnAttestation is the PublicKeyCredential returned by
+// navigator.credentials.create, serialized with toJSON(). Binary values are
+// base64url encoded.
+type WebAuthnAttestation struct {
+	Id       string                      `json:"id" validate:"required" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	RawId    string                      `json:"rawId" validate:"required,eqfield=Id" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	Type     string                      `json:"type" validate:"required,eq=public-key" example:"public-key"`
+	Response WebAuthnAttestationResponse `json:"response" validate:"required"`
+}
+
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required" example:"eyJ0eXBlIjoid2ViYXV0aG4uY3JlYXRlIn0"`
+	AttestationObject string   `json:"attestationObject" validate:"required" example:"o2NmbXRkbm9uZQ"`
+	Transports        []string `json:"transports" validate:"max=8,dive,max=32" example:"internal"`
+}
+
+// WebAuthnRegistrationRequest completes a registration started at
+// /auth/webauthn/register/begin.
+type WebAuthnRegistrationRequest struct {
+	Name       string              `json:"name" validate:"required,max=100" example:"MacBook Touch ID"`
+	Credential WebAuthnAttestation `json:"credential" validate:"required"`
+}
+
+// WebAuthnLoginRequest starts a login. Without a username any discoverable
+// credential may be used.
+type WebAuthnLoginRequest struct {
+	Username string `json:"username" validate:"max=255" example:"john_doe"`
+}
+
+// WebAuthnAssertion is the PublicKeyCredential returned by
+// navigator.credentials.get, serialized with toJSON().
+type WebAuthnAssertion struct {
+	Id       string                    `json:"id" validate:"required" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	RawId    string                    `json:"rawId" validate:"required,eqfield=Id" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	Type     string                    `json:"type" validate:"required,eq=public-key" example:"public-key"`
+	Response WebAuthnAssertionResponse `json:"response" validate:"required"`
+}
+
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required" example:"eyJ0eXBlIjoid2ViYXV0aG4uZ2V0In0"`
+	AuthenticatorData string `json:"authenticatorData" validate:"required" example:"SZYN5YgOjGh0NBcPZHZgW4_krrmihjLHmVzzuoMdl2MFAAAAAQ"`
+	Signature         string `json:"signature" validate:"required" example:"MEUCIQDx"`
+	UserHandle        string `json:"userHandle" example:"MTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAw"`
+}
@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogNotifier writes notifications to the application log instead of
+// delivering them. Messages may contain live tokens, so it is meant for local
+// development only.
+type LogNotifier struct {
+}
+
+func NewLogNotifier() Notifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, message *Message) error {
+	slog.Info("notification", "to", message.To, "subject", message.Subject, "body", message.Body)
+	return nil
+}
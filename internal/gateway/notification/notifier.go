@@ -0,0 +1,16 @@
+package notification
+
+import (
+	"context"
+)
+
+// Message is a plain text notification addressed to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"user-simple-crud/config"
+)
+
+type SMTPNotifier struct {
+	config *config.NotificationConfig
+}
+
+func NewSMTPNotifier(config *config.NotificationConfig) Notifier {
+	return &SMTPNotifier{
+		config: config,
+	}
+}
+
+func (n *SMTPNotifier) Send(ctx context.Context, message *Message) error {
+	if strings.ContainsAny(message.To+message.Subject, "\r\n") {
+		return fmt.Errorf("invalid header value in notification to %q", message.To)
+	}
+	addr := net.JoinHostPort(n.config.SMTPHost, strconv.Itoa(n.config.SMTPPort))
+	var auth smtp.Auth
+	if n.config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", n.config.SMTPUsername, n.config.SMTPPassword, n.config.SMTPHost)
+	}
+	body := "From: " + n.config.SMTPFrom + "\r\n" +
+		"To: " + message.To + "\r\n" +
+		"Subject: " + message.Subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + message.Body
+	return smtp.SendMail(addr, auth, n.config.SMTPFrom, []string{message.To}, []byte(body))
+}
@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "user-simple-crud/internal/model"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -54,9 +56,16 @@ func (_m *UserRepository) DeleteByIDTx(ctx context.Context, tx *gorm.DB, id stri
 	return r0
 }
 
-// FindByID provides a mock function with given fields: ctx, tx, id
-func (_m *UserRepository) FindByID(ctx context.Context, tx *gorm.DB, id string) (*entity.User, error) {
-	ret := _m.Called(ctx, tx, id)
+// FindByID provides a mock function with given fields: ctx, tx, id, preload
+func (_m *UserRepository) FindByID(ctx context.Context, tx *gorm.DB, id string, preload ...string) (*entity.User, error) {
+	_va := make([]interface{}, len(preload))
+	for _i := range preload {
+		_va[_i] = preload[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, tx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByID")
@@ -64,19 +73,19 @@ func (_m *UserRepository) FindByID(ctx context.Context, tx *gorm.DB, id string)
 
 	var r0 *entity.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) (*entity.User, error)); ok {
-		return rf(ctx, tx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, ...string) (*entity.User, error)); ok {
+		return rf(ctx, tx, id, preload...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string) *entity.User); ok {
-		r0 = rf(ctx, tx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, ...string) *entity.User); ok {
+		r0 = rf(ctx, tx, id, preload...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string) error); ok {
-		r1 = rf(ctx, tx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, string, ...string) error); ok {
+		r1 = rf(ctx, tx, id, preload...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -144,6 +153,70 @@ func (_m *UserRepository) FindByPagination(ctx context.Context, tx *gorm.DB, pag
 	return r0, r1
 }
 
+// PurgeDeletedTx provides a mock function with given fields: ctx, tx, cutoff
+func (_m *UserRepository) PurgeDeletedTx(ctx context.Context, tx *gorm.DB, cutoff time.Time) (int64, error) {
+	ret := _m.Called(ctx, tx, cutoff)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedTx")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time) (int64, error)); ok {
+		return rf(ctx, tx, cutoff)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, time.Time) int64); ok {
+		r0 = rf(ctx, tx, cutoff)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *gorm.DB, time.Time) error); ok {
+		r1 = rf(ctx, tx, cutoff)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplacePasswordTx provides a mock function with given fields: ctx, tx, id, oldHash, newHash
+func (_m *UserRepository) ReplacePasswordTx(ctx context.Context, tx *gorm.DB, id string, oldHash string, newHash string) error {
+	ret := _m.Called(ctx, tx, id, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePasswordTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *gorm.DB, string, string, string) error); ok {
+		r0 = rf(ctx, tx, id, oldHash, newHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAssociationMany2ManyTx provides a mock function with given fields: tx, data
+func (_m *UserRepository) UpdateAssociationMany2ManyTx(tx *gorm.DB, data *entity.User) error {
+	ret := _m.Called(tx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAssociationMany2ManyTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *entity.User) error); ok {
+		r0 = rf(tx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTx provides a mock function with given fields: ctx, tx, data
 func (_m *UserRepository) UpdateTx(ctx context.Context, tx *gorm.DB, data *entity.User) error {
 	ret := _m.Called(ctx, tx, data)
@@ -4,14 +4,13 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 	entity "user-simple-crud/internal/entity"
+	model "user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
 	exception "user-simple-crud/pkg/exception"
 
 	mock "github.com/stretchr/testify/mock"
-
-	model "user-simple-crud/internal/model"
-
-	service "user-simple-crud/internal/services"
 )
 
 // UserService is an autogenerated mock type for the UserService type
@@ -19,17 +18,69 @@ type UserService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, _a1
-func (_m *UserService) Create(ctx context.Context, _a1 *entity.UserLogin) *exception.Exception {
-	ret := _m.Called(ctx, _a1)
+// AssignRole provides a mock function with given fields: ctx, id, _a2, actor
+func (_m *UserService) AssignRole(ctx context.Context, id string, _a2 *entity.RoleRequest, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, _a2, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.RoleRequest, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, _a2, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.RoleRequest, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.RoleRequest, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: ctx, id, _a2, actor
+func (_m *UserService) ChangePassword(ctx context.Context, id string, _a2 *entity.ChangePasswordRequest, actor entity.AuditActor) *exception.Exception {
+	ret := _m.Called(ctx, id, _a2, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.ChangePasswordRequest, entity.AuditActor) *exception.Exception); ok {
+		r0 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*exception.Exception)
+		}
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, _a1, actor
+func (_m *UserService) Create(ctx context.Context, _a1 *entity.UserLogin, actor entity.AuditActor) *exception.Exception {
+	ret := _m.Called(ctx, _a1, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 *exception.Exception
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin) *exception.Exception); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin, entity.AuditActor) *exception.Exception); ok {
+		r0 = rf(ctx, _a1, actor)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*exception.Exception)
@@ -39,17 +90,17 @@ func (_m *UserService) Create(ctx context.Context, _a1 *entity.UserLogin) *excep
 	return r0
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *UserService) Delete(ctx context.Context, id string) *exception.Exception {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, version, actor
+func (_m *UserService) Delete(ctx context.Context, id string, version int64, actor entity.AuditActor) *exception.Exception {
+	ret := _m.Called(ctx, id, version, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 *exception.Exception
-	if rf, ok := ret.Get(0).(func(context.Context, string) *exception.Exception); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, entity.AuditActor) *exception.Exception); ok {
+		r0 = rf(ctx, id, version, actor)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*exception.Exception)
@@ -59,9 +110,16 @@ func (_m *UserService) Delete(ctx context.Context, id string) *exception.Excepti
 	return r0
 }
 
-// FindOne provides a mock function with given fields: ctx, id
-func (_m *UserService) FindOne(ctx context.Context, id string) (*entity.User, *exception.Exception) {
-	ret := _m.Called(ctx, id)
+// FindOne provides a mock function with given fields: ctx, id, include
+func (_m *UserService) FindOne(ctx context.Context, id string, include ...string) (*entity.User, *exception.Exception) {
+	_va := make([]interface{}, len(include))
+	for _i := range include {
+		_va[_i] = include[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindOne")
@@ -69,19 +127,19 @@ func (_m *UserService) FindOne(ctx context.Context, id string) (*entity.User, *e
 
 	var r0 *entity.User
 	var r1 *exception.Exception
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, *exception.Exception)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, include...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) *entity.User); ok {
+		r0 = rf(ctx, id, include...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) *exception.Exception); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...string) *exception.Exception); ok {
+		r1 = rf(ctx, id, include...)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*exception.Exception)
@@ -123,9 +181,9 @@ func (_m *UserService) List(ctx context.Context, req model.ListReq) (*service.Li
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, _a1
-func (_m *UserService) Login(ctx context.Context, _a1 *entity.UserLogin) (*service.UserLoginResponse, *exception.Exception) {
-	ret := _m.Called(ctx, _a1)
+// Login provides a mock function with given fields: ctx, _a1, client
+func (_m *UserService) Login(ctx context.Context, _a1 *entity.UserLogin, client entity.ClientInfo) (*service.UserLoginResponse, *exception.Exception) {
+	ret := _m.Called(ctx, _a1, client)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -133,19 +191,19 @@ func (_m *UserService) Login(ctx context.Context, _a1 *entity.UserLogin) (*servi
 
 	var r0 *service.UserLoginResponse
 	var r1 *exception.Exception
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin) (*service.UserLoginResponse, *exception.Exception)); ok {
-		return rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin, entity.ClientInfo) (*service.UserLoginResponse, *exception.Exception)); ok {
+		return rf(ctx, _a1, client)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin) *service.UserLoginResponse); ok {
-		r0 = rf(ctx, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserLogin, entity.ClientInfo) *service.UserLoginResponse); ok {
+		r0 = rf(ctx, _a1, client)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*service.UserLoginResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.UserLogin) *exception.Exception); ok {
-		r1 = rf(ctx, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.UserLogin, entity.ClientInfo) *exception.Exception); ok {
+		r1 = rf(ctx, _a1, client)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*exception.Exception)
@@ -155,24 +213,226 @@ func (_m *UserService) Login(ctx context.Context, _a1 *entity.UserLogin) (*servi
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, id, _a2
-func (_m *UserService) Update(ctx context.Context, id string, _a2 *entity.UserLogin) *exception.Exception {
-	ret := _m.Called(ctx, id, _a2)
+// Patch provides a mock function with given fields: ctx, id, patch, version, actor
+func (_m *UserService) Patch(ctx context.Context, id string, patch []byte, version int64, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, patch, version, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, int64, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, patch, version, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, int64, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, patch, version, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, int64, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, patch, version, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, retention
+func (_m *UserService) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, *exception.Exception) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int64
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, *exception.Exception)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) *exception.Exception); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// Reactivate provides a mock function with given fields: ctx, id, _a2, actor
+func (_m *UserService) Reactivate(ctx context.Context, id string, _a2 *entity.ReactivateRequest, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, _a2, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reactivate")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.ReactivateRequest, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, _a2, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.ReactivateRequest, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.ReactivateRequest, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id, actor
+func (_m *UserService) Restore(ctx context.Context, id string, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// RevokeRole provides a mock function with given fields: ctx, id, role, actor
+func (_m *UserService) RevokeRole(ctx context.Context, id string, role string, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, role, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRole")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, role, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, role, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, role, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// Suspend provides a mock function with given fields: ctx, id, _a2, actor
+func (_m *UserService) Suspend(ctx context.Context, id string, _a2 *entity.SuspendRequest, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, _a2, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Suspend")
+	}
+
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.SuspendRequest, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, _a2, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.SuspendRequest, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.SuspendRequest, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, _a2, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, _a2, version, actor
+func (_m *UserService) Update(ctx context.Context, id string, _a2 *entity.UserUpdateRequest, version int64, actor entity.AuditActor) (*entity.User, *exception.Exception) {
+	ret := _m.Called(ctx, id, _a2, version, actor)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
-	var r0 *exception.Exception
-	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.UserLogin) *exception.Exception); ok {
-		r0 = rf(ctx, id, _a2)
+	var r0 *entity.User
+	var r1 *exception.Exception
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.UserUpdateRequest, int64, entity.AuditActor) (*entity.User, *exception.Exception)); ok {
+		return rf(ctx, id, _a2, version, actor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.UserUpdateRequest, int64, entity.AuditActor) *entity.User); ok {
+		r0 = rf(ctx, id, _a2, version, actor)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*exception.Exception)
+			r0 = ret.Get(0).(*entity.User)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, *entity.UserUpdateRequest, int64, entity.AuditActor) *exception.Exception); ok {
+		r1 = rf(ctx, id, _a2, version, actor)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*exception.Exception)
+		}
+	}
+
+	return r0, r1
 }
 
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
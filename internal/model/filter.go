@@ -1,5 +1,7 @@
 package model
 
+import "fmt"
+
 type FilterParam struct {
 	Field    string
 	Value    string
@@ -7,3 +9,20 @@ type FilterParam struct {
 }
 
 type FilterParams []*FilterParam
+
+// Resolve maps each field to the column named for it in columns. The field
+// ends up in the query, so fields that aren't listed are refused.
+func (p FilterParams) Resolve(columns map[string]string) (FilterParams, error) {
+	if len(p) == 0 {
+		return p, nil
+	}
+	resolved := make(FilterParams, 0, len(p))
+	for _, f := range p {
+		column, ok := columns[f.Field]
+		if !ok {
+			return nil, fmt.Errorf("cannot filter on %s", f.Field)
+		}
+		resolved = append(resolved, &FilterParam{Field: column, Value: f.Value, Operator: f.Operator})
+	}
+	return resolved, nil
+}
@@ -1,6 +1,21 @@
 package model
 
+import "fmt"
+
 type OrderParam struct {
 	Order   string
 	OrderBy string
 }
+
+// Resolve maps OrderBy to the column named for it in columns. Like a filter
+// field it ends up in the query, so fields that aren't listed are refused.
+func (p OrderParam) Resolve(columns map[string]string) (OrderParam, error) {
+	if p.OrderBy == "" {
+		return p, nil
+	}
+	column, ok := columns[p.OrderBy]
+	if !ok {
+		return OrderParam{}, fmt.Errorf("cannot sort on %s", p.OrderBy)
+	}
+	return OrderParam{Order: p.Order, OrderBy: column}, nil
+}
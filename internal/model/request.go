@@ -4,6 +4,8 @@ type ListReq struct {
 	Page   PaginationParam
 	Order  OrderParam
 	Filter FilterParams
+	// IncludeDeleted also lists soft deleted rows
+	IncludeDeleted bool
 }
 
 type UpdateApproval struct {
@@ -1,5 +1,6 @@
 package model
 
 type Signature struct {
-	Signature string `json:"signature" example:"asdkjhad7asjkdhb#%4jzhnjkfx8@"`
+	Signature    string `json:"signature" example:"asdkjhad7asjkdhb#%4jzhnjkfx8@"`
+	StringToSign string `json:"string_to_sign,omitempty" example:"POST\n/signature/verify\n1700000000\n5f1c2e\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`
 }
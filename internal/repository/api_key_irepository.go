@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type APIKeyRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.APIKey) error
+	FindByColumn(ctx context.Context, tx *gorm.DB, column string, value any) (*entity.APIKey, error)
+	// FindByOwner lists a user's keys, or the service account keys when userID is nil
+	FindByOwner(ctx context.Context, tx *gorm.DB, userID *string) ([]*entity.APIKey, error)
+	// RevokeTx revokes a key of the given owner. It returns false when no such
+	// unrevoked key exists.
+	RevokeTx(ctx context.Context, tx *gorm.DB, id string, userID *string, revokedAt time.Time) (bool, error)
+	TouchTx(ctx context.Context, tx *gorm.DB, id string, usedAt time.Time) error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type APIKeySQLRepo struct {
+	Repository[entity.APIKey]
+}
+
+func NewAPIKeySQLRepository() APIKeyRepository {
+	return &APIKeySQLRepo{}
+}
+
+func ownedBy(query *gorm.DB, userID *string) *gorm.DB {
+	if userID == nil {
+		return query.Where("user_id IS NULL")
+	}
+	return query.Where("user_id = ?", *userID)
+}
+
+func (r *APIKeySQLRepo) FindByOwner(ctx context.Context, tx *gorm.DB, userID *string) ([]*entity.APIKey, error) {
+	var data []*entity.APIKey
+	if err := ownedBy(tx.WithContext(ctx), userID).Order("created_at desc").Find(&data).Error; err != nil {
+		slog.Error("failed to find api keys", "error", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *APIKeySQLRepo) RevokeTx(
+	ctx context.Context, tx *gorm.DB, id string, userID *string, revokedAt time.Time,
+) (bool, error) {
+	result := ownedBy(tx.WithContext(ctx).Model(&entity.APIKey{}), userID).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		slog.Error("failed to revoke api key", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *APIKeySQLRepo) TouchTx(ctx context.Context, tx *gorm.DB, id string, usedAt time.Time) error {
+	if err := tx.WithContext(ctx).Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error; err != nil {
+		slog.Error("failed to record api key use", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+)
+
+type AuditRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.AuditLog) error
+	FindByPagination(
+		ctx context.Context, tx *gorm.DB, page model.PaginationParam, order model.OrderParam,
+		filter model.FilterParams,
+	) (*model.PaginationData[entity.AuditLog], error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"user-simple-crud/internal/entity"
+)
+
+type AuditSQLRepo struct {
+	Repository[entity.AuditLog]
+}
+
+func NewAuditSQLRepository() AuditRepository {
+	return &AuditSQLRepo{}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+)
+
+type FederationRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.FederatedIdentity) error
+	UpdateTx(ctx context.Context, tx *gorm.DB, data *entity.FederatedIdentity) error
+	FindIdentity(ctx context.Context, tx *gorm.DB, provider, subject string) (*entity.FederatedIdentity, error)
+	CreateStateTx(ctx context.Context, tx *gorm.DB, data *entity.FederatedLoginState) error
+	FindStateByHash(ctx context.Context, tx *gorm.DB, stateHash string) (*entity.FederatedLoginState, error)
+	// DeleteStateTx removes a login state so it can't be used twice. It returns
+	// false when the state was already used.
+	DeleteStateTx(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"user-simple-crud/internal/entity"
+)
+
+type FederationSQLRepo struct {
+	Repository[entity.FederatedIdentity]
+}
+
+func NewFederationSQLRepository() FederationRepository {
+	return &FederationSQLRepo{}
+}
+
+func (r *FederationSQLRepo) FindIdentity(
+	ctx context.Context, tx *gorm.DB, provider, subject string,
+) (*entity.FederatedIdentity, error) {
+	var data entity.FederatedIdentity
+	if err := tx.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find federated identity", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *FederationSQLRepo) CreateStateTx(ctx context.Context, tx *gorm.DB, data *entity.FederatedLoginState) error {
+	if err := tx.WithContext(ctx).Create(data).Error; err != nil {
+		slog.Error("failed to create login state", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *FederationSQLRepo) FindStateByHash(
+	ctx context.Context, tx *gorm.DB, stateHash string,
+) (*entity.FederatedLoginState, error) {
+	var data entity.FederatedLoginState
+	if err := tx.WithContext(ctx).Where("state_hash = ?", stateHash).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find login state", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *FederationSQLRepo) DeleteStateTx(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.FederatedLoginState{})
+	if result.Error != nil {
+		slog.Error("failed to delete login state", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+)
+
+type GroupRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.Group) error
+	UpdateTx(ctx context.Context, tx *gorm.DB, data *entity.Group) error
+	FindByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Group, error)
+	FindByName(ctx context.Context, tx *gorm.DB, column, value string) (*entity.Group, error)
+	FindByPagination(
+		ctx context.Context, tx *gorm.DB, page model.PaginationParam, order model.OrderParam,
+		filter model.FilterParams,
+	) (*model.PaginationData[entity.Group], error)
+	// DeleteByIDTx removes the group, its memberships go with it
+	DeleteByIDTx(ctx context.Context, tx *gorm.DB, id string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"user-simple-crud/internal/entity"
+)
+
+type GroupSQLRepo struct {
+	Repository[entity.Group]
+}
+
+func NewGroupSQLRepository() GroupRepository {
+	return &GroupSQLRepo{}
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// LoginAttemptRepository is the failed login counter consulted before every
+// password check. Like the revocation store it owns its storage, so callers
+// do not pass a transaction.
+type LoginAttemptRepository interface {
+	// Find returns nil when the subject has no failures on record
+	Find(ctx context.Context, subject string) (*entity.LoginAttempt, error)
+	// RecordFailure counts one failure and returns the updated record. The
+	// count restarts when the previous failure is older than window.
+	RecordFailure(ctx context.Context, subject string, now time.Time, window time.Duration) (*entity.LoginAttempt, error)
+	Lock(ctx context.Context, subject string, until time.Time) error
+	Delete(ctx context.Context, subject string) error
+}
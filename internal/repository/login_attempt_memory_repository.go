@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// LoginAttemptMemoryRepo keeps failure counters in process memory. Each
+// instance counts on its own, use the SQL store for clusters.
+type LoginAttemptMemoryRepo struct {
+	mu       sync.Mutex
+	attempts map[string]*entity.LoginAttempt
+}
+
+func NewLoginAttemptMemoryRepository() LoginAttemptRepository {
+	return &LoginAttemptMemoryRepo{
+		attempts: make(map[string]*entity.LoginAttempt),
+	}
+}
+
+func (r *LoginAttemptMemoryRepo) Find(_ context.Context, subject string) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt, ok := r.attempts[subject]
+	if !ok {
+		return nil, nil
+	}
+	data := *attempt
+	return &data, nil
+}
+
+func (r *LoginAttemptMemoryRepo) RecordFailure(
+	_ context.Context, subject string, now time.Time, window time.Duration,
+) (*entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, attempt := range r.attempts {
+		if k != subject && attempt.LastFailedAt.Before(now.Add(-window)) && !attempt.IsLocked(now) {
+			delete(r.attempts, k)
+		}
+	}
+	attempt, ok := r.attempts[subject]
+	if !ok {
+		attempt = &entity.LoginAttempt{Subject: subject}
+		r.attempts[subject] = attempt
+	}
+	if attempt.LastFailedAt.Before(now.Add(-window)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	data := *attempt
+	return &data, nil
+}
+
+func (r *LoginAttemptMemoryRepo) Lock(_ context.Context, subject string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt, ok := r.attempts[subject]
+	if !ok {
+		attempt = &entity.LoginAttempt{Subject: subject, LastFailedAt: time.Now()}
+		r.attempts[subject] = attempt
+	}
+	attempt.LockedUntil = &until
+	return nil
+}
+
+func (r *LoginAttemptMemoryRepo) Delete(_ context.Context, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, subject)
+	return nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// LoginAttemptSQLRepo shares failure counters between instances through the database.
+type LoginAttemptSQLRepo struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptSQLRepository(db *gorm.DB) LoginAttemptRepository {
+	return &LoginAttemptSQLRepo{db: db}
+}
+
+func (r *LoginAttemptSQLRepo) Find(ctx context.Context, subject string) (*entity.LoginAttempt, error) {
+	var data entity.LoginAttempt
+	if err := r.db.WithContext(ctx).Where("subject = ?", subject).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find login attempt", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+// RecordFailure increments the counter in place rather than read-modify-write,
+// so concurrent failures against the same subject are all counted.
+func (r *LoginAttemptSQLRepo) RecordFailure(
+	ctx context.Context, subject string, now time.Time, window time.Duration,
+) (*entity.LoginAttempt, error) {
+	var data entity.LoginAttempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.LoginAttempt{Subject: subject, LastFailedAt: now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.LoginAttempt{}).
+			Where("subject = ? AND last_failed_at < ?", subject, now.Add(-window)).
+			Update("failures", 0).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.LoginAttempt{}).
+			Where("subject = ?", subject).
+			Updates(map[string]any{"failures": gorm.Expr("failures + 1"), "last_failed_at": now}).Error; err != nil {
+			return err
+		}
+		return tx.Where("subject = ?", subject).First(&data).Error
+	})
+	if err != nil {
+		slog.Error("failed to record login attempt", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *LoginAttemptSQLRepo) Lock(ctx context.Context, subject string, until time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&entity.LoginAttempt{}).
+		Where("subject = ?", subject).
+		Update("locked_until", until).Error; err != nil {
+		slog.Error("failed to lock login subject", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *LoginAttemptSQLRepo) Delete(ctx context.Context, subject string) error {
+	if err := r.db.WithContext(ctx).Where("subject = ?", subject).Delete(&entity.LoginAttempt{}).Error; err != nil {
+		slog.Error("failed to delete login attempt", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type MFARepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.UserMFA) error
+	UpdateTx(ctx context.Context, tx *gorm.DB, data *entity.UserMFA) error
+	FindByColumn(ctx context.Context, tx *gorm.DB, column string, value any) (*entity.UserMFA, error)
+	// AdvanceStepTx records step as the last accepted TOTP step. It returns false
+	// when a code from the same or a later step was already accepted.
+	AdvanceStepTx(ctx context.Context, tx *gorm.DB, id string, step int64) (bool, error)
+	// DeleteByUserTx removes the user's TOTP secret and recovery codes.
+	DeleteByUserTx(ctx context.Context, tx *gorm.DB, userID string) error
+	CreateRecoveryCodesTx(ctx context.Context, tx *gorm.DB, codes []*entity.MFARecoveryCode) error
+	// UseRecoveryCodeTx marks an unused recovery code of the user as used. It
+	// returns false when there is no such code.
+	UseRecoveryCodeTx(ctx context.Context, tx *gorm.DB, userID, codeHash string, usedAt time.Time) (bool, error)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type MFASQLRepo struct {
+	Repository[entity.UserMFA]
+}
+
+func NewMFASQLRepository() MFARepository {
+	return &MFASQLRepo{}
+}
+
+func (r *MFASQLRepo) AdvanceStepTx(ctx context.Context, tx *gorm.DB, id string, step int64) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.UserMFA{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		slog.Error("failed to record totp step", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *MFASQLRepo) DeleteByUserTx(ctx context.Context, tx *gorm.DB, userID string) error {
+	if err := tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.MFARecoveryCode{}).Error; err != nil {
+		slog.Error("failed to delete recovery codes", "error", err.Error())
+		return err
+	}
+	if err := tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.UserMFA{}).Error; err != nil {
+		slog.Error("failed to delete mfa", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *MFASQLRepo) CreateRecoveryCodesTx(ctx context.Context, tx *gorm.DB, codes []*entity.MFARecoveryCode) error {
+	if err := tx.WithContext(ctx).Create(codes).Error; err != nil {
+		slog.Error("failed to create recovery codes", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *MFASQLRepo) UseRecoveryCodeTx(
+	ctx context.Context, tx *gorm.DB, userID, codeHash string, usedAt time.Time,
+) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		slog.Error("failed to use recovery code", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type OAuthRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.OAuthClient) error
+	FindByID(ctx context.Context, tx *gorm.DB, id string) (*entity.OAuthClient, error)
+	FindClients(ctx context.Context, tx *gorm.DB) ([]*entity.OAuthClient, error)
+	// DeleteClientTx removes a client and its outstanding authorization codes.
+	// It returns false when there is no such client.
+	DeleteClientTx(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+	CreateCodeTx(ctx context.Context, tx *gorm.DB, data *entity.OAuthAuthorizationCode) error
+	FindCodeByHash(ctx context.Context, tx *gorm.DB, codeHash string) (*entity.OAuthAuthorizationCode, error)
+	// ConsumeCodeTx marks an unused authorization code as used. It returns false
+	// when the code was already exchanged.
+	ConsumeCodeTx(ctx context.Context, tx *gorm.DB, id string, consumedAt time.Time) (bool, error)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type OAuthSQLRepo struct {
+	Repository[entity.OAuthClient]
+}
+
+func NewOAuthSQLRepository() OAuthRepository {
+	return &OAuthSQLRepo{}
+}
+
+func (r *OAuthSQLRepo) FindClients(ctx context.Context, tx *gorm.DB) ([]*entity.OAuthClient, error) {
+	var data []*entity.OAuthClient
+	if err := tx.WithContext(ctx).Order("created_at desc").Find(&data).Error; err != nil {
+		slog.Error("failed to find oauth clients", "error", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *OAuthSQLRepo) DeleteClientTx(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	if err := tx.WithContext(ctx).Where("client_id = ?", id).Delete(&entity.OAuthAuthorizationCode{}).Error; err != nil {
+		slog.Error("failed to delete authorization codes", "error", err.Error())
+		return false, err
+	}
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.OAuthClient{})
+	if result.Error != nil {
+		slog.Error("failed to delete oauth client", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *OAuthSQLRepo) CreateCodeTx(ctx context.Context, tx *gorm.DB, data *entity.OAuthAuthorizationCode) error {
+	if err := tx.WithContext(ctx).Create(data).Error; err != nil {
+		slog.Error("failed to create authorization code", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *OAuthSQLRepo) FindCodeByHash(
+	ctx context.Context, tx *gorm.DB, codeHash string,
+) (*entity.OAuthAuthorizationCode, error) {
+	var data entity.OAuthAuthorizationCode
+	if err := tx.WithContext(ctx).Where("code_hash = ?", codeHash).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find authorization code", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *OAuthSQLRepo) ConsumeCodeTx(ctx context.Context, tx *gorm.DB, id string, consumedAt time.Time) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.OAuthAuthorizationCode{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", consumedAt)
+	if result.Error != nil {
+		slog.Error("failed to consume authorization code", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
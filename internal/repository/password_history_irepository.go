@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+)
+
+type PasswordHistoryRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.PasswordHistory) error
+	// FindRecentByUser lists the user's last limit passwords, newest first
+	FindRecentByUser(ctx context.Context, tx *gorm.DB, userID string, limit int) ([]*entity.PasswordHistory, error)
+	// PruneTx deletes all but the user's newest keep entries
+	PruneTx(ctx context.Context, tx *gorm.DB, userID string, keep int) error
+}
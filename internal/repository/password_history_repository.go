@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"user-simple-crud/internal/entity"
+)
+
+type PasswordHistorySQLRepo struct {
+	Repository[entity.PasswordHistory]
+}
+
+func NewPasswordHistorySQLRepository() PasswordHistoryRepository {
+	return &PasswordHistorySQLRepo{}
+}
+
+func (r *PasswordHistorySQLRepo) FindRecentByUser(
+	ctx context.Context, tx *gorm.DB, userID string, limit int,
+) ([]*entity.PasswordHistory, error) {
+	var data []*entity.PasswordHistory
+	if err := tx.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&data).Error; err != nil {
+		slog.Error("failed to find password history", "error", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+// PruneTx looks the kept ids up first, MySQL doesn't allow LIMIT in an IN subquery.
+func (r *PasswordHistorySQLRepo) PruneTx(ctx context.Context, tx *gorm.DB, userID string, keep int) error {
+	var kept []string
+	if err := tx.WithContext(ctx).Model(&entity.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Limit(keep).
+		Pluck("id", &kept).Error; err != nil {
+		slog.Error("failed to find password history", "error", err.Error())
+		return err
+	}
+	query := tx.WithContext(ctx).Where("user_id = ?", userID)
+	if len(kept) > 0 {
+		query = query.Where("id NOT IN ?", kept)
+	}
+	if err := query.Delete(&entity.PasswordHistory{}).Error; err != nil {
+		slog.Error("failed to prune password history", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// RateLimitRepository counts requests per key in fixed windows. Unlike the
+// login attempt store it counts every request, not failures. It owns its
+// storage, so callers do not pass a transaction.
+type RateLimitRepository interface {
+	// Hit counts one request and returns the updated counter. A key without a
+	// running window starts one that ends window after now.
+	Hit(ctx context.Context, key string, now time.Time, window time.Duration) (*entity.RateLimit, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// RateLimitMemoryRepo keeps request counters in process memory. Each instance
+// counts on its own, use the SQL store for clusters.
+type RateLimitMemoryRepo struct {
+	mu     sync.Mutex
+	limits map[string]*entity.RateLimit
+}
+
+func NewRateLimitMemoryRepository() RateLimitRepository {
+	return &RateLimitMemoryRepo{
+		limits: make(map[string]*entity.RateLimit),
+	}
+}
+
+func (r *RateLimitMemoryRepo) Hit(
+	_ context.Context, key string, now time.Time, window time.Duration,
+) (*entity.RateLimit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, limit := range r.limits {
+		if !now.Before(limit.ExpiresAt) {
+			delete(r.limits, k)
+		}
+	}
+	limit, ok := r.limits[key]
+	if !ok {
+		limit = &entity.RateLimit{Key: key, ExpiresAt: now.Add(window)}
+		r.limits[key] = limit
+	}
+	limit.Count++
+	data := *limit
+	return &data, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// RateLimitSQLRepo shares request counters between instances through the database.
+type RateLimitSQLRepo struct {
+	db *gorm.DB
+}
+
+func NewRateLimitSQLRepository(db *gorm.DB) RateLimitRepository {
+	return &RateLimitSQLRepo{db: db}
+}
+
+// Hit increments the counter in place rather than read-modify-write, so
+// concurrent requests under the same key are all counted.
+func (r *RateLimitSQLRepo) Hit(
+	ctx context.Context, key string, now time.Time, window time.Duration,
+) (*entity.RateLimit, error) {
+	var data entity.RateLimit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at <= ?", now).Delete(&entity.RateLimit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.RateLimit{Key: key, ExpiresAt: now.Add(window)}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.RateLimit{}).
+			Where("key = ?", key).
+			Update("count", gorm.Expr("count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Where("key = ?", key).First(&data).Error
+	})
+	if err != nil {
+		slog.Error("failed to count rate limited request", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
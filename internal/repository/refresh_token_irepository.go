@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type RefreshTokenRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.RefreshToken) error
+	FindByColumn(ctx context.Context, tx *gorm.DB, column string, value any) (*entity.RefreshToken, error)
+	// MarkRotatedTx revokes a still-active token and records its successor.
+	// It returns false when the token had already been revoked by someone else.
+	MarkRotatedTx(ctx context.Context, tx *gorm.DB, id, replacedBy string, revokedAt time.Time) (bool, error)
+	RevokeFamilyTx(ctx context.Context, tx *gorm.DB, familyID string, revokedAt time.Time) error
+	RevokeFamiliesTx(ctx context.Context, tx *gorm.DB, familyIDs []string, revokedAt time.Time) error
+	RevokeByUserTx(ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time) error
+	RevokeByClientTx(ctx context.Context, tx *gorm.DB, clientID string, revokedAt time.Time) error
+}
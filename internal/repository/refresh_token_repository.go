@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type RefreshTokenSQLRepo struct {
+	Repository[entity.RefreshToken]
+}
+
+func NewRefreshTokenSQLRepository() RefreshTokenRepository {
+	return &RefreshTokenSQLRepo{}
+}
+
+func (r *RefreshTokenSQLRepo) MarkRotatedTx(
+	ctx context.Context, tx *gorm.DB, id, replacedBy string, revokedAt time.Time,
+) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": revokedAt, "replaced_by": replacedBy})
+	if result.Error != nil {
+		slog.Error("failed to rotate refresh token", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RefreshTokenSQLRepo) RevokeFamilyTx(
+	ctx context.Context, tx *gorm.DB, familyID string, revokedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke refresh token family", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *RefreshTokenSQLRepo) RevokeFamiliesTx(
+	ctx context.Context, tx *gorm.DB, familyIDs []string, revokedAt time.Time,
+) error {
+	if len(familyIDs) == 0 {
+		return nil
+	}
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("family_id IN ? AND revoked_at IS NULL", familyIDs).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke refresh token families", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *RefreshTokenSQLRepo) RevokeByUserTx(
+	ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke user refresh tokens", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *RefreshTokenSQLRepo) RevokeByClientTx(
+	ctx context.Context, tx *gorm.DB, clientID string, revokedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).
+		Where("client_id = ? AND revoked_at IS NULL", clientID).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke client refresh tokens", "error", err.Error())
+		return err
+	}
+	return nil
+}
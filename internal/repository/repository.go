@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"reflect"
 	"strings"
+	"time"
 	"user-simple-crud/internal/model"
 	"user-simple-crud/pkg/pagination"
 
@@ -13,6 +14,9 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrStaleVersion means the row was changed since the caller read it.
+var ErrStaleVersion = errors.New("record was changed by another request")
+
 type Repository[T any] struct {
 }
 
@@ -48,7 +52,7 @@ func (r *Repository[T]) Find(
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		slog.Error("failed to find all", err)
+		slog.Error("failed to find all", "error", err.Error())
 		return nil, err
 	}
 	return data, nil
@@ -60,7 +64,7 @@ func (r *Repository[T]) FindByID(ctx context.Context, tx *gorm.DB, id string) (*
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		slog.Error("failed to find by id", err)
+		slog.Error("failed to find by id", "error", err.Error())
 		return nil, err
 	}
 	return &data, nil
@@ -74,7 +78,7 @@ func (r *Repository[T]) FindByColumn(ctx context.Context, tx *gorm.DB, column st
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		slog.Error("failed to find by id", err)
+		slog.Error("failed to find by id", "error", err.Error())
 		return nil, err
 	}
 	return &data, nil
@@ -90,33 +94,35 @@ func (r *Repository[T]) FindByName(ctx context.Context, tx *gorm.DB, column, val
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		slog.Error("failed to find by id", err)
+		slog.Error("failed to find by id", "error", err.Error())
 		return nil, err
 	}
 	return &data, nil
 }
 
 func (r *Repository[T]) CreateTx(ctx context.Context, tx *gorm.DB, data *T) error {
+	initVersion(data)
 	if err := tx.WithContext(ctx).Omit(clause.Associations).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "id"}},
 			UpdateAll: true,
 		}).
 		Create(data).Error; err != nil {
-		slog.Error("failed to create", err)
+		slog.Error("failed to create", "error", err.Error())
 		return err
 	}
 	return nil
 }
 
 func (r *Repository[T]) CreateTxWithAssociations(ctx context.Context, tx *gorm.DB, data *T) error {
+	initVersion(data)
 	if err := tx.WithContext(ctx).
 		Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "id"}},
 			UpdateAll: true,
 		}).
 		Create(data).Error; err != nil {
-		slog.Error("failed to create", err)
+		slog.Error("failed to create", "error", err.Error())
 		return err
 	}
 	return nil
@@ -139,26 +145,88 @@ func (r *Repository[T]) UpdateAssociationMany2ManyTx(tx *gorm.DB, data *T) error
 	return nil
 }
 
+// UpdateTx writes every column of data. When T has a Version field the row is
+// only written if its version still matches data's, which is then bumped;
+// otherwise ErrStaleVersion is returned and data is left as it was.
 func (r *Repository[T]) UpdateTx(ctx context.Context, tx *gorm.DB, data *T) error {
-	if err := tx.WithContext(ctx).Omit(clause.Associations).Model(data).Select("*").Updates(data).Error; err != nil {
-		slog.Error("failed to update", err)
-		return err
-	}
-	return nil
+	return r.updates(tx.WithContext(ctx).Omit(clause.Associations), data)
 }
 
 func (r *Repository[T]) UpdateTxWithAssociations(ctx context.Context, tx *gorm.DB, data *T) error {
-	if err := tx.WithContext(ctx).Model(data).Select("*").Updates(data).Error; err != nil {
-		slog.Error("failed to update", err)
-		return err
+	return r.updates(tx.WithContext(ctx), data)
+}
+
+func (r *Repository[T]) updates(query *gorm.DB, data *T) error {
+	version := versionField(data)
+	if version == nil {
+		if err := query.Model(data).Select("*").Updates(data).Error; err != nil {
+			slog.Error("failed to update", "error", err.Error())
+			return err
+		}
+		return nil
+	}
+	current := version.Int()
+	version.SetInt(current + 1)
+	res := query.Model(data).Where("version = ?", current).Select("*").Updates(data)
+	if res.Error != nil {
+		version.SetInt(current)
+		slog.Error("failed to update", "error", res.Error.Error())
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		version.SetInt(current)
+		return ErrStaleVersion
 	}
 	return nil
 }
 
+// DeleteByIDTx soft deletes models with a gorm.DeletedAt field and removes
+// any other model for good. Versioned models, which are always soft deleted,
+// get a new version too so an ETag read before the delete goes stale.
 func (r *Repository[T]) DeleteByIDTx(ctx context.Context, tx *gorm.DB, id string) error {
-	if err := tx.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(new(T)).Error; err != nil {
-		slog.Error("failed to delete", err)
+	query := tx.WithContext(ctx).Where("id = ?", id)
+	if versionField(new(T)) != nil {
+		query = query.Model(new(T)).UpdateColumns(map[string]any{
+			"deleted_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		})
+	} else {
+		query = query.Delete(new(T))
+	}
+	if err := query.Error; err != nil {
+		slog.Error("failed to delete", "error", err.Error())
 		return err
 	}
 	return nil
 }
+
+// PurgeDeletedTx hard deletes the rows soft deleted before cutoff. T must have
+// a gorm.DeletedAt field.
+func (r *Repository[T]) PurgeDeletedTx(ctx context.Context, tx *gorm.DB, cutoff time.Time) (int64, error) {
+	res := tx.WithContext(ctx).Unscoped().Where("deleted_at < ?", cutoff).Delete(new(T))
+	if res.Error != nil {
+		slog.Error("failed to purge deleted rows", "error", res.Error.Error())
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// versionField returns data's Version field, or nil when its type isn't versioned.
+func versionField(data any) *reflect.Value {
+	val := reflect.ValueOf(data).Elem()
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	field := val.FieldByName("Version")
+	if !field.IsValid() || field.Kind() != reflect.Int64 {
+		return nil
+	}
+	return &field
+}
+
+// initVersion numbers a new versioned row 1, like the column default does.
+func initVersion(data any) {
+	if version := versionField(data); version != nil && version.Int() == 0 {
+		version.SetInt(1)
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RequestNonceRepository is the replay guard for signed requests. Like the
+// revocation store it owns its storage, so callers do not pass a transaction.
+type RequestNonceRepository interface {
+	// Remember stores the nonce until expiresAt. It returns false when the
+	// client has already used the nonce.
+	Remember(ctx context.Context, clientID, nonce string, expiresAt time.Time) (bool, error)
+}
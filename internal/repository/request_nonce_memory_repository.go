@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RequestNonceMemoryRepo keeps used nonces in process memory. It is only
+// suitable for single instance deployments, use the SQL store for clusters.
+type RequestNonceMemoryRepo struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+func NewRequestNonceMemoryRepository() RequestNonceRepository {
+	return &RequestNonceMemoryRepo{nonces: make(map[string]time.Time)}
+}
+
+func (r *RequestNonceMemoryRepo) Remember(_ context.Context, clientID, nonce string, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for k, exp := range r.nonces {
+		if now.After(exp) {
+			delete(r.nonces, k)
+		}
+	}
+	key := clientID + "\x00" + nonce
+	if _, ok := r.nonces[key]; ok {
+		return false, nil
+	}
+	r.nonces[key] = expiresAt
+	return true, nil
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// RequestNonceSQLRepo shares used nonces between instances through the database.
+type RequestNonceSQLRepo struct {
+	db *gorm.DB
+}
+
+func NewRequestNonceSQLRepository(db *gorm.DB) RequestNonceRepository {
+	return &RequestNonceSQLRepo{db: db}
+}
+
+func (r *RequestNonceSQLRepo) Remember(ctx context.Context, clientID, nonce string, expiresAt time.Time) (bool, error) {
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&entity.RequestNonce{}).Error; err != nil {
+		slog.Error("failed to purge request nonces", "error", err.Error())
+	}
+	res := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.RequestNonce{ClientId: clientID, Nonce: nonce, ExpiresAt: expiresAt})
+	if res.Error != nil {
+		slog.Error("failed to remember request nonce", "error", res.Error.Error())
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type SessionRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.Session) error
+	FindByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Session, error)
+	// FindActiveByUser lists the user's sessions that are neither revoked nor
+	// expired at now, most recently seen first
+	FindActiveByUser(ctx context.Context, tx *gorm.DB, userID string, now time.Time) ([]*entity.Session, error)
+	TouchTx(ctx context.Context, tx *gorm.DB, id string, seenAt time.Time) error
+	// ExtendTx records a refresh, which also moves the session's expiry
+	ExtendTx(ctx context.Context, tx *gorm.DB, id string, seenAt, expiresAt time.Time) error
+	// RevokeTx revokes a session of the given user. It returns false when no
+	// such unrevoked session exists.
+	RevokeTx(ctx context.Context, tx *gorm.DB, id, userID string, revokedAt time.Time) (bool, error)
+	RevokeManyTx(ctx context.Context, tx *gorm.DB, ids []string, revokedAt time.Time) error
+	RevokeByUserTx(ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time) error
+}
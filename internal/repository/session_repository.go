@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type SessionSQLRepo struct {
+	Repository[entity.Session]
+}
+
+func NewSessionSQLRepository() SessionRepository {
+	return &SessionSQLRepo{}
+}
+
+func (r *SessionSQLRepo) FindActiveByUser(
+	ctx context.Context, tx *gorm.DB, userID string, now time.Time,
+) ([]*entity.Session, error) {
+	var data []*entity.Session
+	if err := tx.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at desc").
+		Find(&data).Error; err != nil {
+		slog.Error("failed to find sessions", "error", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *SessionSQLRepo) TouchTx(ctx context.Context, tx *gorm.DB, id string, seenAt time.Time) error {
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ?", id).
+		Update("last_seen_at", seenAt).Error; err != nil {
+		slog.Error("failed to record session use", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *SessionSQLRepo) ExtendTx(
+	ctx context.Context, tx *gorm.DB, id string, seenAt, expiresAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"last_seen_at": seenAt, "expires_at": expiresAt}).Error; err != nil {
+		slog.Error("failed to extend session", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *SessionSQLRepo) RevokeTx(
+	ctx context.Context, tx *gorm.DB, id, userID string, revokedAt time.Time,
+) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		slog.Error("failed to revoke session", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *SessionSQLRepo) RevokeManyTx(ctx context.Context, tx *gorm.DB, ids []string, revokedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).
+		Where("id IN ? AND revoked_at IS NULL", ids).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke sessions", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *SessionSQLRepo) RevokeByUserTx(
+	ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		slog.Error("failed to revoke user sessions", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository is the revocation store consulted on every
+// authenticated request. Unlike the SQL repositories it owns its storage, so
+// callers do not pass a transaction.
+type TokenRevocationRepository interface {
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeSubject(ctx context.Context, subject string, revokedAt time.Time) error
+	// SubjectRevokedAt returns nil when the subject has never been revoked
+	SubjectRevokedAt(ctx context.Context, subject string) (*time.Time, error)
+}
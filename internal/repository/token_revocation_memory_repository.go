@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenRevocationMemoryRepo keeps revocations in process memory. It is only
+// suitable for single instance deployments, use the SQL store for clusters.
+type TokenRevocationMemoryRepo struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	subjects map[string]time.Time
+}
+
+func NewTokenRevocationMemoryRepository() TokenRevocationRepository {
+	return &TokenRevocationMemoryRepo{
+		tokens:   make(map[string]time.Time),
+		subjects: make(map[string]time.Time),
+	}
+}
+
+func (r *TokenRevocationMemoryRepo) RevokeToken(_ context.Context, jti string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for k, exp := range r.tokens {
+		if now.After(exp) {
+			delete(r.tokens, k)
+		}
+	}
+	r.tokens[jti] = expiresAt
+	return nil
+}
+
+func (r *TokenRevocationMemoryRepo) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.tokens[jti]
+	return ok, nil
+}
+
+func (r *TokenRevocationMemoryRepo) RevokeSubject(_ context.Context, subject string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subjects[subject] = revokedAt
+	return nil
+}
+
+func (r *TokenRevocationMemoryRepo) SubjectRevokedAt(_ context.Context, subject string) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if revokedAt, ok := r.subjects[subject]; ok {
+		return &revokedAt, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+// TokenRevocationSQLRepo shares revocations between instances through the database.
+type TokenRevocationSQLRepo struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationSQLRepository(db *gorm.DB) TokenRevocationRepository {
+	return &TokenRevocationSQLRepo{db: db}
+}
+
+func (r *TokenRevocationSQLRepo) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	now := time.Now()
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&entity.RevokedToken{}).Error; err != nil {
+		slog.Error("failed to purge revoked tokens", "error", err.Error())
+	}
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.RevokedToken{Jti: jti, ExpiresAt: expiresAt, CreatedAt: now}).Error; err != nil {
+		slog.Error("failed to revoke token", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *TokenRevocationSQLRepo) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		slog.Error("failed to check revoked token", "error", err.Error())
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *TokenRevocationSQLRepo) RevokeSubject(ctx context.Context, subject string, revokedAt time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subject"}},
+			DoUpdates: clause.AssignmentColumns([]string{"revoked_at"}),
+		}).
+		Create(&entity.RevokedSubject{Subject: subject, RevokedAt: revokedAt}).Error; err != nil {
+		slog.Error("failed to revoke subject", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *TokenRevocationSQLRepo) SubjectRevokedAt(ctx context.Context, subject string) (*time.Time, error) {
+	var data entity.RevokedSubject
+	if err := r.db.WithContext(ctx).Where("subject = ?", subject).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find revoked subject", "error", err.Error())
+		return nil, err
+	}
+	return &data.RevokedAt, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"gorm.io/gorm"
+	"time"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/model"
 )
@@ -11,13 +12,23 @@ type UserRepository interface {
 	// Example operations
 	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.User) error
 	UpdateTx(ctx context.Context, tx *gorm.DB, data *entity.User) error
+	// ReplacePasswordTx swaps the password hash only if it still equals oldHash
+	ReplacePasswordTx(ctx context.Context, tx *gorm.DB, id, oldHash, newHash string) error
 	FindByName(ctx context.Context, tx *gorm.DB, column, value string) (
 		*entity.User, error,
 	)
+	// FindByPagination also takes a filter on the group field, keeping the members of the given
+	// group ids with the = and in operators
 	FindByPagination(
 		ctx context.Context, tx *gorm.DB, page model.PaginationParam, order model.OrderParam,
 		filter model.FilterParams,
 	) (*model.PaginationData[entity.User], error)
-	FindByID(ctx context.Context, tx *gorm.DB, id string) (*entity.User, error)
+	// FindByID loads no associations except the ones named in preload, such as entity.UserGroupsAssociation
+	FindByID(ctx context.Context, tx *gorm.DB, id string, preload ...string) (*entity.User, error)
+	// UpdateAssociationMany2ManyTx replaces the user's groups with data.Groups
+	UpdateAssociationMany2ManyTx(tx *gorm.DB, data *entity.User) error
+	// DeleteByIDTx soft deletes the user and bumps its version, pass tx.Unscoped() to query deleted users
 	DeleteByIDTx(ctx context.Context, tx *gorm.DB, id string) error
+	// PurgeDeletedTx removes users soft deleted before cutoff for good, with every row they own
+	PurgeDeletedTx(ctx context.Context, tx *gorm.DB, cutoff time.Time) (int64, error)
 }
@@ -1,9 +1,32 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
 	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
 )
 
+// userOwnedModels hold a user_id and belong to that user alone, they are
+// purged together with it.
+var userOwnedModels = []any{
+	&entity.Session{},
+	&entity.RefreshToken{},
+	&entity.UserToken{},
+	&entity.UserMFA{},
+	&entity.MFARecoveryCode{},
+	&entity.APIKey{},
+	&entity.WebAuthnCredential{},
+	&entity.WebAuthnChallenge{},
+	&entity.PasswordHistory{},
+	&entity.FederatedIdentity{},
+	&entity.OAuthAuthorizationCode{},
+}
+
 type UserSQLRepo struct {
 	Repository[entity.User]
 }
@@ -11,3 +34,71 @@ type UserSQLRepo struct {
 func NewUserSQLRepository() UserRepository {
 	return &UserSQLRepo{}
 }
+
+func (r *UserSQLRepo) FindByID(ctx context.Context, tx *gorm.DB, id string, preload ...string) (*entity.User, error) {
+	var data entity.User
+	query := tx.WithContext(ctx)
+	for _, association := range preload {
+		query = query.Preload(association)
+	}
+	if err := query.Where("id = ?", id).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find by id", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *UserSQLRepo) FindByPagination(
+	ctx context.Context, tx *gorm.DB, page model.PaginationParam, order model.OrderParam,
+	filter model.FilterParams,
+) (*model.PaginationData[entity.User], error) {
+	columns := make(model.FilterParams, 0, len(filter))
+	for _, f := range filter {
+		if f.Field != "group" {
+			columns = append(columns, f)
+			continue
+		}
+		members := tx.Session(&gorm.Session{NewDB: true}).
+			Table(tx.NamingStrategy.JoinTableName(entity.UserGroupTable)).
+			Select("user_id").
+			Where("group_id IN ?", strings.Split(f.Value, ","))
+		tx = tx.Where("id IN (?)", members)
+	}
+	return r.Repository.FindByPagination(ctx, tx, page, order, columns)
+}
+
+// PurgeDeletedTx removes the users soft deleted before cutoff along with their
+// sessions, tokens, credentials and group memberships.
+func (r *UserSQLRepo) PurgeDeletedTx(ctx context.Context, tx *gorm.DB, cutoff time.Time) (int64, error) {
+	query := tx.WithContext(ctx)
+	purged := query.Session(&gorm.Session{NewDB: true}).Unscoped().
+		Model(&entity.User{}).
+		Select("id").
+		Where("deleted_at < ?", cutoff)
+	for _, owned := range userOwnedModels {
+		if err := query.Unscoped().Where("user_id IN (?)", purged).Delete(owned).Error; err != nil {
+			slog.Error("failed to purge rows of deleted users", "error", err.Error())
+			return 0, err
+		}
+	}
+	if err := query.Table(query.NamingStrategy.JoinTableName(entity.UserGroupTable)).
+		Where("user_id IN (?)", purged).
+		Delete(nil).Error; err != nil {
+		slog.Error("failed to purge group memberships of deleted users", "error", err.Error())
+		return 0, err
+	}
+	return r.Repository.PurgeDeletedTx(ctx, tx, cutoff)
+}
+
+func (r *UserSQLRepo) ReplacePasswordTx(ctx context.Context, tx *gorm.DB, id, oldHash, newHash string) error {
+	if err := tx.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND password = ?", id, oldHash).
+		Update("password", newHash).Error; err != nil {
+		slog.Error("failed to replace password hash", "error", err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ownedRows creates one row of every model a user owns.
+func ownedRows(userID string) []any {
+	return []any{
+		&entity.Session{Id: uuid.NewString(), UserId: userID, ExpiresAt: time.Now().Add(time.Hour)},
+		&entity.RefreshToken{Id: uuid.NewString(), UserId: userID, TokenHash: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)},
+		&entity.UserToken{Id: uuid.NewString(), UserId: userID, TokenHash: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)},
+		&entity.UserMFA{Id: uuid.NewString(), UserId: userID, Secret: "JBSWY3DPEHPK3PXP"},
+		&entity.MFARecoveryCode{Id: uuid.NewString(), UserId: userID, CodeHash: uuid.NewString()},
+		&entity.APIKey{Id: uuid.NewString(), UserId: &userID, KeyHash: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)},
+		&entity.WebAuthnCredential{Id: uuid.NewString(), UserId: userID, CredentialId: uuid.NewString()},
+		&entity.WebAuthnChallenge{Id: uuid.NewString(), UserId: &userID, ChallengeHash: uuid.NewString()},
+		&entity.PasswordHistory{Id: uuid.NewString(), UserId: userID, PasswordHash: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe"},
+		&entity.FederatedIdentity{Id: uuid.NewString(), UserId: userID, Provider: "corp", Subject: userID},
+		&entity.OAuthAuthorizationCode{Id: uuid.NewString(), UserId: userID, CodeHash: uuid.NewString()},
+	}
+}
+
+func TestUserSQLRepo_PurgeDeletedTx(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(append(ownedRows(""), &entity.User{}, &entity.Group{})...))
+
+	group := &entity.Group{Id: uuid.NewString(), Name: "engineering"}
+	purged := &entity.User{
+		Id: uuid.NewString(), Username: "john_doe", Email: "john_doe@example.com",
+		DeletedAt: gorm.DeletedAt{Time: time.Now().Add(-60 * 24 * time.Hour), Valid: true},
+		Groups:    []*entity.Group{group},
+	}
+	kept := &entity.User{
+		Id: uuid.NewString(), Username: "jane_doe", Email: "jane_doe@example.com",
+		Groups: []*entity.Group{group},
+	}
+	require.NoError(t, db.Create(purged).Error)
+	require.NoError(t, db.Create(kept).Error)
+	for _, row := range append(ownedRows(purged.Id), ownedRows(kept.Id)...) {
+		require.NoError(t, db.Create(row).Error)
+	}
+
+	tx := db.Begin()
+	count, err := repository.NewUserSQLRepository().PurgeDeletedTx(ctx, tx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit().Error)
+
+	assert.Equal(t, int64(1), count)
+	var users int64
+	require.NoError(t, db.Unscoped().Model(&entity.User{}).Count(&users).Error)
+	assert.Equal(t, int64(1), users)
+	for _, model := range ownedRows("") {
+		var left, other int64
+		require.NoError(t, db.Model(model).Where("user_id = ?", purged.Id).Count(&left).Error)
+		require.NoError(t, db.Model(model).Where("user_id = ?", kept.Id).Count(&other).Error)
+		assert.Zero(t, left, "%T of the purged user", model)
+		assert.Equal(t, int64(1), other, "%T of the remaining user", model)
+	}
+	var memberships int64
+	require.NoError(t, db.Table(entity.UserGroupTable).Where("user_id = ?", purged.Id).Count(&memberships).Error)
+	assert.Zero(t, memberships)
+	require.NoError(t, db.Table(entity.UserGroupTable).Where("user_id = ?", kept.Id).Count(&memberships).Error)
+	assert.Equal(t, int64(1), memberships)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type UserTokenRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.UserToken) error
+	FindByColumn(ctx context.Context, tx *gorm.DB, column string, value any) (*entity.UserToken, error)
+	// ConsumeTx marks an unconsumed token as used. It returns false when the
+	// token had already been consumed, so a token redeems at most once.
+	ConsumeTx(ctx context.Context, tx *gorm.DB, id string, consumedAt time.Time) (bool, error)
+	// InvalidateTx consumes every outstanding token of purpose issued to the user.
+	InvalidateTx(ctx context.Context, tx *gorm.DB, userID, purpose string, consumedAt time.Time) error
+}
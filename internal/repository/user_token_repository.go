@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type UserTokenSQLRepo struct {
+	Repository[entity.UserToken]
+}
+
+func NewUserTokenSQLRepository() UserTokenRepository {
+	return &UserTokenSQLRepo{}
+}
+
+func (r *UserTokenSQLRepo) ConsumeTx(
+	ctx context.Context, tx *gorm.DB, id string, consumedAt time.Time,
+) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.UserToken{}).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update("consumed_at", consumedAt)
+	if result.Error != nil {
+		slog.Error("failed to consume user token", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserTokenSQLRepo) InvalidateTx(
+	ctx context.Context, tx *gorm.DB, userID, purpose string, consumedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", userID, purpose).
+		Update("consumed_at", consumedAt).Error; err != nil {
+		slog.Error("failed to invalidate user tokens", "error", err.Error())
+		return err
+	}
+	return nil
+}
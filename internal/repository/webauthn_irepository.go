@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type WebAuthnRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, data *entity.WebAuthnCredential) error
+	FindByCredentialID(ctx context.Context, tx *gorm.DB, credentialID string) (*entity.WebAuthnCredential, error)
+	FindByUser(ctx context.Context, tx *gorm.DB, userID string) ([]*entity.WebAuthnCredential, error)
+	// TouchTx stores the sign count reported by the last successful login
+	TouchTx(ctx context.Context, tx *gorm.DB, id string, signCount uint32, usedAt time.Time) error
+	// DeleteTx removes a credential of the given user. It returns false when
+	// the user has no such credential.
+	DeleteTx(ctx context.Context, tx *gorm.DB, id, userID string) (bool, error)
+	CreateChallengeTx(ctx context.Context, tx *gorm.DB, data *entity.WebAuthnChallenge) error
+	FindChallengeByHash(ctx context.Context, tx *gorm.DB, challengeHash string) (*entity.WebAuthnChallenge, error)
+	// DeleteChallengeTx removes a challenge so it can't be used twice. It
+	// returns false when the challenge was already used.
+	DeleteChallengeTx(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+}
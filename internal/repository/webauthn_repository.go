@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+)
+
+type WebAuthnSQLRepo struct {
+	Repository[entity.WebAuthnCredential]
+}
+
+func NewWebAuthnSQLRepository() WebAuthnRepository {
+	return &WebAuthnSQLRepo{}
+}
+
+func (r *WebAuthnSQLRepo) FindByCredentialID(
+	ctx context.Context, tx *gorm.DB, credentialID string,
+) (*entity.WebAuthnCredential, error) {
+	var data entity.WebAuthnCredential
+	if err := tx.WithContext(ctx).Where("credential_id = ?", credentialID).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find webauthn credential", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *WebAuthnSQLRepo) FindByUser(
+	ctx context.Context, tx *gorm.DB, userID string,
+) ([]*entity.WebAuthnCredential, error) {
+	var data []*entity.WebAuthnCredential
+	if err := tx.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&data).Error; err != nil {
+		slog.Error("failed to find webauthn credentials", "error", err.Error())
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *WebAuthnSQLRepo) TouchTx(
+	ctx context.Context, tx *gorm.DB, id string, signCount uint32, usedAt time.Time,
+) error {
+	if err := tx.WithContext(ctx).Model(&entity.WebAuthnCredential{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"sign_count": signCount, "last_used_at": usedAt}).Error; err != nil {
+		slog.Error("failed to record webauthn credential use", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *WebAuthnSQLRepo) DeleteTx(ctx context.Context, tx *gorm.DB, id, userID string) (bool, error) {
+	result := tx.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&entity.WebAuthnCredential{})
+	if result.Error != nil {
+		slog.Error("failed to delete webauthn credential", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *WebAuthnSQLRepo) CreateChallengeTx(ctx context.Context, tx *gorm.DB, data *entity.WebAuthnChallenge) error {
+	if err := tx.WithContext(ctx).Create(data).Error; err != nil {
+		slog.Error("failed to create webauthn challenge", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *WebAuthnSQLRepo) FindChallengeByHash(
+	ctx context.Context, tx *gorm.DB, challengeHash string,
+) (*entity.WebAuthnChallenge, error) {
+	var data entity.WebAuthnChallenge
+	if err := tx.WithContext(ctx).Where("challenge_hash = ?", challengeHash).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		slog.Error("failed to find webauthn challenge", "error", err.Error())
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *WebAuthnSQLRepo) DeleteChallengeTx(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.WebAuthnChallenge{})
+	if result.Error != nil {
+		slog.Error("failed to delete webauthn challenge", "error", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+type AccountService interface {
+	// SendEmailVerification replaces any outstanding verification token for the user and mails a new one
+	SendEmailVerification(ctx context.Context, user *entity.User) *exception.Exception
+	// VerifyEmail redeems a verification token and marks the owner's email as verified
+	VerifyEmail(ctx context.Context, model *entity.VerifyEmailRequest) *exception.Exception
+	// ResendVerification mails a new verification token. It succeeds silently for unknown or
+	// already verified addresses so it can't be used to discover accounts.
+	ResendVerification(ctx context.Context, model *entity.ResendVerificationRequest) *exception.Exception
+	// ForgotPassword mails a password reset token. Like ResendVerification it reports success for unknown addresses.
+	ForgotPassword(ctx context.Context, model *entity.ForgotPasswordRequest) *exception.Exception
+	// ResetPassword redeems a reset token, sets the new password and ends all of the user's sessions
+	ResetPassword(ctx context.Context, model *entity.ResetPasswordRequest) *exception.Exception
+	// ChangeExpiredPassword redeems the password change token of a login with an expired password
+	// and sets the new one. The user then logs in again.
+	ChangeExpiredPassword(ctx context.Context, model *entity.ChangeExpiredPasswordRequest) *exception.Exception
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/gateway/notification"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const userTokenBytes = 32
+
+// AccountConfig sets how long each emailed token lives and which page its link
+// opens. The token is appended to the URL as ?token=.
+type AccountConfig struct {
+	VerificationTTL time.Duration
+	VerificationURL string
+	// VerificationMaxPerEmail resent verification emails go to one address
+	// per VerificationWindow, zero turns the limit off.
+	VerificationMaxPerEmail int
+	VerificationWindow      time.Duration
+	PasswordResetTTL        time.Duration
+	PasswordResetURL        string
+	// PasswordResetMaxPerEmail reset emails are sent to one address per
+	// PasswordResetWindow, zero turns the limit off.
+	PasswordResetMaxPerEmail int
+	PasswordResetWindow      time.Duration
+}
+
+type AccountServiceImpl struct {
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	userTokenRepo  repository.UserTokenRepository
+	rateLimitRepo  repository.RateLimitRepository
+	signaturer     signature.Signaturer
+	tokenService   TokenService
+	passwordPolicy PasswordPolicyService
+	notifier       notification.Notifier
+	validate       *xvalidator.Validator
+	conf           *AccountConfig
+}
+
+func NewAccountService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	userTokenRepo repository.UserTokenRepository,
+	rateLimitRepo repository.RateLimitRepository,
+	signaturer signature.Signaturer,
+	tokenService TokenService,
+	passwordPolicy PasswordPolicyService,
+	notifier notification.Notifier,
+	validate *xvalidator.Validator,
+	conf *AccountConfig,
+) AccountService {
+	return &AccountServiceImpl{
+		db:             db,
+		userRepo:       userRepo,
+		userTokenRepo:  userTokenRepo,
+		rateLimitRepo:  rateLimitRepo,
+		signaturer:     signaturer,
+		tokenService:   tokenService,
+		passwordPolicy: passwordPolicy,
+		notifier:       notifier,
+		validate:       validate,
+		conf:           conf,
+	}
+}
+
+func (s *AccountServiceImpl) SendEmailVerification(ctx context.Context, user *entity.User) *exception.Exception {
+	if user.Email == "" {
+		return exception.InvalidArgument("user has no email address")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := issueUserToken(ctx, s.userTokenRepo, tx, user.Id, entity.TokenPurposeEmailVerification, s.conf.VerificationTTL)
+	if exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	if err := s.notifier.Send(ctx, &notification.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: "Open the link below to verify your email address:\n\n" +
+			linkWithToken(s.conf.VerificationURL, token) + "\n\n" +
+			"The link expires in " + s.conf.VerificationTTL.String() + ".",
+	}); err != nil {
+		return exception.Internal("failed to send verification email", err)
+	}
+	return nil
+}
+
+func (s *AccountServiceImpl) VerifyEmail(ctx context.Context, model *entity.VerifyEmailRequest) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := consumeUserToken(ctx, s.userTokenRepo, tx, entity.TokenPurposeEmailVerification, model.Token)
+	if exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByID(ctx, tx, token.UserId)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	if user.EmailVerifiedAt == nil {
+		user.VerifyEmail(time.Now())
+		if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+			return updateException(err)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *AccountServiceImpl) ResendVerification(
+	ctx context.Context, model *entity.ResendVerificationRequest,
+) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	// Counted before the lookup so the answer doesn't tell which addresses
+	// have an unverified account.
+	if exc := throttleAddress(
+		ctx, s.rateLimitRepo, "verify", model.Email, s.conf.VerificationMaxPerEmail, s.conf.VerificationWindow,
+		"too many verification emails requested for this address, try again later",
+	); exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByName(ctx, s.db, "email", model.Email)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.SendEmailVerification(ctx, user)
+}
+
+func (s *AccountServiceImpl) ForgotPassword(ctx context.Context, model *entity.ForgotPasswordRequest) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	// Counted whether or not the address has an account, like magic links.
+	if exc := throttleAddress(
+		ctx, s.rateLimitRepo, "reset", model.Email, s.conf.PasswordResetMaxPerEmail, s.conf.PasswordResetWindow,
+		"too many password resets requested for this address, try again later",
+	); exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByName(ctx, s.db, "email", model.Email)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := issueUserToken(ctx, s.userTokenRepo, tx, user.Id, entity.TokenPurposePasswordReset, s.conf.PasswordResetTTL)
+	if exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	// Reporting a delivery failure would reveal that the address has an account.
+	if err := s.notifier.Send(ctx, &notification.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: "Someone asked to reset the password of your account. Open the link below to choose a new one:\n\n" +
+			linkWithToken(s.conf.PasswordResetURL, token) + "\n\n" +
+			"The link expires in " + s.conf.PasswordResetTTL.String() + ". If you didn't ask for this, ignore this email.",
+	}); err != nil {
+		slog.Error("failed to send password reset email", "user_id", user.Id, "error", err.Error())
+	}
+	return nil
+}
+
+func (s *AccountServiceImpl) ResetPassword(ctx context.Context, model *entity.ResetPasswordRequest) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := consumeUserToken(ctx, s.userTokenRepo, tx, entity.TokenPurposePasswordReset, model.Token)
+	if exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByID(ctx, tx, token.UserId)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	// The token was delivered to the user's inbox, which proves they own the address.
+	if user.EmailVerifiedAt == nil {
+		user.VerifyEmail(time.Now())
+	}
+	if exc := s.setPassword(ctx, tx, user, model.Password); exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return s.tokenService.RevokeUserSessions(ctx, user.Id)
+}
+
+func (s *AccountServiceImpl) ChangeExpiredPassword(
+	ctx context.Context, model *entity.ChangeExpiredPasswordRequest,
+) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := consumeUserToken(ctx, s.userTokenRepo, tx, entity.TokenPurposePasswordChange, model.PasswordChangeToken)
+	if exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByID(ctx, tx, token.UserId)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	if exc := s.setPassword(ctx, tx, user, model.Password); exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return s.tokenService.RevokeUserSessions(ctx, user.Id)
+}
+
+// setPassword checks password against the policy, then stores its hash and
+// keeps it in the user's password history.
+func (s *AccountServiceImpl) setPassword(
+	ctx context.Context, tx *gorm.DB, user *entity.User, password string,
+) *exception.Exception {
+	if exc := s.passwordPolicy.Validate(ctx, tx, user, password); exc != nil {
+		return exc
+	}
+	hash, err := s.signaturer.HashPassword(password)
+	if err != nil {
+		return exception.Internal("can't create password", err)
+	}
+	now := time.Now()
+	user.Password = hash
+	user.PasswordChangedAt = &now
+	if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+		return updateException(err)
+	}
+	return s.passwordPolicy.RecordTx(ctx, tx, user)
+}
+
+// throttleAddress counts a request of kind for email and rejects it once the
+// address made more than max of them within window. The address is hashed so
+// it isn't stored and always fits the key. A zero max turns the limit off.
+func throttleAddress(
+	ctx context.Context, rateLimitRepo repository.RateLimitRepository,
+	kind, email string, max int, window time.Duration, message string,
+) *exception.Exception {
+	if max <= 0 {
+		return nil
+	}
+	now := time.Now()
+	key := kind + ":" + signature.HashToken(strings.ToLower(strings.TrimSpace(email)))
+	limit, err := rateLimitRepo.Hit(ctx, key, now, window)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if limit.Count > max {
+		return exception.TooManyRequests(message, limit.ExpiresAt.Sub(now))
+	}
+	return nil
+}
+
+// issueUserToken invalidates the user's outstanding tokens for purpose and
+// stores the hash of a fresh one. The plain token is returned for delivery.
+func issueUserToken(
+	ctx context.Context, repo repository.UserTokenRepository, tx *gorm.DB, userID, purpose string, ttl time.Duration,
+) (string, *exception.Exception) {
+	now := time.Now()
+	if err := repo.InvalidateTx(ctx, tx, userID, purpose, now); err != nil {
+		return "", exception.Internal("err", err)
+	}
+	token, err := signature.GenerateRandomToken(userTokenBytes)
+	if err != nil {
+		return "", exception.Internal("can't generate token", err)
+	}
+	if err := repo.CreateTx(ctx, tx, &entity.UserToken{
+		Id:        uuid.NewString(),
+		UserId:    userID,
+		Purpose:   purpose,
+		TokenHash: signature.HashToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}); err != nil {
+		return "", exception.Internal("err", err)
+	}
+	return token, nil
+}
+
+// consumeUserToken redeems a token issued for purpose. Unknown, expired, spent
+// and wrong-purpose tokens are all reported the same way.
+func consumeUserToken(
+	ctx context.Context, repo repository.UserTokenRepository, tx *gorm.DB, purpose, token string,
+) (*entity.UserToken, *exception.Exception) {
+	stored, err := repo.FindByColumn(ctx, tx, "token_hash", signature.HashToken(token))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	now := time.Now()
+	if stored == nil || !stored.IsUsable(purpose, now) {
+		return nil, exception.InvalidArgument("invalid or expired token")
+	}
+	consumed, err := repo.ConsumeTx(ctx, tx, stored.Id, now)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !consumed {
+		return nil, exception.InvalidArgument("invalid or expired token")
+	}
+	return stored, nil
+}
+
+func linkWithToken(base, token string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		slog.Error("invalid link base url", "error", err.Error())
+		return base + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
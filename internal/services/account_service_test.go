@@ -0,0 +1,641 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/gateway/notification"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+var accountConfig = &service.AccountConfig{
+	VerificationTTL:          time.Hour,
+	VerificationURL:          "https://example.com/verify",
+	VerificationMaxPerEmail:  3,
+	VerificationWindow:       time.Hour,
+	PasswordResetTTL:         30 * time.Minute,
+	PasswordResetURL:         "https://example.com/reset-password",
+	PasswordResetMaxPerEmail: 3,
+	PasswordResetWindow:      time.Hour,
+}
+
+func TestSendEmailVerification(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:    "123e4567-e89b-12d3-a456-426614174000",
+		Email: "john_doe@example.com",
+	}
+
+	t.Run("SendEmailVerification Success", func(t *testing.T) {
+		// Mocks
+		var stored *entity.UserToken
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, user.Id, entity.TokenPurposeEmailVerification, mock.Anything).Return(nil)
+		mockUserTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.UserToken) bool {
+			stored = token
+			return token.UserId == user.Id && token.Purpose == entity.TokenPurposeEmailVerification
+		})).Return(nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockNotifier.On("Send", mockAppCtx, mock.MatchedBy(func(message *notification.Message) bool {
+			_, token, ok := strings.Cut(message.Body, accountConfig.VerificationURL+"?token=")
+			token, _, _ = strings.Cut(token, "\n")
+			return ok && message.To == user.Email && signature.HashToken(token) == stored.TokenHash
+		})).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.SendEmailVerification(mockAppCtx, user)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNotifier.AssertExpectations(t)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("SendEmailVerification Notifier Failed", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, user.Id, entity.TokenPurposeEmailVerification, mock.Anything).Return(nil)
+		mockUserTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockNotifier.On("Send", mockAppCtx, mock.Anything).Return(errors.New("smtp unavailable"))
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.SendEmailVerification(mockAppCtx, user)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InternalErrorCode, errService.Code)
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	mockAppCtx := context.Background()
+	request := &entity.VerifyEmailRequest{Token: "verification_token"}
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    userID,
+			Purpose:   entity.TokenPurposeEmailVerification,
+			TokenHash: signature.HashToken(request.Token),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("VerifyEmail Success", func(t *testing.T) {
+		token := newToken()
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID}, nil)
+		mockUserRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.EmailVerifiedAt != nil
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.VerifyEmail(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("VerifyEmail Already Consumed", func(t *testing.T) {
+		token := newToken()
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(false, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		errService := mockService.VerifyEmail(mockAppCtx, request)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("VerifyEmail Expired", func(t *testing.T) {
+		token := newToken()
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		errService := mockService.VerifyEmail(mockAppCtx, request)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockUserTokenRepository.AssertNotCalled(t, "ConsumeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyEmail Wrong Purpose", func(t *testing.T) {
+		token := newToken()
+		token.Purpose = "some_other_purpose"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		errService := mockService.VerifyEmail(mockAppCtx, request)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
+
+func TestResendVerification(t *testing.T) {
+	mockAppCtx := context.Background()
+	request := &entity.ResendVerificationRequest{Email: "john_doe@example.com"}
+
+	t.Run("ResendVerification Unknown Email", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "verify:") && !strings.Contains(key, request.Email)
+		}), mock.Anything, accountConfig.VerificationWindow).Return(&entity.RateLimit{Count: 1}, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ResendVerification(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRateLimitRepository.AssertExpectations(t)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResendVerification Already Verified", func(t *testing.T) {
+		verifiedAt := time.Now()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(&entity.User{
+			Id: "123e4567-e89b-12d3-a456-426614174000", Email: request.Email, EmailVerifiedAt: &verifiedAt,
+		}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, accountConfig.VerificationWindow).Return(&entity.RateLimit{Count: 1}, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ResendVerification(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResendVerification Invalid Email", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ResendVerification(mockAppCtx, &entity.ResendVerificationRequest{Email: "not-an-email"})
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("ResendVerification Rate Limited", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, accountConfig.VerificationWindow).Return(&entity.RateLimit{
+			Count: 4, ExpiresAt: time.Now().Add(20 * time.Minute),
+		}, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ResendVerification(mockAppCtx, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.TooManyRequestsCode, errService.Code)
+		assert.InDelta(t, (20 * time.Minute).Seconds(), errService.RetryAfter.Seconds(), 5)
+		mockUserRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestForgotPassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	request := &entity.ForgotPasswordRequest{Email: "john_doe@example.com"}
+
+	t.Run("ForgotPassword Success", func(t *testing.T) {
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Email: request.Email}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(user, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "reset:") && !strings.Contains(key, request.Email)
+		}), mock.Anything, accountConfig.PasswordResetWindow).Return(&entity.RateLimit{Count: 1}, nil)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, user.Id, entity.TokenPurposePasswordReset, mock.Anything).Return(nil)
+		mockUserTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.UserToken) bool {
+			return token.Purpose == entity.TokenPurposePasswordReset && token.ExpiresAt.Before(time.Now().Add(accountConfig.PasswordResetTTL+time.Minute))
+		})).Return(nil)
+		mockNotifier := new(mocks.Notifier)
+		mockNotifier.On("Send", mockAppCtx, mock.MatchedBy(func(message *notification.Message) bool {
+			return message.To == user.Email && strings.Contains(message.Body, accountConfig.PasswordResetURL+"?token=")
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.ForgotPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserTokenRepository.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("ForgotPassword Unknown Email", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, accountConfig.PasswordResetWindow).Return(&entity.RateLimit{Count: 1}, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ForgotPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ForgotPassword Rate Limited", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, accountConfig.PasswordResetWindow).Return(&entity.RateLimit{
+			Count: 4, ExpiresAt: time.Now().Add(20 * time.Minute),
+		}, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		errService := mockService.ForgotPassword(mockAppCtx, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.TooManyRequestsCode, errService.Code)
+		assert.InDelta(t, (20 * time.Minute).Seconds(), errService.RetryAfter.Seconds(), 5)
+		mockUserRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	request := &entity.ResetPasswordRequest{Token: "reset_token", Password: "NewSecurePass123!"}
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    userID,
+			Purpose:   entity.TokenPurposePasswordReset,
+			TokenHash: signature.HashToken(request.Token),
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	}
+
+	t.Run("ResetPassword Success", func(t *testing.T) {
+		token := newToken()
+		newHash := "$2a$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID, Password: "old_hash"}, nil)
+		mockUserRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Password == newHash && user.EmailVerifiedAt != nil
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("HashPassword", request.Password).Return(newHash, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("RevokeUserSessions", mockAppCtx, userID).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Password == newHash && user.PasswordChangedAt != nil
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.ResetPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+		mockPasswordPolicyService.AssertExpectations(t)
+	})
+
+	t.Run("ResetPassword Weak Password", func(t *testing.T) {
+		token := newToken()
+		violations := map[string][]xvalidator.PasswordViolation{"password": {
+			{Rule: xvalidator.PasswordRuleMinLength, Message: "must be at least 8 characters long"},
+		}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID, Password: "old_hash"}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, "short").Return(exception.InvalidArgument(violations))
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.ResetPassword(mockAppCtx, &entity.ResetPasswordRequest{Token: request.Token, Password: "short"})
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Equal(t, violations, errService.Message)
+		mockSignaturer.AssertNotCalled(t, "HashPassword", mock.Anything)
+		mockUserRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResetPassword Verification Token Rejected", func(t *testing.T) {
+		token := newToken()
+		token.Purpose = entity.TokenPurposeEmailVerification
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		errService := mockService.ResetPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangeExpiredPassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	request := &entity.ChangeExpiredPasswordRequest{PasswordChangeToken: "change_token", Password: "NewSecurePass123!"}
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    userID,
+			Purpose:   entity.TokenPurposePasswordChange,
+			TokenHash: signature.HashToken(request.PasswordChangeToken),
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	}
+
+	t.Run("ChangeExpiredPassword Success", func(t *testing.T) {
+		token := newToken()
+		newHash := "$2a$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+		changedAt := time.Now().AddDate(0, -6, 0)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID, Password: "old_hash", PasswordChangedAt: &changedAt}, nil)
+		mockUserRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Password == newHash && user.PasswordChangedAt.After(changedAt)
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("HashPassword", request.Password).Return(newHash, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("RevokeUserSessions", mockAppCtx, userID).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Password == "old_hash"
+		}), request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.ChangeExpiredPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertExpectations(t)
+		mockPasswordPolicyService.AssertExpectations(t)
+	})
+
+	t.Run("ChangeExpiredPassword Reset Token Rejected", func(t *testing.T) {
+		token := newToken()
+		token.Purpose = entity.TokenPurposePasswordReset
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockNotifier := new(mocks.Notifier)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAccountService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockSignaturer, mockTokenService, mockPasswordPolicyService, mockNotifier, validate, accountConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.ChangeExpiredPassword(mockAppCtx, request)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockUserTokenRepository.AssertNotCalled(t, "ConsumeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+// APIKeyService manages API keys. userID selects whose keys are meant: a
+// user's personal access tokens, or the service account keys when it is nil.
+type APIKeyService interface {
+	// Create issues a key and returns it in plain text, the only time it is shown
+	Create(ctx context.Context, userID *string, model *entity.APIKeyRequest) (*entity.APIKeySecret, *exception.Exception)
+	List(ctx context.Context, userID *string) ([]*entity.APIKey, *exception.Exception)
+	Revoke(ctx context.Context, userID *string, id string) *exception.Exception
+	// Authenticate resolves a key to the identity and permissions it acts with
+	Authenticate(ctx context.Context, key string) (*signature.JwtAuthenticationRes, *exception.Exception)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const (
+	apiKeyBytes        = 32
+	apiKeyPrefixLength = 12
+	// apiKeyTouchInterval limits how often last_used_at is written for a busy key
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyServiceImpl struct {
+	db         *gorm.DB
+	userRepo   repository.UserRepository
+	apiKeyRepo repository.APIKeyRepository
+	validate   *xvalidator.Validator
+	// maxTTL is the longest lifetime a key may be given, and the default one
+	maxTTL time.Duration
+}
+
+func NewAPIKeyService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	validate *xvalidator.Validator,
+	maxTTL time.Duration,
+) APIKeyService {
+	return &APIKeyServiceImpl{
+		db:         db,
+		userRepo:   userRepo,
+		apiKeyRepo: apiKeyRepo,
+		validate:   validate,
+		maxTTL:     maxTTL,
+	}
+}
+
+func (s *APIKeyServiceImpl) Create(
+	ctx context.Context, userID *string, model *entity.APIKeyRequest,
+) (*entity.APIKeySecret, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.maxTTL)
+	if model.ExpiresAt != nil {
+		if !model.ExpiresAt.After(now) {
+			return nil, exception.InvalidArgument("expires_at must be in the future")
+		}
+		if model.ExpiresAt.After(expiresAt) {
+			return nil, exception.InvalidArgument("expires_at exceeds the maximum lifetime of " + s.maxTTL.String())
+		}
+		expiresAt = *model.ExpiresAt
+	}
+	for _, scope := range model.Scopes {
+		if !entity.IsValidPermission(scope) {
+			return nil, exception.InvalidArgument("unknown scope " + scope)
+		}
+	}
+	if userID != nil {
+		user, err := s.userRepo.FindByID(ctx, s.db, *userID)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if user == nil {
+			return nil, exception.NotFound("user not found")
+		}
+		granted := entity.PermissionsFor(user.RoleNames())
+		for _, scope := range model.Scopes {
+			if !slices.Contains(granted, scope) {
+				return nil, exception.PermissionDenied("scope " + scope + " is not granted to the user")
+			}
+		}
+	}
+	random, err := signature.GenerateRandomToken(apiKeyBytes)
+	if err != nil {
+		return nil, exception.Internal("can't generate api key", err)
+	}
+	key := entity.APIKeyPrefix + random
+	data := &entity.APIKey{
+		Id:        uuid.NewString(),
+		UserId:    userID,
+		Name:      model.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   signature.HashToken(key),
+		Scopes:    model.Scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.apiKeyRepo.CreateTx(ctx, tx, data); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &entity.APIKeySecret{APIKey: *data, Key: key}, nil
+}
+
+func (s *APIKeyServiceImpl) List(ctx context.Context, userID *string) ([]*entity.APIKey, *exception.Exception) {
+	data, err := s.apiKeyRepo.FindByOwner(ctx, s.db, userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return data, nil
+}
+
+func (s *APIKeyServiceImpl) Revoke(ctx context.Context, userID *string, id string) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid api key id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	revoked, err := s.apiKeyRepo.RevokeTx(ctx, tx, id, userID, time.Now())
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if !revoked {
+		return exception.NotFound("api key not found")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *APIKeyServiceImpl) Authenticate(
+	ctx context.Context, key string,
+) (*signature.JwtAuthenticationRes, *exception.Exception) {
+	if !strings.HasPrefix(key, entity.APIKeyPrefix) {
+		return nil, exception.Unauthenticated("Invalid API key")
+	}
+	data, err := s.apiKeyRepo.FindByColumn(ctx, s.db, "key_hash", signature.HashToken(key))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	now := time.Now()
+	if data == nil || !data.IsUsable(now) {
+		return nil, exception.Unauthenticated("Invalid API key")
+	}
+	res := &signature.JwtAuthenticationRes{
+		Username:    data.Name,
+		Permissions: data.Scopes,
+		TokenID:     data.Id,
+		IssuedAt:    data.CreatedAt,
+		ExpiresAt:   data.ExpiresAt,
+		APIKey:      true,
+	}
+	if data.UserId != nil {
+		// A personal token never grants more than its owner currently holds
+		user, err := s.userRepo.FindByID(ctx, s.db, *data.UserId)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if user == nil {
+			return nil, exception.Unauthenticated("Invalid API key")
+		}
+		if exc := userStatusException(user, now); exc != nil {
+			return nil, exc
+		}
+		granted := entity.PermissionsFor(user.RoleNames())
+		permissions := make([]string, 0, len(data.Scopes))
+		for _, scope := range data.Scopes {
+			if slices.Contains(granted, scope) {
+				permissions = append(permissions, scope)
+			}
+		}
+		res.Username = user.Username
+		res.Subject = user.Id
+		res.Roles = user.RoleNames()
+		res.Permissions = permissions
+	}
+	if data.LastUsedAt == nil || now.Sub(*data.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchTx(ctx, s.db, data.Id, now); err != nil {
+			slog.Error("failed to record api key use", "api_key_id", data.Id, "error", err.Error())
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,309 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const apiKeyMaxTTL = 365 * 24 * time.Hour
+
+func TestCreateAPIKey(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+		Roles:    []string{entity.RoleUser},
+	}
+
+	t.Run("CreateAPIKey Personal Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.APIKeyRequest{Name: "nightly-export", Scopes: []string{entity.PermissionUsersRead}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(key *entity.APIKey) bool {
+			return *key.UserId == user.Id && key.KeyHash != "" && key.Name == request.Name &&
+				key.ExpiresAt.After(time.Now().Add(apiKeyMaxTTL-time.Minute))
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Create(mockAppCtx, &user.Id, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.True(t, strings.HasPrefix(result.Key, entity.APIKeyPrefix))
+		assert.True(t, strings.HasPrefix(result.Key, result.Prefix))
+		assert.Equal(t, signature.HashToken(result.Key), result.KeyHash)
+		mockAPIKeyRepository.AssertExpectations(t)
+	})
+
+	t.Run("CreateAPIKey Scope Not Granted", func(t *testing.T) {
+		// Set up input
+		request := &entity.APIKeyRequest{Name: "cleanup", Scopes: []string{entity.PermissionUsersDelete}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Create(mockAppCtx, &user.Id, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		assert.Nil(t, result)
+		mockAPIKeyRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateAPIKey Service Account Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.APIKeyRequest{Name: "billing", Scopes: []string{entity.PermissionUsersDelete}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(key *entity.APIKey) bool {
+			return key.UserId == nil
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Create(mockAppCtx, nil, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.NotEmpty(t, result.Key)
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateAPIKey Unknown Scope", func(t *testing.T) {
+		// Set up input
+		request := &entity.APIKeyRequest{Name: "billing", Scopes: []string{"everything:all"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Create(mockAppCtx, nil, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Nil(t, result)
+	})
+
+	t.Run("CreateAPIKey Expiry Too Far", func(t *testing.T) {
+		// Set up input
+		expiresAt := time.Now().Add(2 * apiKeyMaxTTL)
+		request := &entity.APIKeyRequest{Name: "billing", Scopes: []string{entity.PermissionUsersRead}, ExpiresAt: &expiresAt}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Create(mockAppCtx, nil, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	keyID := "223e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("RevokeAPIKey Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("RevokeTx", mockAppCtx, mock.Anything, keyID, &userID, mock.Anything).Return(true, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Revoke(mockAppCtx, &userID, keyID)
+
+		// Assert the result
+		assert.Nil(t, errService)
+	})
+
+	t.Run("RevokeAPIKey Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("RevokeTx", mockAppCtx, mock.Anything, keyID, &userID, mock.Anything).Return(false, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Revoke(mockAppCtx, &userID, keyID)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	mockAppCtx := context.Background()
+	key := entity.APIKeyPrefix + "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	user := &entity.User{Id: userID, Username: "john_doe", Roles: []string{entity.RoleUser}}
+
+	t.Run("AuthenticateAPIKey Personal Success", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(user, nil)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("FindByColumn", mockAppCtx, mock.Anything, "key_hash", signature.HashToken(key)).Return(&entity.APIKey{
+			Id:        "223e4567-e89b-12d3-a456-426614174000",
+			UserId:    &userID,
+			Name:      "nightly-export",
+			Scopes:    []string{entity.PermissionUsersRead, entity.PermissionUsersDelete},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mockAPIKeyRepository.On("TouchTx", mockAppCtx, mock.Anything, "223e4567-e89b-12d3-a456-426614174000", mock.Anything).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, key)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, userID, result.Subject)
+		assert.Equal(t, []string{entity.PermissionUsersRead}, result.Permissions, "scopes the owner lost are dropped")
+		assert.True(t, result.APIKey)
+		assert.False(t, result.IsFirstParty())
+		mockAPIKeyRepository.AssertExpectations(t)
+	})
+
+	t.Run("AuthenticateAPIKey Service Account Success", func(t *testing.T) {
+		lastUsedAt := time.Now()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("FindByColumn", mockAppCtx, mock.Anything, "key_hash", signature.HashToken(key)).Return(&entity.APIKey{
+			Id:         "223e4567-e89b-12d3-a456-426614174000",
+			Name:       "billing",
+			Scopes:     []string{entity.PermissionUsersDelete},
+			ExpiresAt:  time.Now().Add(time.Hour),
+			LastUsedAt: &lastUsedAt,
+		}, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, key)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Empty(t, result.Subject)
+		assert.True(t, result.HasPermission(entity.PermissionUsersDelete))
+		mockAPIKeyRepository.AssertNotCalled(t, "TouchTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("AuthenticateAPIKey Revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("FindByColumn", mockAppCtx, mock.Anything, "key_hash", signature.HashToken(key)).Return(&entity.APIKey{
+			Id:        "223e4567-e89b-12d3-a456-426614174000",
+			Scopes:    []string{entity.PermissionUsersRead},
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, key)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		assert.Nil(t, result)
+	})
+
+	t.Run("AuthenticateAPIKey Expired", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAPIKeyRepository := new(mocks.APIKeyRepository)
+		mockAPIKeyRepository.On("FindByColumn", mockAppCtx, mock.Anything, "key_hash", signature.HashToken(key)).Return(&entity.APIKey{
+			Id:        "223e4567-e89b-12d3-a456-426614174000",
+			Scopes:    []string{entity.PermissionUsersRead},
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewAPIKeyService(gormDB, mockUserRepository, mockAPIKeyRepository, validate, apiKeyMaxTTL)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, key)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		assert.Nil(t, result)
+	})
+}
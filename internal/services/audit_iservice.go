@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	"user-simple-crud/pkg/exception"
+)
+
+// AuditService keeps the record of who changed what.
+type AuditService interface {
+	// RecordTx writes an entry for a change made in tx, so it is only kept if
+	// the change commits. before and after are the record's states, nil when it
+	// didn't exist; only the fields that differ are stored and secrets are redacted.
+	RecordTx(
+		ctx context.Context, tx *gorm.DB, actor entity.AuditActor, action, targetID string, before, after any,
+	) *exception.Exception
+	List(ctx context.Context, req model.ListReq) (*ListAuditResp, *exception.Exception)
+}
+
+type ListAuditResp struct {
+	Pagination *model.Pagination  `json:"pagination"`
+	Data       []*entity.AuditLog `json:"data"`
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"reflect"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+)
+
+// auditRedactedFields are the JSON fields whose values never reach the audit log.
+var auditRedactedFields = map[string]bool{
+	"password": true,
+}
+
+// auditFilterColumns are the fields the audit log can be filtered and sorted
+// on and the columns they map to.
+var auditFilterColumns = map[string]string{
+	"actor":      "actor_id",
+	"action":     "action",
+	"target":     "target_id",
+	"created_at": "created_at",
+}
+
+type AuditServiceImpl struct {
+	db        *gorm.DB
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(db *gorm.DB, auditRepo repository.AuditRepository) AuditService {
+	return &AuditServiceImpl{
+		db:        db,
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditServiceImpl) RecordTx(
+	ctx context.Context, tx *gorm.DB, actor entity.AuditActor, action, targetID string, before, after any,
+) *exception.Exception {
+	changes, err := auditDiff(before, after)
+	if err != nil {
+		return exception.Internal("failed to diff audited record", err)
+	}
+	entry := &entity.AuditLog{
+		Id:             uuid.NewString(),
+		ActorId:        actor.UserId,
+		ActorName:      actor.Username,
+		ImpersonatorId: actor.ImpersonatorId,
+		Action:         action,
+		TargetId:       targetID,
+		Changes:        changes,
+		RequestId:      actor.RequestId,
+		IpAddress:      actor.IpAddress,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.auditRepo.CreateTx(ctx, tx, entry); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+func (s *AuditServiceImpl) List(ctx context.Context, req model.ListReq) (*ListAuditResp, *exception.Exception) {
+	if req.Order.OrderBy == "" {
+		req.Order = model.OrderParam{OrderBy: "created_at", Order: "desc"}
+	}
+	filter, err := req.Filter.Resolve(auditFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	order, err := req.Order.Resolve(auditFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	result, err := s.auditRepo.FindByPagination(ctx, s.db, req.Page, order, filter)
+	if err != nil {
+		return nil, exception.Internal("failed to get audit log", err)
+	}
+	return &ListAuditResp{
+		Pagination: &model.Pagination{
+			Page:             result.Page,
+			PageSize:         result.PageSize,
+			TotalPage:        result.TotalPage,
+			TotalDataPerPage: result.TotalDataPerPage,
+			TotalData:        result.TotalData,
+		},
+		Data: result.Data,
+	}, nil
+}
+
+// auditDiff compares the JSON forms of before and after field by field.
+func auditDiff(before, after any) (map[string]entity.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[string]entity.AuditChange)
+	for name, from := range beforeFields {
+		if to, ok := afterFields[name]; !ok || !reflect.DeepEqual(from, to) {
+			changes[name] = auditChange(name, from, afterFields[name])
+		}
+	}
+	for name, to := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = auditChange(name, nil, to)
+		}
+	}
+	return changes, nil
+}
+
+func auditFields(record any) (map[string]any, error) {
+	if record == nil || reflect.ValueOf(record).Kind() == reflect.Ptr && reflect.ValueOf(record).IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	// Fields kept out of JSON, such as the password hash, are still compared
+	// under the name of their audit tag.
+	val := reflect.Indirect(reflect.ValueOf(record))
+	if val.Kind() == reflect.Struct {
+		for i := 0; i < val.NumField(); i++ {
+			if name := val.Type().Field(i).Tag.Get("audit"); name != "" {
+				fields[name] = val.Field(i).Interface()
+			}
+		}
+	}
+	return fields, nil
+}
+
+func auditChange(name string, from, to any) entity.AuditChange {
+	if auditRedactedFields[name] {
+		if from != nil {
+			from = entity.AuditRedacted
+		}
+		if to != nil {
+			to = entity.AuditRedacted
+		}
+	}
+	return entity.AuditChange{From: from, To: to}
+}
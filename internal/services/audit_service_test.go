@@ -0,0 +1,218 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+func TestRecordAudit(t *testing.T) {
+	mockAppCtx := context.Background()
+	actor := entity.AuditActor{
+		UserId:     "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		Username:   "admin",
+		RequestId:  "5f0c6a1e-9a4b-4c8e-8a43-0e2f4b7f5d21",
+		ClientInfo: entity.ClientInfo{IpAddress: "203.0.113.7"},
+	}
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("RecordAudit Only Changed Fields", func(t *testing.T) {
+		before := &entity.User{Id: id, Username: "john_doe", Email: "john_doe@example.com", Password: "old_hash"}
+		after := &entity.User{Id: id, Username: "john_doe", Email: "johnny@example.com", Password: "new_hash"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("CreateTx", mockAppCtx, gormDB, mock.MatchedBy(func(entry *entity.AuditLog) bool {
+			_, usernameChanged := entry.Changes["username"]
+			return entry.ActorId == actor.UserId && entry.ActorName == actor.Username &&
+				entry.RequestId == actor.RequestId && entry.IpAddress == actor.IpAddress &&
+				entry.Action == entity.AuditUserUpdate && entry.TargetId == id &&
+				!usernameChanged &&
+				entry.Changes["email"] == entity.AuditChange{From: "john_doe@example.com", To: "johnny@example.com"} &&
+				entry.Changes["password"] == entity.AuditChange{From: entity.AuditRedacted, To: entity.AuditRedacted}
+		})).Return(nil)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		errService := mockService.RecordTx(mockAppCtx, gormDB, actor, entity.AuditUserUpdate, id, before, after)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("RecordAudit Created Record", func(t *testing.T) {
+		after := &entity.User{Id: id, Username: "john_doe", Password: "new_hash"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("CreateTx", mockAppCtx, gormDB, mock.MatchedBy(func(entry *entity.AuditLog) bool {
+			return entry.Changes["username"] == entity.AuditChange{From: nil, To: "john_doe"} &&
+				entry.Changes["password"] == entity.AuditChange{From: nil, To: entity.AuditRedacted}
+		})).Return(nil)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		var before *entity.User
+		errService := mockService.RecordTx(mockAppCtx, gormDB, actor, entity.AuditUserCreate, id, before, after)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("RecordAudit Repository Error", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("CreateTx", mockAppCtx, gormDB, mock.Anything).Return(errors.New("test error"))
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		errService := mockService.RecordTx(mockAppCtx, gormDB, actor, entity.AuditUserDelete, id, nil, &entity.User{Id: id})
+
+		// Assert the result
+		assert.NotNil(t, errService)
+	})
+}
+
+func TestListAudit(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("ListAudit Newest First By Default", func(t *testing.T) {
+		req := model.ListReq{Page: model.PaginationParam{Page: 1, PageSize: 10}}
+		response := &model.PaginationData[entity.AuditLog]{
+			Page:     1,
+			PageSize: 10,
+			Data:     []*entity.AuditLog{{Action: entity.AuditUserCreate}},
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page,
+			model.OrderParam{OrderBy: "created_at", Order: "desc"}, req.Filter).Return(response, nil)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Len(t, result.Data, 1)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ListAudit Filter Columns", func(t *testing.T) {
+		req := model.ListReq{Filter: model.FilterParams{
+			{Field: "actor", Value: "123e4567-e89b-12d3-a456-426614174000", Operator: "="},
+			{Field: "target", Value: "0b8d3f3d-d343-4390-964c-4f05c4c803d6", Operator: "="},
+		}}
+		response := &model.PaginationData[entity.AuditLog]{Page: 1, PageSize: 10}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, mock.Anything, model.FilterParams{
+			{Field: "actor_id", Value: "123e4567-e89b-12d3-a456-426614174000", Operator: "="},
+			{Field: "target_id", Value: "0b8d3f3d-d343-4390-964c-4f05c4c803d6", Operator: "="},
+		}).Return(response, nil)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		_, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ListAudit Unknown Filter", func(t *testing.T) {
+		req := model.ListReq{Filter: model.FilterParams{{Field: "changes", Value: "password", Operator: "like"}}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListAudit Sort Column", func(t *testing.T) {
+		req := model.ListReq{Order: model.OrderParam{OrderBy: "actor", Order: "asc"}}
+		response := &model.PaginationData[entity.AuditLog]{Page: 1, PageSize: 10}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page,
+			model.OrderParam{OrderBy: "actor_id", Order: "asc"}, req.Filter).Return(response, nil)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		_, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ListAudit Unknown Sort", func(t *testing.T) {
+		req := model.ListReq{Order: model.OrderParam{OrderBy: "changes", Order: "asc"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListAudit Repository Error", func(t *testing.T) {
+		req := model.ListReq{Order: model.OrderParam{OrderBy: "action", Order: "asc"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.AuditRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).
+			Return(nil, errors.New("test error"))
+
+		mockService := service.NewAuditService(gormDB, mockRepository)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.NotNil(t, errService)
+	})
+}
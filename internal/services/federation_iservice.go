@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+// FederationService signs users in through external OpenID Connect providers.
+type FederationService interface {
+	// Providers lists the names of the configured providers
+	Providers() []string
+	// Begin starts a login at the provider. It returns the URL to send the user
+	// to and the state, which must be bound to the user's browser and handed
+	// back to Complete.
+	Begin(ctx context.Context, provider string) (string, string, *exception.Exception)
+	// Complete verifies the provider's answer, links or creates the user and
+	// starts a session for them. browserState is the state bound to the browser by Begin.
+	Complete(
+		ctx context.Context, provider string, model *entity.FederatedCallbackRequest, browserState string,
+		client entity.ClientInfo,
+	) (*UserLoginResponse, *exception.Exception)
+}
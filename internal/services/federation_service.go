@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/oidc"
+	"user-simple-crud/pkg/signature"
+)
+
+const (
+	federationStateBytes = 32
+	// usernameAttempts bounds the search for a free username for a new user
+	usernameAttempts = 5
+)
+
+type FederationServiceImpl struct {
+	db           *gorm.DB
+	userRepo     repository.UserRepository
+	fedRepo      repository.FederationRepository
+	tokenService TokenService
+	providers    map[string]oidc.Provider
+	stateTTL     time.Duration
+}
+
+func NewFederationService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	fedRepo repository.FederationRepository,
+	tokenService TokenService,
+	providers map[string]oidc.Provider,
+	stateTTL time.Duration,
+) FederationService {
+	return &FederationServiceImpl{
+		db:           db,
+		userRepo:     userRepo,
+		fedRepo:      fedRepo,
+		tokenService: tokenService,
+		providers:    providers,
+		stateTTL:     stateTTL,
+	}
+}
+
+func (s *FederationServiceImpl) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *FederationServiceImpl) Begin(ctx context.Context, name string) (string, string, *exception.Exception) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return "", "", exception.NotFound("unknown identity provider " + name)
+	}
+	var values [3]string
+	for i := range values {
+		value, err := signature.GenerateRandomToken(federationStateBytes)
+		if err != nil {
+			return "", "", exception.Internal("can't generate login state", err)
+		}
+		values[i] = value
+	}
+	state, nonce, verifier := values[0], values[1], values[2]
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		slog.Error("failed to reach identity provider", "provider", name, "error", err.Error())
+		return "", "", exception.Internal("identity provider is unavailable", err)
+	}
+	now := time.Now()
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.fedRepo.CreateStateTx(ctx, tx, &entity.FederatedLoginState{
+		Id:           uuid.NewString(),
+		StateHash:    signature.HashToken(state),
+		Provider:     name,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    now.Add(s.stateTTL),
+		CreatedAt:    now,
+	}); err != nil {
+		return "", "", exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return "", "", exception.Internal("commit transaction", err)
+	}
+	return authURL, state, nil
+}
+
+func (s *FederationServiceImpl) Complete(
+	ctx context.Context, name string, model *entity.FederatedCallbackRequest, browserState string,
+	client entity.ClientInfo,
+) (*UserLoginResponse, *exception.Exception) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, exception.NotFound("unknown identity provider " + name)
+	}
+	// The state must come back to the browser that started the login, which
+	// stops an attacker from completing a login into their own account
+	if model.State == "" || subtle.ConstantTimeCompare([]byte(model.State), []byte(browserState)) != 1 {
+		return nil, exception.PermissionDenied("login state does not match")
+	}
+	state, err := s.fedRepo.FindStateByHash(ctx, s.db, signature.HashToken(model.State))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if state == nil || state.Provider != name || time.Now().After(state.ExpiresAt) {
+		return nil, exception.PermissionDenied("login state is invalid or has expired")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	deleted, err := s.fedRepo.DeleteStateTx(ctx, tx, state.Id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !deleted {
+		return nil, exception.PermissionDenied("login state is invalid or has expired")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	if model.Error != "" {
+		return nil, exception.PermissionDenied("identity provider denied the login: " + model.Error)
+	}
+	if model.Code == "" {
+		return nil, exception.InvalidArgument("code is required")
+	}
+	claims, err := provider.Exchange(ctx, model.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		slog.Error("failed to complete federated login", "provider", name, "error", err.Error())
+		return nil, exception.Unauthenticated("identity provider login could not be verified")
+	}
+	user, exc := s.resolveUser(ctx, name, claims)
+	if exc != nil {
+		return nil, exc
+	}
+	return s.tokenService.Issue(ctx, user, client)
+}
+
+// resolveUser finds the user the provider account is linked to. An unlinked
+// account is linked to the user with the same email, but only when the
+// provider has verified it; otherwise a new user is created for it.
+func (s *FederationServiceImpl) resolveUser(
+	ctx context.Context, name string, claims *oidc.IDTokenClaims,
+) (*entity.User, *exception.Exception) {
+	now := time.Now()
+	identity, err := s.fedRepo.FindIdentity(ctx, s.db, name, claims.Subject)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if identity != nil {
+		user, err := s.userRepo.FindByID(ctx, s.db, identity.UserId)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if user == nil {
+			return nil, exception.NotFound("user not found")
+		}
+		identity.LastLoginAt = &now
+		if claims.Email != "" {
+			identity.Email = claims.Email
+		}
+		tx := s.db.Begin()
+		defer tx.Rollback()
+		if err := s.fedRepo.UpdateTx(ctx, tx, identity); err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, exception.Internal("commit transaction", err)
+		}
+		return user, nil
+	}
+
+	var user *entity.User
+	if claims.EmailVerified && claims.Email != "" {
+		if user, err = s.userRepo.FindByName(ctx, s.db, "email", claims.Email); err != nil {
+			return nil, exception.Internal("err", err)
+		}
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if user == nil {
+		var exc *exception.Exception
+		if user, exc = s.newFederatedUser(ctx, claims); exc != nil {
+			return nil, exc
+		}
+		if err := s.userRepo.CreateTx(ctx, tx, user); err != nil {
+			return nil, exception.Internal("err", err)
+		}
+	}
+	if err := s.fedRepo.CreateTx(ctx, tx, &entity.FederatedIdentity{
+		Id:          uuid.NewString(),
+		UserId:      user.Id,
+		Provider:    name,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		CreatedAt:   now,
+		LastLoginAt: &now,
+	}); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return user, nil
+}
+
+// newFederatedUser builds a user for a provider account. It has no password,
+// so it can only sign in through the provider until the user sets one with a
+// password reset. The email is only copied when the provider has verified it.
+func (s *FederationServiceImpl) newFederatedUser(
+	ctx context.Context, claims *oidc.IDTokenClaims,
+) (*entity.User, *exception.Exception) {
+	user := &entity.User{
+		Id:    uuid.NewString(),
+		Roles: []string{entity.RoleUser},
+	}
+	if claims.EmailVerified && claims.Email != "" {
+		verifiedAt := time.Now()
+		user.Email = claims.Email
+		user.EmailVerifiedAt = &verifiedAt
+	}
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	if base == "" {
+		base = "user"
+	}
+	candidate := base
+	for range usernameAttempts {
+		existing, err := s.userRepo.FindByName(ctx, s.db, "username", candidate)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if existing == nil {
+			user.Username = candidate
+			return user, nil
+		}
+		candidate = base + "_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+	}
+	return nil, exception.Conflict("can't find a free username for " + base)
+}
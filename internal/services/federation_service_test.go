@@ -0,0 +1,276 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/oidc"
+	"user-simple-crud/pkg/signature"
+)
+
+const federationStateTTL = 10 * time.Minute
+
+func TestFederationBegin(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("Begin Success", func(t *testing.T) {
+		var stored *entity.FederatedLoginState
+		var challenge string
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockFederationRepository := new(mocks.FederationRepository)
+		mockFederationRepository.On("CreateStateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(state *entity.FederatedLoginState) bool {
+			stored = state
+			return state.Provider == "corp" && state.Nonce != "" && state.CodeVerifier != ""
+		})).Return(nil)
+		mockProvider := new(mocksSignature.Provider)
+		mockProvider.On("AuthCodeURL", mockAppCtx, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { challenge = args.String(3) }).
+			Return("https://login.example.com/authorize?client_id=user-simple-crud", nil)
+		mockTokenService := new(mocks.TokenService)
+
+		mockService := service.NewFederationService(gormDB, mockUserRepository, mockFederationRepository, mockTokenService,
+			map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		location, state, errService := mockService.Begin(mockAppCtx, "corp")
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "https://login.example.com/authorize?client_id=user-simple-crud", location)
+		require.NotNil(t, stored)
+		assert.Equal(t, signature.HashToken(state), stored.StateHash)
+		sum := sha256.Sum256([]byte(stored.CodeVerifier))
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), challenge)
+		assert.WithinDuration(t, time.Now().Add(federationStateTTL), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Begin Unknown Provider", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockService := service.NewFederationService(gormDB, new(mocks.UserRepository), new(mocks.FederationRepository),
+			new(mocks.TokenService), map[string]oidc.Provider{}, federationStateTTL)
+
+		// Call the function under test
+		_, _, errService := mockService.Begin(mockAppCtx, "corp")
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
+
+func TestFederationComplete(t *testing.T) {
+	mockAppCtx := context.Background()
+	client := entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	const state = "3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"
+	loginState := &entity.FederatedLoginState{
+		Id:           "0f8fad5b-d9cb-469f-a165-70867728950e",
+		StateHash:    signature.HashToken(state),
+		Provider:     "corp",
+		Nonce:        "nonce",
+		CodeVerifier: "verifier",
+		ExpiresAt:    time.Now().Add(federationStateTTL),
+	}
+	claims := &oidc.IDTokenClaims{
+		RegisteredClaims:  jwt.RegisteredClaims{Subject: "248289761001"},
+		Email:             "jane@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "jane",
+	}
+	request := &entity.FederatedCallbackRequest{State: state, Code: "code"}
+	loginResponse := &service.UserLoginResponse{Username: "jane", Token: "access_token"}
+
+	// expectState mocks a valid state being looked up and used
+	expectState := func(repo *mocks.FederationRepository) {
+		repo.On("FindStateByHash", mockAppCtx, mock.Anything, loginState.StateHash).Return(loginState, nil)
+		repo.On("DeleteStateTx", mockAppCtx, mock.Anything, loginState.Id).Return(true, nil)
+	}
+
+	t.Run("Complete Existing Identity", func(t *testing.T) {
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "jane"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockFederationRepository := new(mocks.FederationRepository)
+		expectState(mockFederationRepository)
+		mockFederationRepository.On("FindIdentity", mockAppCtx, mock.Anything, "corp", claims.Subject).
+			Return(&entity.FederatedIdentity{Id: "identity", UserId: user.Id, Provider: "corp", Subject: claims.Subject}, nil)
+		mockFederationRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(identity *entity.FederatedIdentity) bool {
+			return identity.LastLoginAt != nil
+		})).Return(nil)
+		mockProvider := new(mocksSignature.Provider)
+		mockProvider.On("Exchange", mockAppCtx, "code", "verifier", "nonce").Return(claims, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, user, client).Return(loginResponse, nil)
+
+		mockService := service.NewFederationService(gormDB, mockUserRepository, mockFederationRepository, mockTokenService,
+			map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Complete(mockAppCtx, "corp", request, state, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, loginResponse, result)
+		mockUserRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Complete Links Verified Email", func(t *testing.T) {
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "jane_doe", Email: "jane@example.com"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", claims.Email).Return(user, nil)
+		mockFederationRepository := new(mocks.FederationRepository)
+		expectState(mockFederationRepository)
+		mockFederationRepository.On("FindIdentity", mockAppCtx, mock.Anything, "corp", claims.Subject).Return(nil, nil)
+		mockFederationRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(identity *entity.FederatedIdentity) bool {
+			return identity.UserId == user.Id && identity.Provider == "corp" && identity.Subject == claims.Subject
+		})).Return(nil)
+		mockProvider := new(mocksSignature.Provider)
+		mockProvider.On("Exchange", mockAppCtx, "code", "verifier", "nonce").Return(claims, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, user, client).Return(loginResponse, nil)
+
+		mockService := service.NewFederationService(gormDB, mockUserRepository, mockFederationRepository, mockTokenService,
+			map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Complete(mockAppCtx, "corp", request, state, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, loginResponse, result)
+		mockUserRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+		mockFederationRepository.AssertExpectations(t)
+	})
+
+	t.Run("Complete Creates User For Unverified Email", func(t *testing.T) {
+		unverified := *claims
+		unverified.EmailVerified = false
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "jane").
+			Return(&entity.User{Id: "another-user", Username: "jane"}, nil).Once()
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "username", mock.Anything).Return(nil, nil)
+		mockUserRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username != "jane" && user.Email == "" && user.Password == "" && user.EmailVerifiedAt == nil
+		})).Return(nil)
+		mockFederationRepository := new(mocks.FederationRepository)
+		expectState(mockFederationRepository)
+		mockFederationRepository.On("FindIdentity", mockAppCtx, mock.Anything, "corp", claims.Subject).Return(nil, nil)
+		mockFederationRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockProvider := new(mocksSignature.Provider)
+		mockProvider.On("Exchange", mockAppCtx, "code", "verifier", "nonce").Return(&unverified, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, mock.Anything, client).Return(loginResponse, nil)
+
+		mockService := service.NewFederationService(gormDB, mockUserRepository, mockFederationRepository, mockTokenService,
+			map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		_, errService := mockService.Complete(mockAppCtx, "corp", request, state, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		mockUserRepository.AssertNotCalled(t, "FindByName", mockAppCtx, mock.Anything, "email", mock.Anything)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("Complete Browser State Mismatch", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockFederationRepository := new(mocks.FederationRepository)
+		mockProvider := new(mocksSignature.Provider)
+
+		mockService := service.NewFederationService(gormDB, new(mocks.UserRepository), mockFederationRepository,
+			new(mocks.TokenService), map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		_, errService := mockService.Complete(mockAppCtx, "corp", request, "another-state", client)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockFederationRepository.AssertNotCalled(t, "FindStateByHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Complete State Already Used", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockFederationRepository := new(mocks.FederationRepository)
+		mockFederationRepository.On("FindStateByHash", mockAppCtx, mock.Anything, loginState.StateHash).Return(loginState, nil)
+		mockFederationRepository.On("DeleteStateTx", mockAppCtx, mock.Anything, loginState.Id).Return(false, nil)
+		mockProvider := new(mocksSignature.Provider)
+
+		mockService := service.NewFederationService(gormDB, new(mocks.UserRepository), mockFederationRepository,
+			new(mocks.TokenService), map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		_, errService := mockService.Complete(mockAppCtx, "corp", request, state, client)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockProvider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Complete ID Token Rejected", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockFederationRepository := new(mocks.FederationRepository)
+		expectState(mockFederationRepository)
+		mockProvider := new(mocksSignature.Provider)
+		mockProvider.On("Exchange", mockAppCtx, "code", "verifier", "nonce").Return(nil, errors.New("id token nonce does not match"))
+		mockTokenService := new(mocks.TokenService)
+
+		mockService := service.NewFederationService(gormDB, new(mocks.UserRepository), mockFederationRepository,
+			mockTokenService, map[string]oidc.Provider{"corp": mockProvider}, federationStateTTL)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		_, errService := mockService.Complete(mockAppCtx, "corp", request, state, client)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	"user-simple-crud/pkg/exception"
+)
+
+// GroupService manages groups of users. Members are listed through
+// UserService.List with a group filter. Every change is written to the audit
+// log, attributed to actor.
+type GroupService interface {
+	Create(ctx context.Context, model *entity.GroupRequest, actor entity.AuditActor) (*entity.Group, *exception.Exception)
+	List(ctx context.Context, req model.ListReq) (*ListGroupResp, *exception.Exception)
+	FindOne(ctx context.Context, id string) (*entity.Group, *exception.Exception)
+	// Update replaces the group's name and description.
+	Update(
+		ctx context.Context, id string, model *entity.GroupRequest, actor entity.AuditActor,
+	) (*entity.Group, *exception.Exception)
+	// Delete removes the group along with its memberships; the users stay.
+	Delete(ctx context.Context, id string, actor entity.AuditActor) *exception.Exception
+
+	// AddMember and RemoveMember succeed without a change when the user already is, or isn't, a member.
+	AddMember(ctx context.Context, id string, model *entity.GroupMemberRequest, actor entity.AuditActor) *exception.Exception
+	RemoveMember(ctx context.Context, id string, userID string, actor entity.AuditActor) *exception.Exception
+}
+
+type ListGroupResp struct {
+	Pagination *model.Pagination `json:"pagination"`
+	Data       []*entity.Group   `json:"data"`
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/model"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+// groupFilterColumns are the fields groups can be filtered and sorted on and
+// the columns they map to.
+var groupFilterColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"created_at": "created_at",
+}
+
+type GroupServiceImpl struct {
+	db           *gorm.DB
+	groupRepo    repository.GroupRepository
+	userRepo     repository.UserRepository
+	auditService AuditService
+	validate     *xvalidator.Validator
+}
+
+func NewGroupService(
+	db *gorm.DB, groupRepo repository.GroupRepository, userRepo repository.UserRepository,
+	auditService AuditService, validate *xvalidator.Validator,
+) GroupService {
+	return &GroupServiceImpl{
+		db:           db,
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+		validate:     validate,
+	}
+}
+
+func (s *GroupServiceImpl) Create(
+	ctx context.Context, model *entity.GroupRequest, actor entity.AuditActor,
+) (*entity.Group, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if exc := s.checkName(ctx, tx, "", model.Name); exc != nil {
+		return nil, exc
+	}
+	group := &entity.Group{
+		Id:          uuid.NewString(),
+		Name:        model.Name,
+		Description: model.Description,
+	}
+	if err := s.groupRepo.CreateTx(ctx, tx, group); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditGroupCreate, group.Id, nil, group); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return group, nil
+}
+
+func (s *GroupServiceImpl) List(ctx context.Context, req model.ListReq) (*ListGroupResp, *exception.Exception) {
+	filter, err := req.Filter.Resolve(groupFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	order, err := req.Order.Resolve(groupFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	result, err := s.groupRepo.FindByPagination(ctx, s.db, req.Page, order, filter)
+	if err != nil {
+		return nil, exception.Internal("failed to get Group", err)
+	}
+	return &ListGroupResp{
+		Pagination: &model.Pagination{
+			Page:             result.Page,
+			PageSize:         result.PageSize,
+			TotalPage:        result.TotalPage,
+			TotalDataPerPage: result.TotalDataPerPage,
+			TotalData:        result.TotalData,
+		},
+		Data: result.Data,
+	}, nil
+}
+
+func (s *GroupServiceImpl) FindOne(ctx context.Context, id string) (*entity.Group, *exception.Exception) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid group id, must be uuid")
+	}
+	group, err := s.groupRepo.FindByID(ctx, s.db, id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if group == nil {
+		return nil, exception.NotFound("group not found")
+	}
+	return group, nil
+}
+
+func (s *GroupServiceImpl) Update(
+	ctx context.Context, id string, model *entity.GroupRequest, actor entity.AuditActor,
+) (*entity.Group, *exception.Exception) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid group id, must be uuid")
+	}
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	group, err := s.groupRepo.FindByID(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if group == nil {
+		return nil, exception.NotFound("group not found")
+	}
+	if exc := s.checkName(ctx, tx, id, model.Name); exc != nil {
+		return nil, exc
+	}
+	before := *group
+	group.Name = model.Name
+	group.Description = model.Description
+	if err := s.groupRepo.UpdateTx(ctx, tx, group); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditGroupUpdate, id, &before, group); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return group, nil
+}
+
+func (s *GroupServiceImpl) Delete(ctx context.Context, id string, actor entity.AuditActor) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid group id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	group, err := s.groupRepo.FindByID(ctx, tx, id)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if group == nil {
+		return exception.NotFound("group not found")
+	}
+	if err := s.groupRepo.DeleteByIDTx(ctx, tx, id); err != nil {
+		return exception.Internal("err", err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditGroupDelete, id, group, nil); exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *GroupServiceImpl) AddMember(
+	ctx context.Context, id string, model *entity.GroupMemberRequest, actor entity.AuditActor,
+) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	return s.changeMembership(ctx, id, model.UserId, true, actor)
+}
+
+func (s *GroupServiceImpl) RemoveMember(
+	ctx context.Context, id string, userID string, actor entity.AuditActor,
+) *exception.Exception {
+	if _, err := uuid.Parse(userID); err != nil {
+		return exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	return s.changeMembership(ctx, id, userID, false, actor)
+}
+
+// changeMembership adds the user to or removes it from the group by rewriting
+// the user's groups. The user row stays locked until commit, so concurrent
+// changes to its groups don't drop each other.
+func (s *GroupServiceImpl) changeMembership(
+	ctx context.Context, id string, userID string, member bool, actor entity.AuditActor,
+) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid group id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	group, err := s.groupRepo.FindByID(ctx, tx, id)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if group == nil {
+		return exception.NotFound("group not found")
+	}
+	user, err := s.userRepo.FindByID(
+		ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), userID, entity.UserGroupsAssociation,
+	)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	groups := make([]*entity.Group, 0, len(user.Groups)+1)
+	isMember := false
+	for _, g := range user.Groups {
+		if g.Id == id {
+			isMember = true
+			continue
+		}
+		groups = append(groups, g)
+	}
+	if isMember == member {
+		return nil
+	}
+	membership := &entity.GroupMemberRequest{UserId: userID}
+	var before, after *entity.GroupMemberRequest
+	action := entity.AuditGroupMemberRemove
+	if member {
+		groups = append(groups, group)
+		after = membership
+		action = entity.AuditGroupMemberAdd
+	} else {
+		before = membership
+	}
+	user.Groups = groups
+	if err := s.userRepo.UpdateAssociationMany2ManyTx(tx.WithContext(ctx), user); err != nil {
+		return exception.Internal("err", err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, action, id, before, after); exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+// checkName fails when another group than id already has name, ignoring case.
+func (s *GroupServiceImpl) checkName(ctx context.Context, tx *gorm.DB, id, name string) *exception.Exception {
+	duplicate, err := s.groupRepo.FindByName(ctx, tx, "name", name)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if duplicate != nil && duplicate.Id != id {
+		return exception.Conflict("group name already exists")
+	}
+	return nil
+}
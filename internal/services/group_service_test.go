@@ -0,0 +1,403 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	"user-simple-crud/internal/model"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+func TestCreateGroup(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("CreateGroup Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.GroupRequest{Name: "engineering", Description: "Everyone working on the product"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByName", mockAppCtx, mock.Anything, "name", request.Name).Return(nil, nil)
+		mockGroupRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(group *entity.Group) bool {
+			return group.Id != "" && group.Name == request.Name && group.Description == request.Description
+		})).Return(nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditGroupCreate, mock.Anything, nil, mock.Anything).Return(nil)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Create(mockAppCtx, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, request.Name, result.Name)
+		mockGroupRepository.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("CreateGroup Name Taken", func(t *testing.T) {
+		// Set up input
+		request := &entity.GroupRequest{Name: "Engineering"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByName", mockAppCtx, mock.Anything, "name", request.Name).
+			Return(&entity.Group{Id: "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", Name: "engineering"}, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Create(mockAppCtx, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.AlreadyExistsCode, errService.Code)
+		mockGroupRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateGroup Validation Error", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		result, errService := mockService.Create(mockAppCtx, &entity.GroupRequest{}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
+
+func TestListGroup(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("ListGroup Success", func(t *testing.T) {
+		req := model.ListReq{Page: model.PaginationParam{Page: 1, PageSize: 10}, Order: model.OrderParam{OrderBy: "name", Order: "asc"}}
+		response := &model.PaginationData[entity.Group]{
+			Page:     1,
+			PageSize: 10,
+			Data:     []*entity.Group{{Name: "engineering"}},
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).Return(response, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Len(t, result.Data, 1)
+	})
+
+	t.Run("ListGroup Unknown Filter", func(t *testing.T) {
+		req := model.ListReq{Filter: model.FilterParams{{Field: "description", Value: "product", Operator: "like"}}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockGroupRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListGroup Unknown Sort", func(t *testing.T) {
+		req := model.ListReq{Order: model.OrderParam{OrderBy: "description", Order: "asc"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockGroupRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateGroup(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+
+	t.Run("UpdateGroup Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.GroupRequest{Name: "Engineering", Description: "Product and platform"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.Group{Id: id, Name: "engineering"}, nil)
+		// Renaming a group to a different case of its own name isn't a conflict
+		mockGroupRepository.On("FindByName", mockAppCtx, mock.Anything, "name", request.Name).Return(&entity.Group{Id: id, Name: "engineering"}, nil)
+		mockGroupRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(group *entity.Group) bool {
+			return group.Name == request.Name && group.Description == request.Description
+		})).Return(nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditGroupUpdate, id, mock.MatchedBy(func(before *entity.Group) bool {
+			return before.Name == "engineering"
+		}), mock.Anything).Return(nil)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Update(mockAppCtx, id, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "Engineering", result.Name)
+		mockGroupRepository.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateGroup Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Update(mockAppCtx, id, &entity.GroupRequest{Name: "ops"}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
+
+func TestDeleteGroup(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+
+	t.Run("DeleteGroup Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.Group{Id: id, Name: "engineering"}, nil)
+		mockGroupRepository.On("DeleteByIDTx", mockAppCtx, mock.Anything, id).Return(nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditGroupDelete, id, mock.Anything, nil).Return(nil)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Delete(mockAppCtx, id, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockGroupRepository.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteGroup Invalid UUID", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		errService := mockService.Delete(mockAppCtx, "invalid-uuid", auditActor)
+
+		// Assert the result
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
+
+func TestGroupMembers(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10"
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	group := &entity.Group{Id: id, Name: "engineering"}
+	other := &entity.Group{Id: "9a7d2c4e-1b3f-4e5a-8c6d-0f2e4a6b8c1d", Name: "ops"}
+
+	t.Run("AddMember Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(group, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID, entity.UserGroupsAssociation).
+			Return(&entity.User{Id: userID, Groups: []*entity.Group{other}}, nil)
+		mockUserRepository.On("UpdateAssociationMany2ManyTx", mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return len(user.Groups) == 2 && user.Groups[0] == other && user.Groups[1] == group
+		})).Return(nil)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditGroupMemberAdd, id,
+			(*entity.GroupMemberRequest)(nil), &entity.GroupMemberRequest{UserId: userID}).Return(nil)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.AddMember(mockAppCtx, id, &entity.GroupMemberRequest{UserId: userID}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("AddMember Already Member", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(group, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID, entity.UserGroupsAssociation).
+			Return(&entity.User{Id: userID, Groups: []*entity.Group{group}}, nil)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.AddMember(mockAppCtx, id, &entity.GroupMemberRequest{UserId: userID}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertNotCalled(t, "UpdateAssociationMany2ManyTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AddMember User Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(group, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID, entity.UserGroupsAssociation).Return(nil, nil)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.AddMember(mockAppCtx, id, &entity.GroupMemberRequest{UserId: userID}, auditActor)
+
+		// Assert the result
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+		assert.Equal(t, "user not found", errService.Message)
+	})
+
+	t.Run("AddMember Invalid User ID", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		errService := mockService.AddMember(mockAppCtx, id, &entity.GroupMemberRequest{UserId: "john_doe"}, auditActor)
+
+		// Assert the result
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("RemoveMember Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(group, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID, entity.UserGroupsAssociation).
+			Return(&entity.User{Id: userID, Groups: []*entity.Group{group, other}}, nil)
+		mockUserRepository.On("UpdateAssociationMany2ManyTx", mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return len(user.Groups) == 1 && user.Groups[0] == other
+		})).Return(nil)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditGroupMemberRemove, id,
+			&entity.GroupMemberRequest{UserId: userID}, (*entity.GroupMemberRequest)(nil)).Return(nil)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.RemoveMember(mockAppCtx, id, userID, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockUserRepository.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("RemoveMember Group Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockGroupRepository := new(mocks.GroupRepository)
+		mockGroupRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAuditService := new(mocks.AuditService)
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewGroupService(gormDB, mockGroupRepository, mockUserRepository, mockAuditService, validate)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.RemoveMember(mockAppCtx, id, userID, auditActor)
+
+		// Assert the result
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"time"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+// ImpersonationService lets support staff use the API as a given user.
+type ImpersonationService interface {
+	// Impersonate issues actor a short-lived access token for the user, carrying
+	// the user's permissions and an act claim naming actor. No refresh token or
+	// session is created, so it can't be extended.
+	Impersonate(
+		ctx context.Context, actor *signature.JwtAuthenticationRes, userID string,
+	) (*ImpersonationResponse, *exception.Exception)
+}
+
+type ImpersonationResponse struct {
+	UserId    string    `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Username  string    `json:"username" example:"john_doe"`
+	ActorId   string    `json:"actor_id" example:"8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"`
+	Token     string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-12-31T23:59:59Z"`
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+type ImpersonationServiceImpl struct {
+	db         *gorm.DB
+	userRepo   repository.UserRepository
+	signaturer signature.Signaturer
+	ttl        time.Duration
+}
+
+func NewImpersonationService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	signaturer signature.Signaturer,
+	ttl time.Duration,
+) ImpersonationService {
+	return &ImpersonationServiceImpl{
+		db:         db,
+		userRepo:   userRepo,
+		signaturer: signaturer,
+		ttl:        ttl,
+	}
+}
+
+func (s *ImpersonationServiceImpl) Impersonate(
+	ctx context.Context, actor *signature.JwtAuthenticationRes, userID string,
+) (*ImpersonationResponse, *exception.Exception) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	if actor.Subject == "" || !actor.IsFirstParty() {
+		return nil, exception.PermissionDenied("impersonation needs a signed-in admin")
+	}
+	if actor.IsImpersonated() {
+		return nil, exception.PermissionDenied("can't impersonate while impersonating")
+	}
+	if actor.Subject == userID {
+		return nil, exception.InvalidArgument("can't impersonate yourself")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	// An admin's token would let the actor act with another admin's authority
+	if user.HasRole(entity.RoleAdmin) {
+		return nil, exception.PermissionDenied("admins can't be impersonated")
+	}
+	roles := user.RoleNames()
+	expiresAt := time.Now().Add(s.ttl)
+	token, err := s.signaturer.GenerateJWT(signature.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.Id,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Username:    user.Username,
+		Roles:       roles,
+		Permissions: entity.PermissionsFor(roles),
+		Actor:       &signature.ActorClaim{Subject: actor.Subject, Username: actor.Username},
+	})
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	slog.Warn("impersonation started",
+		"actor_id", actor.Subject, "actor_username", actor.Username,
+		"user_id", user.Id, "username", user.Username, "expires_at", expiresAt)
+	return &ImpersonationResponse{
+		UserId:    user.Id,
+		Username:  user.Username,
+		ActorId:   actor.Subject,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestImpersonate(t *testing.T) {
+	mockAppCtx := context.Background()
+	actor := &signature.JwtAuthenticationRes{
+		Username: "admin",
+		Subject:  "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		Roles:    []string{entity.RoleAdmin},
+	}
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+		Email:    "john_doe@example.com",
+	}
+
+	t.Run("Impersonate Success", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			return claims.Subject == user.Id &&
+				claims.Actor != nil && claims.Actor.Subject == actor.Subject &&
+				claims.SessionID == "" &&
+				assert.ObjectsAreEqual(entity.PermissionsFor([]string{entity.RoleUser}), claims.Permissions) &&
+				claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(time.Now().Add(16*time.Minute))
+		})).Return("impersonation_token", nil)
+
+		mockService := service.NewImpersonationService(gormDB, mockUserRepository, mockSignaturer, 15*time.Minute)
+
+		// Call the function under test
+		result, errService := mockService.Impersonate(mockAppCtx, actor, user.Id)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "impersonation_token", result.Token)
+		assert.Equal(t, actor.Subject, result.ActorId)
+		assert.Equal(t, user.Id, result.UserId)
+		mockSignaturer.AssertExpectations(t)
+	})
+
+	t.Run("Impersonate Admin", func(t *testing.T) {
+		admin := &entity.User{Id: user.Id, Username: "other_admin", Roles: []string{entity.RoleAdmin}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(admin, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		mockService := service.NewImpersonationService(gormDB, mockUserRepository, mockSignaturer, 15*time.Minute)
+
+		// Call the function under test
+		result, errService := mockService.Impersonate(mockAppCtx, actor, user.Id)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockSignaturer.AssertNotCalled(t, "GenerateJWT", mock.Anything)
+	})
+
+	t.Run("Impersonate While Impersonating", func(t *testing.T) {
+		nested := *actor
+		nested.Actor = &signature.ActorClaim{Subject: "5d41402a-bc4b-4a76-b971-9d911017c592"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		mockService := service.NewImpersonationService(gormDB, mockUserRepository, mockSignaturer, 15*time.Minute)
+
+		// Call the function under test
+		result, errService := mockService.Impersonate(mockAppCtx, &nested, user.Id)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Impersonate User Not Found", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(nil, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		mockService := service.NewImpersonationService(gormDB, mockUserRepository, mockSignaturer, 15*time.Minute)
+
+		// Call the function under test
+		result, errService := mockService.Impersonate(mockAppCtx, actor, user.Id)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
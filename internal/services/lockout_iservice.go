@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/pkg/exception"
+)
+
+type LockoutService interface {
+	// Check rejects a login while the client IP is throttled or the account is
+	// locked or still backing off. userID is empty when the login names no account.
+	Check(ctx context.Context, userID, clientIP string) *exception.Exception
+	// RecordFailure counts a failed login against the account and the client IP
+	// and locks the account once it reaches the configured threshold
+	RecordFailure(ctx context.Context, userID, clientIP string) *exception.Exception
+	// RecordSuccess clears the account's failures. The client IP keeps its count
+	// so one valid account cannot be used to reset a guessing run.
+	RecordSuccess(ctx context.Context, userID string) *exception.Exception
+	// Unlock lifts a lock and clears the failures of an account
+	Unlock(ctx context.Context, userID string) *exception.Exception
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+)
+
+// LockoutConfig bounds failed logins. A zero MaxAttempts, MaxAttemptsPerIP or
+// BackoffBase turns that check off.
+type LockoutConfig struct {
+	// MaxAttempts is how many failures within Window lock an account
+	MaxAttempts int
+	// MaxAttemptsPerIP is how many failures within Window throttle a client IP
+	MaxAttemptsPerIP int
+	Window           time.Duration
+	LockoutDuration  time.Duration
+	// BackoffBase is the wait after the first failure, doubled for each further one
+	BackoffBase time.Duration
+}
+
+type LockoutServiceImpl struct {
+	db          *gorm.DB
+	userRepo    repository.UserRepository
+	attemptRepo repository.LoginAttemptRepository
+	conf        *LockoutConfig
+}
+
+func NewLockoutService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	attemptRepo repository.LoginAttemptRepository,
+	conf *LockoutConfig,
+) LockoutService {
+	return &LockoutServiceImpl{
+		db:          db,
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		conf:        conf,
+	}
+}
+
+func userSubject(userID string) string {
+	return "user:" + userID
+}
+
+func ipSubject(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+func (s *LockoutServiceImpl) Check(ctx context.Context, userID, clientIP string) *exception.Exception {
+	now := time.Now()
+	if s.conf.MaxAttemptsPerIP > 0 && clientIP != "" {
+		attempt, err := s.attemptRepo.Find(ctx, ipSubject(clientIP))
+		if err != nil {
+			return exception.Internal("err", err)
+		}
+		if attempt != nil && attempt.Failures >= s.conf.MaxAttemptsPerIP {
+			if wait := attempt.LastFailedAt.Add(s.conf.Window).Sub(now); wait > 0 {
+				return exception.TooManyRequests("too many failed logins from this address, try again later", wait)
+			}
+		}
+	}
+	if userID == "" {
+		return nil
+	}
+	attempt, err := s.attemptRepo.Find(ctx, userSubject(userID))
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if attempt == nil {
+		return nil
+	}
+	if attempt.IsLocked(now) {
+		return exception.Locked("account is temporarily locked, try again later", attempt.LockedUntil.Sub(now))
+	}
+	if attempt.LastFailedAt.Before(now.Add(-s.conf.Window)) {
+		return nil
+	}
+	if wait := attempt.LastFailedAt.Add(s.backoff(attempt.Failures)).Sub(now); wait > 0 {
+		return exception.TooManyRequests("too many failed logins, try again later", wait)
+	}
+	return nil
+}
+
+func (s *LockoutServiceImpl) RecordFailure(ctx context.Context, userID, clientIP string) *exception.Exception {
+	now := time.Now()
+	if clientIP != "" {
+		if _, err := s.attemptRepo.RecordFailure(ctx, ipSubject(clientIP), now, s.conf.Window); err != nil {
+			return exception.Internal("err", err)
+		}
+	}
+	if userID == "" {
+		return nil
+	}
+	attempt, err := s.attemptRepo.RecordFailure(ctx, userSubject(userID), now, s.conf.Window)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if s.conf.MaxAttempts > 0 && attempt.Failures >= s.conf.MaxAttempts {
+		if err := s.attemptRepo.Lock(ctx, userSubject(userID), now.Add(s.conf.LockoutDuration)); err != nil {
+			return exception.Internal("err", err)
+		}
+	}
+	return nil
+}
+
+func (s *LockoutServiceImpl) RecordSuccess(ctx context.Context, userID string) *exception.Exception {
+	if err := s.attemptRepo.Delete(ctx, userSubject(userID)); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+func (s *LockoutServiceImpl) Unlock(ctx context.Context, userID string) *exception.Exception {
+	if _, err := uuid.Parse(userID); err != nil {
+		return exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	return s.RecordSuccess(ctx, userID)
+}
+
+// backoff is how long an account waits after its latest failure, doubling
+// from BackoffBase with each failure and capped at LockoutDuration.
+func (s *LockoutServiceImpl) backoff(failures int) time.Duration {
+	if s.conf.BackoffBase <= 0 || failures < 1 {
+		return 0
+	}
+	delay := s.conf.BackoffBase
+	for i := 1; i < failures && delay < s.conf.LockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > s.conf.LockoutDuration {
+		return s.conf.LockoutDuration
+	}
+	return delay
+}
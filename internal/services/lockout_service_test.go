@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+)
+
+var lockoutConfig = &service.LockoutConfig{
+	MaxAttempts:      5,
+	MaxAttemptsPerIP: 20,
+	Window:           15 * time.Minute,
+	LockoutDuration:  15 * time.Minute,
+	BackoffBase:      time.Second,
+}
+
+func TestCheckLockout(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	clientIP := "203.0.113.7"
+
+	t.Run("CheckLockout Allowed", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("Find", mockAppCtx, "ip:"+clientIP).Return(nil, nil)
+		mockAttemptRepository.On("Find", mockAppCtx, "user:"+userID).Return(&entity.LoginAttempt{
+			Subject: "user:" + userID, Failures: 4, LastFailedAt: time.Now().Add(-time.Hour),
+		}, nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Check(mockAppCtx, userID, clientIP)
+
+		// Assert the result
+		assert.Nil(t, errService)
+	})
+
+	t.Run("CheckLockout Account Locked", func(t *testing.T) {
+		lockedUntil := time.Now().Add(10 * time.Minute)
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("Find", mockAppCtx, "ip:"+clientIP).Return(nil, nil)
+		mockAttemptRepository.On("Find", mockAppCtx, "user:"+userID).Return(&entity.LoginAttempt{
+			Subject: "user:" + userID, Failures: 5, LastFailedAt: time.Now(), LockedUntil: &lockedUntil,
+		}, nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Check(mockAppCtx, userID, clientIP)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.LockedCode, errService.Code)
+		assert.Equal(t, 423, errService.GetHttpCode())
+		assert.InDelta(t, (10 * time.Minute).Seconds(), errService.RetryAfter.Seconds(), 1)
+	})
+
+	t.Run("CheckLockout Backing Off", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("Find", mockAppCtx, "ip:"+clientIP).Return(nil, nil)
+		mockAttemptRepository.On("Find", mockAppCtx, "user:"+userID).Return(&entity.LoginAttempt{
+			Subject: "user:" + userID, Failures: 3, LastFailedAt: time.Now(),
+		}, nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Check(mockAppCtx, userID, clientIP)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.TooManyRequestsCode, errService.Code)
+		assert.InDelta(t, (4 * time.Second).Seconds(), errService.RetryAfter.Seconds(), 1)
+	})
+
+	t.Run("CheckLockout Client IP Throttled", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("Find", mockAppCtx, "ip:"+clientIP).Return(&entity.LoginAttempt{
+			Subject: "ip:" + clientIP, Failures: 20, LastFailedAt: time.Now(),
+		}, nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Check(mockAppCtx, "", clientIP)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.TooManyRequestsCode, errService.Code)
+		assert.Equal(t, 429, errService.GetHttpCode())
+		assert.Greater(t, errService.RetryAfter, time.Duration(0))
+	})
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	clientIP := "203.0.113.7"
+
+	t.Run("RecordLoginFailure Below Threshold", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("RecordFailure", mockAppCtx, "ip:"+clientIP, mock.Anything, lockoutConfig.Window).
+			Return(&entity.LoginAttempt{Subject: "ip:" + clientIP, Failures: 1}, nil)
+		mockAttemptRepository.On("RecordFailure", mockAppCtx, "user:"+userID, mock.Anything, lockoutConfig.Window).
+			Return(&entity.LoginAttempt{Subject: "user:" + userID, Failures: 4}, nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.RecordFailure(mockAppCtx, userID, clientIP)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockAttemptRepository.AssertExpectations(t)
+		mockAttemptRepository.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RecordLoginFailure Locks Account", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("RecordFailure", mockAppCtx, "ip:"+clientIP, mock.Anything, lockoutConfig.Window).
+			Return(&entity.LoginAttempt{Subject: "ip:" + clientIP, Failures: 5}, nil)
+		mockAttemptRepository.On("RecordFailure", mockAppCtx, "user:"+userID, mock.Anything, lockoutConfig.Window).
+			Return(&entity.LoginAttempt{Subject: "user:" + userID, Failures: 5}, nil)
+		mockAttemptRepository.On("Lock", mockAppCtx, "user:"+userID, mock.MatchedBy(func(until time.Time) bool {
+			return until.After(time.Now().Add(lockoutConfig.LockoutDuration - time.Minute))
+		})).Return(nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.RecordFailure(mockAppCtx, userID, clientIP)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockAttemptRepository.AssertExpectations(t)
+	})
+}
+
+func TestUnlockAccount(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+	}
+
+	t.Run("UnlockAccount Success", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockAttemptRepository.On("Delete", mockAppCtx, "user:"+user.Id).Return(nil)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Unlock(mockAppCtx, user.Id)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockAttemptRepository.AssertExpectations(t)
+	})
+
+	t.Run("UnlockAccount Invalid UUID", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Unlock(mockAppCtx, "not-a-uuid")
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("UnlockAccount User Not Found", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(nil, nil)
+		mockAttemptRepository := new(mocks.LoginAttemptRepository)
+		mockService := service.NewLockoutService(gormDB, mockUserRepository, mockAttemptRepository, lockoutConfig)
+
+		// Call the function under test
+		errService := mockService.Unlock(mockAppCtx, user.Id)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+		mockAttemptRepository.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+// MagicLinkService signs users in through a link emailed to them instead of
+// a password.
+type MagicLinkService interface {
+	// Send emails a single-use sign-in link. The response is the same whether or
+	// not the address belongs to an account, except when the address has asked
+	// for too many links recently.
+	Send(ctx context.Context, model *entity.MagicLinkRequest) *exception.Exception
+	// Consume redeems the link's token like Login, answering with tokens or, when
+	// the user has MFA, an mfa_token
+	Consume(
+		ctx context.Context, model *entity.ConsumeMagicLinkRequest, client entity.ClientInfo,
+	) (*UserLoginResponse, *exception.Exception)
+}
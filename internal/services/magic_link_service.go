@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/gateway/notification"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+// MagicLinkConfig sets how long a sign-in link lives, which page it opens and
+// how many links one address may request per Window. A zero MaxPerEmail
+// turns the limit off.
+type MagicLinkConfig struct {
+	TTL         time.Duration
+	URL         string
+	MaxPerEmail int
+	Window      time.Duration
+}
+
+type MagicLinkServiceImpl struct {
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	userTokenRepo  repository.UserTokenRepository
+	rateLimitRepo  repository.RateLimitRepository
+	tokenService   TokenService
+	mfaService     MFAService
+	lockoutService LockoutService
+	notifier       notification.Notifier
+	validate       *xvalidator.Validator
+	conf           *MagicLinkConfig
+}
+
+func NewMagicLinkService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	userTokenRepo repository.UserTokenRepository,
+	rateLimitRepo repository.RateLimitRepository,
+	tokenService TokenService,
+	mfaService MFAService,
+	lockoutService LockoutService,
+	notifier notification.Notifier,
+	validate *xvalidator.Validator,
+	conf *MagicLinkConfig,
+) MagicLinkService {
+	return &MagicLinkServiceImpl{
+		db:             db,
+		userRepo:       userRepo,
+		userTokenRepo:  userTokenRepo,
+		rateLimitRepo:  rateLimitRepo,
+		tokenService:   tokenService,
+		mfaService:     mfaService,
+		lockoutService: lockoutService,
+		notifier:       notifier,
+		validate:       validate,
+		conf:           conf,
+	}
+}
+
+func (s *MagicLinkServiceImpl) Send(ctx context.Context, model *entity.MagicLinkRequest) *exception.Exception {
+	if errs := s.validate.Struct(model); errs != nil {
+		return exception.InvalidArgument(errs)
+	}
+	// The limit is counted per address whether or not it has an account, so
+	// hitting it doesn't reveal which addresses do.
+	if exc := throttleAddress(
+		ctx, s.rateLimitRepo, "magic", model.Email, s.conf.MaxPerEmail, s.conf.Window,
+		"too many sign-in links requested for this address, try again later",
+	); exc != nil {
+		return exc
+	}
+	user, err := s.userRepo.FindByName(ctx, s.db, "email", model.Email)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := issueUserToken(ctx, s.userTokenRepo, tx, user.Id, entity.TokenPurposeMagicLink, s.conf.TTL)
+	if exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	// Reporting a delivery failure would reveal that the address has an account.
+	if err := s.notifier.Send(ctx, &notification.Message{
+		To:      user.Email,
+		Subject: "Your sign-in link",
+		Body: "Open the link below to sign in:\n\n" +
+			linkWithToken(s.conf.URL, token) + "\n\n" +
+			"The link works once and expires in " + s.conf.TTL.String() + ". If you didn't ask for it, ignore this email.",
+	}); err != nil {
+		slog.Error("failed to send magic link email", "user_id", user.Id, "error", err.Error())
+	}
+	return nil
+}
+
+func (s *MagicLinkServiceImpl) Consume(
+	ctx context.Context, model *entity.ConsumeMagicLinkRequest, client entity.ClientInfo,
+) (*UserLoginResponse, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := consumeUserToken(ctx, s.userTokenRepo, tx, entity.TokenPurposeMagicLink, model.Token)
+	if exc != nil {
+		return nil, exc
+	}
+	user, err := s.userRepo.FindByID(ctx, tx, token.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	// A locked account stays locked whichever way it signs in. The token is
+	// left unused so the link still works once the lock ends.
+	if exc := s.lockoutService.Check(ctx, user.Id, client.IpAddress); exc != nil {
+		return nil, exc
+	}
+	// The token was delivered to the user's inbox, which proves they own the address.
+	if user.EmailVerifiedAt == nil {
+		user.VerifyEmail(time.Now())
+		if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+			return nil, updateException(err)
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	// The link stands in for the password only, a second factor is still asked
+	// for. Password expiry doesn't apply as no password was used.
+	mfaEnabled, exc := s.mfaService.Enabled(ctx, user.Id)
+	if exc != nil {
+		return nil, exc
+	}
+	if mfaEnabled {
+		return s.mfaService.Challenge(ctx, user)
+	}
+	return s.tokenService.Issue(ctx, user, client)
+}
@@ -0,0 +1,281 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/gateway/notification"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+var magicLinkConfig = &service.MagicLinkConfig{
+	TTL:         15 * time.Minute,
+	URL:         "https://example.com/magic-link",
+	MaxPerEmail: 3,
+	Window:      time.Hour,
+}
+
+func TestSendMagicLink(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:    "123e4567-e89b-12d3-a456-426614174000",
+		Email: "john_doe@example.com",
+	}
+	request := &entity.MagicLinkRequest{Email: user.Email}
+
+	t.Run("SendMagicLink Success", func(t *testing.T) {
+		// Mocks
+		var stored *entity.UserToken
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", user.Email).Return(user, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, user.Id, entity.TokenPurposeMagicLink, mock.Anything).Return(nil)
+		mockUserTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.UserToken) bool {
+			stored = token
+			return token.UserId == user.Id && token.Purpose == entity.TokenPurposeMagicLink
+		})).Return(nil)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "magic:") && !strings.Contains(key, user.Email)
+		}), mock.Anything, magicLinkConfig.Window).Return(&entity.RateLimit{Count: 1}, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockNotifier := new(mocks.Notifier)
+		mockNotifier.On("Send", mockAppCtx, mock.MatchedBy(func(message *notification.Message) bool {
+			_, token, ok := strings.Cut(message.Body, magicLinkConfig.URL+"?token=")
+			token, _, _ = strings.Cut(token, "\n")
+			return ok && message.To == user.Email && signature.HashToken(token) == stored.TokenHash
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Send(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNotifier.AssertExpectations(t)
+		mockRateLimitRepository.AssertExpectations(t)
+		assert.WithinDuration(t, time.Now().Add(magicLinkConfig.TTL), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("SendMagicLink Unknown Email", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "email", user.Email).Return(nil, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, magicLinkConfig.Window).Return(&entity.RateLimit{Count: 1}, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		errService := mockService.Send(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		mockRateLimitRepository.AssertExpectations(t)
+	})
+
+	t.Run("SendMagicLink Rate Limited", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockRateLimitRepository.On("Hit", mockAppCtx, mock.Anything, mock.Anything, magicLinkConfig.Window).Return(&entity.RateLimit{
+			Count: 4, ExpiresAt: time.Now().Add(50 * time.Minute),
+		}, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		errService := mockService.Send(mockAppCtx, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.TooManyRequestsCode, errService.Code)
+		assert.InDelta(t, (50 * time.Minute).Seconds(), errService.RetryAfter.Seconds(), 5)
+		mockUserRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockNotifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConsumeMagicLink(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+	request := &entity.ConsumeMagicLinkRequest{Token: "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"}
+	client := entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    userID,
+			Purpose:   entity.TokenPurposeMagicLink,
+			TokenHash: signature.HashToken(request.Token),
+			ExpiresAt: time.Now().Add(time.Minute),
+		}
+	}
+
+	t.Run("ConsumeMagicLink Success", func(t *testing.T) {
+		token := newToken()
+		loginResponse := &service.UserLoginResponse{Username: "john_doe", Token: "jwt_token", RefreshToken: "refresh_token"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID, Username: "john_doe"}, nil)
+		mockUserRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.EmailVerifiedAt != nil
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, mock.Anything, client).Return(loginResponse, nil)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, userID, client.IpAddress).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, userID).Return(false, nil)
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Consume(mockAppCtx, request, client)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, loginResponse, result)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("ConsumeMagicLink MFA Enabled", func(t *testing.T) {
+		token := newToken()
+		verifiedAt := time.Now().Add(-time.Hour)
+		challenge := &service.UserLoginResponse{Username: "john_doe", MFARequired: true, MFAToken: "mfa_token"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID, EmailVerifiedAt: &verifiedAt}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, userID, client.IpAddress).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, userID).Return(true, nil)
+		mockMFAService.On("Challenge", mockAppCtx, mock.Anything).Return(challenge, nil)
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Consume(mockAppCtx, request, client)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, challenge, result)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ConsumeMagicLink Account Locked", func(t *testing.T) {
+		token := newToken()
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, userID).Return(&entity.User{Id: userID}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, token.Id, mock.Anything).Return(true, nil)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, userID, client.IpAddress).Return(exception.Locked("account is temporarily locked", 10*time.Minute))
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Consume(mockAppCtx, request, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.LockedCode, errService.Code)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockMFAService.AssertNotCalled(t, "Enabled", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ConsumeMagicLink Wrong Purpose", func(t *testing.T) {
+		token := newToken()
+		token.Purpose = entity.TokenPurposePasswordReset
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", token.TokenHash).Return(token, nil)
+		mockRateLimitRepository := new(mocks.RateLimitRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockNotifier := new(mocks.Notifier)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMagicLinkService(gormDB, mockUserRepository, mockUserTokenRepository, mockRateLimitRepository, mockTokenService, mockMFAService, mockLockoutService, mockNotifier, validate, magicLinkConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Consume(mockAppCtx, request, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockUserTokenRepository.AssertNotCalled(t, "ConsumeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
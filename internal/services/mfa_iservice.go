@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+type MFAService interface {
+	// Enroll creates a new, unconfirmed TOTP secret for the user, replacing any earlier unconfirmed one
+	Enroll(ctx context.Context, userID string) (*entity.MFAEnrollment, *exception.Exception)
+	// Confirm enables MFA once the user proves their authenticator works and returns fresh recovery codes
+	Confirm(ctx context.Context, userID string, model *entity.MFACodeRequest) (*entity.MFARecoveryCodes, *exception.Exception)
+	// Enabled reports whether the user has confirmed MFA
+	Enabled(ctx context.Context, userID string) (bool, *exception.Exception)
+	// Challenge answers a password login with a short-lived mfa_token instead of access tokens
+	Challenge(ctx context.Context, user *entity.User) (*UserLoginResponse, *exception.Exception)
+	// Verify redeems an mfa_token together with a TOTP or recovery code and issues the tokens,
+	// or a password change token if the password expired
+	Verify(ctx context.Context, model *entity.MFAVerifyRequest, client entity.ClientInfo) (*UserLoginResponse, *exception.Exception)
+	// Reset removes the user's MFA so they can sign in with only their password and enroll again
+	Reset(ctx context.Context, userID string) *exception.Exception
+}
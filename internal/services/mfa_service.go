@@ -0,0 +1,368 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+	"gorm.io/gorm"
+	"image/png"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const (
+	totpPeriod = 30
+	// totpSkew is how many time steps before and after now a code is accepted for
+	totpSkew          = 1
+	recoveryCodeCount = 10
+	qrCodeSize        = 256
+)
+
+// MFAConfig names the issuer shown in authenticator apps and bounds how long a
+// login may wait for its second factor.
+type MFAConfig struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+}
+
+type MFAServiceImpl struct {
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	mfaRepo        repository.MFARepository
+	userTokenRepo  repository.UserTokenRepository
+	tokenService   TokenService
+	passwordPolicy PasswordPolicyService
+	lockoutService LockoutService
+	validate       *xvalidator.Validator
+	conf           *MFAConfig
+}
+
+func NewMFAService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	userTokenRepo repository.UserTokenRepository,
+	tokenService TokenService,
+	passwordPolicy PasswordPolicyService,
+	lockoutService LockoutService,
+	validate *xvalidator.Validator,
+	conf *MFAConfig,
+) MFAService {
+	return &MFAServiceImpl{
+		db:             db,
+		userRepo:       userRepo,
+		mfaRepo:        mfaRepo,
+		userTokenRepo:  userTokenRepo,
+		tokenService:   tokenService,
+		passwordPolicy: passwordPolicy,
+		lockoutService: lockoutService,
+		validate:       validate,
+		conf:           conf,
+	}
+}
+
+func (s *MFAServiceImpl) Enroll(ctx context.Context, userID string) (*entity.MFAEnrollment, *exception.Exception) {
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	existing, err := s.mfaRepo.FindByColumn(ctx, s.db, "user_id", userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if existing.IsEnabled() {
+		return nil, exception.Conflict("mfa is already enabled")
+	}
+	accountName := user.Email
+	if accountName == "" {
+		accountName = user.Username
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.conf.Issuer,
+		AccountName: accountName,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, exception.Internal("can't generate totp secret", err)
+	}
+	qrCode, err := qrCodeDataURI(key)
+	if err != nil {
+		return nil, exception.Internal("can't render qr code", err)
+	}
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if existing != nil {
+		if err := s.mfaRepo.DeleteByUserTx(ctx, tx, userID); err != nil {
+			return nil, exception.Internal("err", err)
+		}
+	}
+	if err := s.mfaRepo.CreateTx(ctx, tx, &entity.UserMFA{
+		Id:        uuid.NewString(),
+		UserId:    userID,
+		Secret:    key.Secret(),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &entity.MFAEnrollment{
+		Secret:     key.Secret(),
+		OtpauthURL: key.URL(),
+		QRCode:     qrCode,
+	}, nil
+}
+
+func (s *MFAServiceImpl) Confirm(ctx context.Context, userID string, model *entity.MFACodeRequest) (
+	*entity.MFARecoveryCodes, *exception.Exception,
+) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	mfa, err := s.mfaRepo.FindByColumn(ctx, s.db, "user_id", userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if mfa == nil {
+		return nil, exception.NotFound("mfa enrollment has not been started")
+	}
+	if mfa.IsEnabled() {
+		return nil, exception.Conflict("mfa is already enabled")
+	}
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	ok, exc := s.checkCode(ctx, tx, mfa, model.Code)
+	if exc != nil {
+		return nil, exc
+	}
+	if !ok {
+		return nil, exception.InvalidArgument("invalid code")
+	}
+	now := time.Now()
+	mfa.ConfirmedAt = &now
+	if err := s.mfaRepo.UpdateTx(ctx, tx, mfa); err != nil {
+		return nil, updateException(err)
+	}
+	codes, exc := s.createRecoveryCodes(ctx, tx, userID)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &entity.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func (s *MFAServiceImpl) Enabled(ctx context.Context, userID string) (bool, *exception.Exception) {
+	mfa, err := s.mfaRepo.FindByColumn(ctx, s.db, "user_id", userID)
+	if err != nil {
+		return false, exception.Internal("err", err)
+	}
+	return mfa.IsEnabled(), nil
+}
+
+func (s *MFAServiceImpl) Challenge(ctx context.Context, user *entity.User) (*UserLoginResponse, *exception.Exception) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := issueUserToken(ctx, s.userTokenRepo, tx, user.Id, entity.TokenPurposeMFAChallenge, s.conf.ChallengeTTL)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &UserLoginResponse{
+		Username:    user.Username,
+		Email:       user.Email,
+		MFARequired: true,
+		MFAToken:    token,
+	}, nil
+}
+
+// Verify spends the mfa_token on the first attempt, right or wrong, so codes
+// can't be guessed against one password login. A wrong code means logging in again
+// and counts towards the account lockout like a wrong password; the failures are
+// only cleared once the code is right.
+func (s *MFAServiceImpl) Verify(ctx context.Context, model *entity.MFAVerifyRequest, client entity.ClientInfo) (
+	*UserLoginResponse, *exception.Exception,
+) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	challenge, exc := consumeUserToken(ctx, s.userTokenRepo, tx, entity.TokenPurposeMFAChallenge, model.MFAToken)
+	if exc != nil {
+		if exc.Code == exception.InvalidArgumentCode {
+			return nil, exception.Unauthenticated("invalid or expired mfa token")
+		}
+		return nil, exc
+	}
+	mfa, err := s.mfaRepo.FindByColumn(ctx, tx, "user_id", challenge.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !mfa.IsEnabled() {
+		return nil, exception.Unauthenticated("mfa is not enabled")
+	}
+	var ok bool
+	if model.Code != "" {
+		ok, exc = s.checkCode(ctx, tx, mfa, model.Code)
+	} else {
+		ok, err = s.mfaRepo.UseRecoveryCodeTx(ctx, tx, mfa.UserId, hashRecoveryCode(model.RecoveryCode), time.Now())
+		if err != nil {
+			exc = exception.Internal("err", err)
+		}
+	}
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	if exc := s.lockoutService.Check(ctx, mfa.UserId, client.IpAddress); exc != nil {
+		return nil, exc
+	}
+	if !ok {
+		if exc := s.lockoutService.RecordFailure(ctx, mfa.UserId, client.IpAddress); exc != nil {
+			return nil, exc
+		}
+		return nil, exception.Unauthenticated("invalid code")
+	}
+	if exc := s.lockoutService.RecordSuccess(ctx, mfa.UserId); exc != nil {
+		return nil, exc
+	}
+
+	user, err := s.userRepo.FindByID(ctx, s.db, mfa.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.Unauthenticated("user not found")
+	}
+	// Expiry is checked once every factor passed, so a leaked password alone can't change it.
+	if s.passwordPolicy.Expired(user, time.Now()) {
+		return s.passwordPolicy.ChangeRequired(ctx, user)
+	}
+	return s.tokenService.Issue(ctx, user, client)
+}
+
+func (s *MFAServiceImpl) Reset(ctx context.Context, userID string) *exception.Exception {
+	if _, err := uuid.Parse(userID); err != nil {
+		return exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.mfaRepo.DeleteByUserTx(ctx, tx, userID); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := s.userTokenRepo.InvalidateTx(ctx, tx, userID, entity.TokenPurposeMFAChallenge, time.Now()); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+// checkCode accepts a code from the current time step or totpSkew steps around
+// it, and only if no code from that step or a later one was accepted before.
+func (s *MFAServiceImpl) checkCode(
+	ctx context.Context, tx *gorm.DB, mfa *entity.UserMFA, code string,
+) (bool, *exception.Exception) {
+	current := time.Now().Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		expected, err := hotp.GenerateCodeCustom(mfa.Secret, uint64(step), hotp.ValidateOpts{
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return false, exception.Internal("can't generate totp code", err)
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) != 1 {
+			continue
+		}
+		advanced, err := s.mfaRepo.AdvanceStepTx(ctx, tx, mfa.Id, step)
+		if err != nil {
+			return false, exception.Internal("err", err)
+		}
+		if advanced {
+			mfa.LastUsedStep = step
+		}
+		return advanced, nil
+	}
+	return false, nil
+}
+
+func (s *MFAServiceImpl) createRecoveryCodes(ctx context.Context, tx *gorm.DB, userID string) (
+	[]string, *exception.Exception,
+) {
+	now := time.Now()
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]*entity.MFARecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, exception.Internal("can't generate recovery code", err)
+		}
+		codes = append(codes, code)
+		records = append(records, &entity.MFARecoveryCode{
+			Id:        uuid.NewString(),
+			UserId:    userID,
+			CodeHash:  hashRecoveryCode(code),
+			CreatedAt: now,
+		})
+	}
+	if err := s.mfaRepo.CreateRecoveryCodesTx(ctx, tx, records); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return codes, nil
+}
+
+// generateRecoveryCode returns 10 random base32 characters, about 50 bits,
+// formatted as "xxxxx-xxxxx".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return signature.HashToken(code)
+}
+
+func qrCodeDataURI(key *otp.Key) (string, error) {
+	img, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
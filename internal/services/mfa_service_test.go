@@ -0,0 +1,435 @@
+package service_test
+
+import (
+	"context"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+var mfaConfig = &service.MFAConfig{
+	Issuer:       "user-simple-crud",
+	ChallengeTTL: 5 * time.Minute,
+}
+
+const totpSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func TestEnrollMFA(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+		Email:    "john_doe@example.com",
+	}
+
+	t.Run("EnrollMFA Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(nil, nil)
+		mockMFARepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(mfa *entity.UserMFA) bool {
+			return mfa.UserId == user.Id && mfa.Secret != "" && mfa.ConfirmedAt == nil
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Enroll(mockAppCtx, user.Id)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.True(t, strings.HasPrefix(result.OtpauthURL, "otpauth://totp/"))
+		assert.Contains(t, result.OtpauthURL, "secret="+result.Secret)
+		assert.True(t, strings.HasPrefix(result.QRCode, "data:image/png;base64,"))
+		mockMFARepository.AssertExpectations(t)
+	})
+
+	t.Run("EnrollMFA Already Enabled", func(t *testing.T) {
+		confirmedAt := time.Now()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(&entity.UserMFA{
+			UserId: user.Id, Secret: totpSecret, ConfirmedAt: &confirmedAt,
+		}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		result, errService := mockService.Enroll(mockAppCtx, user.Id)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.AlreadyExistsCode, errService.Code)
+	})
+}
+
+func TestConfirmMFA(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("ConfirmMFA Success", func(t *testing.T) {
+		pending := &entity.UserMFA{Id: "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11", UserId: userID, Secret: totpSecret}
+		code, err := totp.GenerateCode(totpSecret, time.Now())
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", userID).Return(pending, nil)
+		mockMFARepository.On("AdvanceStepTx", mockAppCtx, mock.Anything, pending.Id, mock.Anything).Return(true, nil)
+		mockMFARepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(mfa *entity.UserMFA) bool {
+			return mfa.ConfirmedAt != nil && mfa.LastUsedStep > 0
+		})).Return(nil)
+		mockMFARepository.On("CreateRecoveryCodesTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(codes []*entity.MFARecoveryCode) bool {
+			return len(codes) == 10 && codes[0].CodeHash != ""
+		})).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Confirm(mockAppCtx, userID, &entity.MFACodeRequest{Code: code})
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Len(t, result.RecoveryCodes, 10)
+		mockMFARepository.AssertExpectations(t)
+	})
+
+	t.Run("ConfirmMFA Invalid Code", func(t *testing.T) {
+		pending := &entity.UserMFA{Id: "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11", UserId: userID, Secret: totpSecret}
+		code, err := totp.GenerateCode(totpSecret, time.Now().Add(-10*time.Minute))
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", userID).Return(pending, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		result, errService := mockService.Confirm(mockAppCtx, userID, &entity.MFACodeRequest{Code: code})
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockMFARepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestVerifyMFA(t *testing.T) {
+	mockAppCtx := context.Background()
+	client := entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "john_doe"}
+	confirmedAt := time.Now().Add(-time.Hour)
+	enabled := &entity.UserMFA{Id: "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11", UserId: user.Id, Secret: totpSecret, ConfirmedAt: &confirmedAt}
+	challenge := &entity.UserToken{
+		Id:        "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		UserId:    user.Id,
+		Purpose:   entity.TokenPurposeMFAChallenge,
+		TokenHash: signature.HashToken("mfa_token"),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	t.Run("VerifyMFA Code Success", func(t *testing.T) {
+		code, err := totp.GenerateCode(totpSecret, time.Now())
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("AdvanceStepTx", mockAppCtx, mock.Anything, enabled.Id, mock.Anything).Return(true, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, user, client).Return(&service.UserLoginResponse{Username: user.Username, Token: "jwt_token"}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, user.Id).Return(nil)
+		mockPasswordPolicyService.On("Expired", user, mock.Anything).Return(false)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: code}, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+	})
+
+	t.Run("VerifyMFA Replayed Code", func(t *testing.T) {
+		code, err := totp.GenerateCode(totpSecret, time.Now())
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("AdvanceStepTx", mockAppCtx, mock.Anything, enabled.Id, mock.Anything).Return(false, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+		mockLockoutService.On("RecordFailure", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: code}, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockLockoutService.AssertCalled(t, "RecordFailure", mockAppCtx, user.Id, client.IpAddress)
+		mockLockoutService.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyMFA Recovery Code Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("UseRecoveryCodeTx", mockAppCtx, mock.Anything, user.Id, signature.HashToken("k7qzmp2x4d"), mock.Anything).Return(true, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, user, client).Return(&service.UserLoginResponse{Username: user.Username, Token: "jwt_token"}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, user.Id).Return(nil)
+		mockPasswordPolicyService.On("Expired", user, mock.Anything).Return(false)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", RecoveryCode: "K7QZM-P2X4D"}, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+	})
+
+	t.Run("VerifyMFA Expired Password", func(t *testing.T) {
+		code, err := totp.GenerateCode(totpSecret, time.Now())
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("AdvanceStepTx", mockAppCtx, mock.Anything, enabled.Id, mock.Anything).Return(true, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, user.Id).Return(nil)
+		mockPasswordPolicyService.On("Expired", user, mock.Anything).Return(true)
+		mockPasswordPolicyService.On("ChangeRequired", mockAppCtx, user).Return(&service.UserLoginResponse{
+			Username: user.Username, PasswordChangeRequired: true, PasswordChangeToken: "change_token",
+		}, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: code}, client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.True(t, result.PasswordChangeRequired)
+		assert.Empty(t, result.Token)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyMFA Wrong Recovery Code", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("UseRecoveryCodeTx", mockAppCtx, mock.Anything, user.Id, signature.HashToken("aaaaaaaaaa"), mock.Anything).Return(false, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+		mockLockoutService.On("RecordFailure", mockAppCtx, user.Id, client.IpAddress).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", RecoveryCode: "AAAAA-AAAAA"}, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		mockLockoutService.AssertExpectations(t)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyMFA Account Locked", func(t *testing.T) {
+		code, err := totp.GenerateCode(totpSecret, time.Now())
+		require.NoError(t, err)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("FindByColumn", mockAppCtx, mock.Anything, "user_id", user.Id).Return(enabled, nil)
+		mockMFARepository.On("AdvanceStepTx", mockAppCtx, mock.Anything, enabled.Id, mock.Anything).Return(true, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", challenge.TokenHash).Return(challenge, nil)
+		mockUserTokenRepository.On("ConsumeTx", mockAppCtx, mock.Anything, challenge.Id, mock.Anything).Return(true, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, user.Id, client.IpAddress).Return(exception.Locked("account is temporarily locked, try again later", time.Minute))
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "mfa_token", Code: code}, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.LockedCode, errService.Code)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockLockoutService.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyMFA Invalid MFA Token", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", signature.HashToken("unknown")).Return(nil, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		result, errService := mockService.Verify(mockAppCtx, &entity.MFAVerifyRequest{MFAToken: "unknown", Code: "123456"}, client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+}
+
+func TestResetMFA(t *testing.T) {
+	mockAppCtx := context.Background()
+	userID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("ResetMFA Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockMFARepository := new(mocks.MFARepository)
+		mockMFARepository.On("DeleteByUserTx", mockAppCtx, mock.Anything, userID).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, userID, entity.TokenPurposeMFAChallenge, mock.Anything).Return(nil)
+		mockTokenService := new(mocks.TokenService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockLockoutService := new(mocks.LockoutService)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewMFAService(gormDB, mockUserRepository, mockMFARepository, mockUserTokenRepository, mockTokenService, mockPasswordPolicyService, mockLockoutService, validate, mfaConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Reset(mockAppCtx, userID)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockMFARepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+// OAuthService is the OAuth2 authorization server and OpenID Connect provider.
+// Exceptions returned by Authorize and Token carry an *entity.OAuthError so
+// they can be reported in the RFC 6749 format.
+type OAuthService interface {
+	// RegisterClient returns the client secret in plain text, the only time it is shown
+	RegisterClient(ctx context.Context, model *entity.OAuthClientRequest) (*entity.OAuthClientSecret, *exception.Exception)
+	ListClients(ctx context.Context) ([]*entity.OAuthClient, *exception.Exception)
+	// DeleteClient removes the client and revokes the refresh tokens issued to it
+	DeleteClient(ctx context.Context, id string) *exception.Exception
+	// Authorize checks a request the client sent through the browser and returns where to send
+	// the browser next: the consent page carrying the resolved request, or the client redirect URI
+	// carrying an error. An exception means the request can't be redirected at all.
+	Authorize(ctx context.Context, model *entity.AuthorizeRequest) (string, *exception.Exception)
+	// Consent checks the request again and, when the signed in user approved it, issues an
+	// authorization code. It returns the client redirect URI carrying the code or an error.
+	Consent(
+		ctx context.Context, auth *signature.JwtAuthenticationRes, model *entity.OAuthConsentRequest,
+	) (string, *exception.Exception)
+	Token(ctx context.Context, model *entity.OAuthTokenRequest) (*entity.OAuthTokenResponse, *exception.Exception)
+	UserInfo(ctx context.Context, auth *signature.JwtAuthenticationRes) (*entity.UserInfo, *exception.Exception)
+	Discovery() *entity.OpenIDConfiguration
+}
@@ -0,0 +1,717 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const (
+	oauthClientSecretBytes = 32
+	oauthCodeBytes         = 32
+	// pkceChallengeLength is the length of a base64url encoded SHA-256 digest
+	pkceChallengeLength = 43
+	pkceVerifierMin     = 43
+	pkceVerifierMax     = 128
+)
+
+// OAuthConfig holds the settings of the authorization server. Issuer must be
+// the public URL the discovery document is served under for OpenID Connect
+// clients to accept the ID tokens.
+type OAuthConfig struct {
+	Issuer  string
+	BaseURL string
+	// ConsentURL is the first-party page that signs the user in, asks them to
+	// approve an authorization request and posts the answer to Consent
+	ConsentURL      string
+	CodeTTL         time.Duration
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type OAuthServiceImpl struct {
+	db               *gorm.DB
+	userRepo         repository.UserRepository
+	oauthRepo        repository.OAuthRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	signaturer       signature.Signaturer
+	validate         *xvalidator.Validator
+	conf             *OAuthConfig
+}
+
+func NewOAuthService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	oauthRepo repository.OAuthRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	signaturer signature.Signaturer,
+	validate *xvalidator.Validator,
+	conf *OAuthConfig,
+) OAuthService {
+	return &OAuthServiceImpl{
+		db:               db,
+		userRepo:         userRepo,
+		oauthRepo:        oauthRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		signaturer:       signaturer,
+		validate:         validate,
+		conf:             conf,
+	}
+}
+
+func (s *OAuthServiceImpl) RegisterClient(
+	ctx context.Context, model *entity.OAuthClientRequest,
+) (*entity.OAuthClientSecret, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	for _, scope := range model.Scopes {
+		if !isOpenIDScope(scope) && !entity.IsValidPermission(scope) {
+			return nil, exception.InvalidArgument("unknown scope " + scope)
+		}
+	}
+	usesCode := slices.Contains(model.GrantTypes, entity.GrantTypeAuthorizationCode)
+	if usesCode && len(model.RedirectURIs) == 0 {
+		return nil, exception.InvalidArgument("redirect_uris are required for the authorization_code grant")
+	}
+	if !usesCode && slices.Contains(model.GrantTypes, entity.GrantTypeRefreshToken) {
+		return nil, exception.InvalidArgument("the refresh_token grant requires the authorization_code grant")
+	}
+	if model.Public && slices.Contains(model.GrantTypes, entity.GrantTypeClientCredentials) {
+		return nil, exception.InvalidArgument("public clients can't use the client_credentials grant")
+	}
+	for _, uri := range model.RedirectURIs {
+		if parsed, err := url.Parse(uri); err != nil || parsed.Fragment != "" {
+			return nil, exception.InvalidArgument("redirect_uri " + uri + " must be an absolute URI without a fragment")
+		}
+	}
+	data := &entity.OAuthClient{
+		Id:           uuid.NewString(),
+		Name:         model.Name,
+		Public:       model.Public,
+		RedirectURIs: model.RedirectURIs,
+		GrantTypes:   model.GrantTypes,
+		Scopes:       model.Scopes,
+		CreatedAt:    time.Now(),
+	}
+	var secret string
+	if !model.Public {
+		var err error
+		if secret, err = signature.GenerateRandomToken(oauthClientSecretBytes); err != nil {
+			return nil, exception.Internal("can't generate client secret", err)
+		}
+		data.SecretHash = signature.HashToken(secret)
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.oauthRepo.CreateTx(ctx, tx, data); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &entity.OAuthClientSecret{OAuthClient: *data, ClientSecret: secret}, nil
+}
+
+func (s *OAuthServiceImpl) ListClients(ctx context.Context) ([]*entity.OAuthClient, *exception.Exception) {
+	data, err := s.oauthRepo.FindClients(ctx, s.db)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return data, nil
+}
+
+func (s *OAuthServiceImpl) DeleteClient(ctx context.Context, id string) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid client id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	deleted, err := s.oauthRepo.DeleteClientTx(ctx, tx, id)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if !deleted {
+		return exception.NotFound("client not found")
+	}
+	if err := s.refreshTokenRepo.RevokeByClientTx(ctx, tx, id, time.Now()); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *OAuthServiceImpl) Authorize(ctx context.Context, model *entity.AuthorizeRequest) (
+	string, *exception.Exception,
+) {
+	req, location, exc := s.checkAuthorizeRequest(ctx, model)
+	if req == nil {
+		return location, exc
+	}
+	// The consent page gets the request as it was resolved, so what the user
+	// approves is what Consent checks again. The redirect URI stays as the
+	// client sent it, Consent has to know whether it was given.
+	return withQuery(s.conf.ConsentURL, map[string]string{
+		"response_type":         model.ResponseType,
+		"client_id":             req.client.Id,
+		"client_name":           req.client.Name,
+		"redirect_uri":          model.RedirectURI,
+		"scope":                 strings.Join(req.scopes, " "),
+		"state":                 model.State,
+		"code_challenge":        model.CodeChallenge,
+		"code_challenge_method": model.CodeChallengeMethod,
+		"nonce":                 model.Nonce,
+	}), nil
+}
+
+func (s *OAuthServiceImpl) Consent(
+	ctx context.Context, auth *signature.JwtAuthenticationRes, model *entity.OAuthConsentRequest,
+) (string, *exception.Exception) {
+	req, location, exc := s.checkAuthorizeRequest(ctx, &model.AuthorizeRequest)
+	if req == nil {
+		return location, exc
+	}
+	if !model.Approve {
+		return req.fail(entity.OAuthAccessDenied, "the user denied the request")
+	}
+
+	code, err := signature.GenerateRandomToken(oauthCodeBytes)
+	if err != nil {
+		return "", exception.Internal("can't generate authorization code", err)
+	}
+	now := time.Now()
+	data := &entity.OAuthAuthorizationCode{
+		Id:               uuid.NewString(),
+		CodeHash:         signature.HashToken(code),
+		ClientId:         req.client.Id,
+		UserId:           auth.Subject,
+		RedirectURI:      req.redirectURI,
+		RedirectURIGiven: model.RedirectURI != "",
+		Scopes:           req.scopes,
+		CodeChallenge:    model.CodeChallenge,
+		Nonce:            model.Nonce,
+		// The session's access token is the closest record of when the user
+		// last authenticated
+		AuthTime:  auth.IssuedAt,
+		ExpiresAt: now.Add(s.conf.CodeTTL),
+		CreatedAt: now,
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.oauthRepo.CreateCodeTx(ctx, tx, data); err != nil {
+		return "", exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return "", exception.Internal("commit transaction", err)
+	}
+	return withQuery(req.redirectURI, map[string]string{"code": code, "state": model.State}), nil
+}
+
+// authorizeRequest is an authorization request resolved against its client.
+type authorizeRequest struct {
+	client      *entity.OAuthClient
+	redirectURI string
+	scopes      []string
+	state       string
+}
+
+// fail sends the error back to the client's redirect URI.
+func (r *authorizeRequest) fail(code, description string) (string, *exception.Exception) {
+	return withQuery(r.redirectURI, map[string]string{
+		"error":             code,
+		"error_description": description,
+		"state":             r.state,
+	}), nil
+}
+
+// checkAuthorizeRequest resolves model against its client. When it is invalid
+// the request is nil and either the client redirect URI carrying the error or
+// an exception, when it can't be redirected, is returned instead.
+func (s *OAuthServiceImpl) checkAuthorizeRequest(ctx context.Context, model *entity.AuthorizeRequest) (
+	*authorizeRequest, string, *exception.Exception,
+) {
+	// Until the client and redirect URI are known to be genuine, errors go to
+	// the user and never to the redirect URI.
+	if _, err := uuid.Parse(model.ClientId); err != nil {
+		return nil, "", oauthError(entity.OAuthInvalidRequest, "unknown client_id")
+	}
+	client, err := s.oauthRepo.FindByID(ctx, s.db, model.ClientId)
+	if err != nil {
+		return nil, "", exception.Internal("err", err)
+	}
+	if client == nil {
+		return nil, "", oauthError(entity.OAuthInvalidRequest, "unknown client_id")
+	}
+	req := &authorizeRequest{client: client, redirectURI: model.RedirectURI, state: model.State}
+	if req.redirectURI == "" && len(client.RedirectURIs) == 1 {
+		req.redirectURI = client.RedirectURIs[0]
+	}
+	if !client.AllowsRedirectURI(req.redirectURI) {
+		return nil, "", oauthError(entity.OAuthInvalidRequest, "redirect_uri is not registered for the client")
+	}
+
+	fail := func(code, description string) (*authorizeRequest, string, *exception.Exception) {
+		location, exc := req.fail(code, description)
+		return nil, location, exc
+	}
+	if model.ResponseType != "code" {
+		return fail(entity.OAuthUnsupportedResponseType, "only the code response type is supported")
+	}
+	if !client.AllowsGrant(entity.GrantTypeAuthorizationCode) {
+		return fail(entity.OAuthUnauthorizedClient, "client is not allowed to use the authorization_code grant")
+	}
+	if model.CodeChallengeMethod != entity.PKCEMethodS256 || len(model.CodeChallenge) != pkceChallengeLength {
+		return fail(entity.OAuthInvalidRequest, "a code_challenge with code_challenge_method S256 is required")
+	}
+	req.scopes = strings.Fields(model.Scope)
+	if len(req.scopes) == 0 {
+		req.scopes = client.Scopes
+	}
+	for _, scope := range req.scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return fail(entity.OAuthInvalidScope, "scope "+scope+" is not allowed for the client")
+		}
+	}
+	return req, "", nil
+}
+
+func (s *OAuthServiceImpl) Token(
+	ctx context.Context, model *entity.OAuthTokenRequest,
+) (*entity.OAuthTokenResponse, *exception.Exception) {
+	if model.GrantType == "" {
+		return nil, oauthError(entity.OAuthInvalidRequest, "grant_type is required")
+	}
+	client, exc := s.authenticateClient(ctx, model)
+	if exc != nil {
+		return nil, exc
+	}
+	switch model.GrantType {
+	case entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken, entity.GrantTypeClientCredentials:
+	default:
+		return nil, oauthError(entity.OAuthUnsupportedGrantType, "unsupported grant_type "+model.GrantType)
+	}
+	if !client.AllowsGrant(model.GrantType) {
+		return nil, oauthError(entity.OAuthUnauthorizedClient, "client is not allowed to use the "+model.GrantType+" grant")
+	}
+	switch model.GrantType {
+	case entity.GrantTypeAuthorizationCode:
+		return s.exchangeCode(ctx, client, model)
+	case entity.GrantTypeRefreshToken:
+		return s.exchangeRefreshToken(ctx, client, model)
+	default:
+		return s.clientCredentials(client, model)
+	}
+}
+
+func (s *OAuthServiceImpl) UserInfo(
+	ctx context.Context, auth *signature.JwtAuthenticationRes,
+) (*entity.UserInfo, *exception.Exception) {
+	scopes := strings.Fields(auth.Scope)
+	if !slices.Contains(scopes, entity.ScopeOpenID) {
+		return nil, exception.PermissionDenied("access token was not granted the openid scope")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, auth.Subject)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.Unauthenticated("Invalid token")
+	}
+	info := &entity.UserInfo{Subject: user.Id}
+	if slices.Contains(scopes, entity.ScopeProfile) {
+		info.PreferredUsername = user.Username
+	}
+	if slices.Contains(scopes, entity.ScopeEmail) {
+		verified := user.EmailVerifiedAt != nil
+		info.Email = user.Email
+		info.EmailVerified = &verified
+	}
+	return info, nil
+}
+
+func (s *OAuthServiceImpl) Discovery() *entity.OpenIDConfiguration {
+	baseURL := strings.TrimSuffix(s.conf.BaseURL, "/")
+	var algorithms []string
+	for _, key := range s.signaturer.JWKS().Keys {
+		if !slices.Contains(algorithms, key.Alg) {
+			algorithms = append(algorithms, key.Alg)
+		}
+	}
+	roles := make([]string, 0, len(entity.RolePermissions))
+	for role := range entity.RolePermissions {
+		roles = append(roles, role)
+	}
+	return &entity.OpenIDConfiguration{
+		Issuer:                           s.conf.Issuer,
+		AuthorizationEndpoint:            baseURL + "/oauth/authorize",
+		TokenEndpoint:                    baseURL + "/oauth/token",
+		UserinfoEndpoint:                 baseURL + "/oauth/userinfo",
+		JwksURI:                          baseURL + "/.well-known/jwks.json",
+		ResponseTypesSupported:           []string{"code"},
+		GrantTypesSupported:              []string{entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken, entity.GrantTypeClientCredentials},
+		SubjectTypesSupported:            []string{"public"},
+		IdTokenSigningAlgValuesSupported: algorithms,
+		ScopesSupported: append(
+			[]string{entity.ScopeOpenID, entity.ScopeProfile, entity.ScopeEmail},
+			entity.PermissionsFor(roles)...,
+		),
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "preferred_username", "email", "email_verified",
+		},
+		CodeChallengeMethodsSupported: []string{entity.PKCEMethodS256},
+	}
+}
+
+// authenticateClient checks the client secret of confidential clients. Public
+// clients only identify themselves and rely on PKCE instead.
+func (s *OAuthServiceImpl) authenticateClient(
+	ctx context.Context, model *entity.OAuthTokenRequest,
+) (*entity.OAuthClient, *exception.Exception) {
+	if _, err := uuid.Parse(model.ClientId); err != nil {
+		return nil, oauthError(entity.OAuthInvalidClient, "client authentication failed")
+	}
+	client, err := s.oauthRepo.FindByID(ctx, s.db, model.ClientId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if client == nil {
+		return nil, oauthError(entity.OAuthInvalidClient, "client authentication failed")
+	}
+	if client.Public {
+		if model.ClientSecret != "" {
+			return nil, oauthError(entity.OAuthInvalidClient, "client authentication failed")
+		}
+		return client, nil
+	}
+	if model.ClientSecret == "" ||
+		subtle.ConstantTimeCompare([]byte(signature.HashToken(model.ClientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, oauthError(entity.OAuthInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+func (s *OAuthServiceImpl) exchangeCode(
+	ctx context.Context, client *entity.OAuthClient, model *entity.OAuthTokenRequest,
+) (*entity.OAuthTokenResponse, *exception.Exception) {
+	if model.Code == "" {
+		return nil, oauthError(entity.OAuthInvalidRequest, "code is required")
+	}
+	code, err := s.oauthRepo.FindCodeByHash(ctx, s.db, signature.HashToken(model.Code))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if code == nil || code.ClientId != client.Id {
+		return nil, oauthError(entity.OAuthInvalidGrant, "invalid authorization code")
+	}
+	if code.ConsumedAt != nil {
+		return nil, s.replayedCode(ctx, code)
+	}
+	if !time.Now().Before(code.ExpiresAt) {
+		return nil, oauthError(entity.OAuthInvalidGrant, "authorization code has expired")
+	}
+	// RFC 6749 section 4.1.3, redirect_uri must match when the authorization
+	// request included it.
+	if (code.RedirectURIGiven || model.RedirectURI != "") && model.RedirectURI != code.RedirectURI {
+		return nil, oauthError(entity.OAuthInvalidGrant, "redirect_uri does not match the authorization request")
+	}
+	if !verifyPKCE(model.CodeVerifier, code.CodeChallenge) {
+		return nil, oauthError(entity.OAuthInvalidGrant, "code_verifier does not match the code_challenge")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, code.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, oauthError(entity.OAuthInvalidGrant, "invalid authorization code")
+	}
+	if exc := inactiveUserGrant(user); exc != nil {
+		return nil, exc
+	}
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	consumed, err := s.oauthRepo.ConsumeCodeTx(ctx, tx, code.Id, time.Now())
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !consumed {
+		tx.Rollback()
+		return nil, s.replayedCode(ctx, code)
+	}
+	scopes := grantedScopes(user, code.Scopes)
+	var refreshToken *issuedRefreshToken
+	if client.AllowsGrant(entity.GrantTypeRefreshToken) {
+		// The code id names the refresh token family, so a replayed code can
+		// revoke what was issued for it
+		var exc *exception.Exception
+		refreshToken, exc = createRefreshToken(ctx, tx, s.refreshTokenRepo, &entity.RefreshToken{
+			UserId:   user.Id,
+			FamilyId: code.Id,
+			ClientId: client.Id,
+			Scopes:   scopes,
+		}, s.conf.RefreshTokenTTL)
+		if exc != nil {
+			return nil, exc
+		}
+	}
+	resp, exc := s.tokenResponse(client, user, scopes, refreshToken, code.Nonce, code.AuthTime)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return resp, nil
+}
+
+func (s *OAuthServiceImpl) exchangeRefreshToken(
+	ctx context.Context, client *entity.OAuthClient, model *entity.OAuthTokenRequest,
+) (*entity.OAuthTokenResponse, *exception.Exception) {
+	if model.RefreshToken == "" {
+		return nil, oauthError(entity.OAuthInvalidRequest, "refresh_token is required")
+	}
+	current, err := s.refreshTokenRepo.FindByColumn(ctx, s.db, "token_hash", signature.HashToken(model.RefreshToken))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if current == nil || current.ClientId != client.Id {
+		return nil, oauthError(entity.OAuthInvalidGrant, "invalid refresh token")
+	}
+	now := time.Now()
+	if current.RevokedAt != nil {
+		if current.ReplacedBy != "" {
+			if exc := revokeRefreshFamily(ctx, s.db, s.refreshTokenRepo, current); exc != nil {
+				return nil, exc
+			}
+			return nil, oauthError(entity.OAuthInvalidGrant, "refresh token reuse detected")
+		}
+		return nil, oauthError(entity.OAuthInvalidGrant, "refresh token has been revoked")
+	}
+	if !current.IsActive(now) {
+		return nil, oauthError(entity.OAuthInvalidGrant, "refresh token has expired")
+	}
+	scopes := current.Scopes
+	if requested := strings.Fields(model.Scope); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(current.Scopes, scope) {
+				return nil, oauthError(entity.OAuthInvalidScope, "scope "+scope+" was not originally granted")
+			}
+		}
+		scopes = requested
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, current.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, oauthError(entity.OAuthInvalidGrant, "invalid refresh token")
+	}
+	if exc := inactiveUserGrant(user); exc != nil {
+		return nil, exc
+	}
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	// The successor keeps the original grant so a later refresh may ask for
+	// any of it again
+	next, exc := createRefreshToken(ctx, tx, s.refreshTokenRepo, &entity.RefreshToken{
+		UserId:   user.Id,
+		FamilyId: current.FamilyId,
+		ClientId: client.Id,
+		Scopes:   current.Scopes,
+	}, s.conf.RefreshTokenTTL)
+	if exc != nil {
+		return nil, exc
+	}
+	rotated, err := s.refreshTokenRepo.MarkRotatedTx(ctx, tx, current.Id, next.record.Id, now)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !rotated {
+		tx.Rollback()
+		if exc := revokeRefreshFamily(ctx, s.db, s.refreshTokenRepo, current); exc != nil {
+			return nil, exc
+		}
+		return nil, oauthError(entity.OAuthInvalidGrant, "refresh token reuse detected")
+	}
+	resp, exc := s.tokenResponse(client, user, grantedScopes(user, scopes), next, "", time.Time{})
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return resp, nil
+}
+
+// clientCredentials issues a token to the client itself. There is no user, so
+// only permission scopes apply and no refresh or ID token is issued.
+func (s *OAuthServiceImpl) clientCredentials(
+	client *entity.OAuthClient, model *entity.OAuthTokenRequest,
+) (*entity.OAuthTokenResponse, *exception.Exception) {
+	if client.Public {
+		return nil, oauthError(entity.OAuthUnauthorizedClient, "public clients can't use the client_credentials grant")
+	}
+	requested := strings.Fields(model.Scope)
+	if len(requested) == 0 {
+		requested = client.Scopes
+	}
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if !slices.Contains(client.Scopes, scope) {
+			return nil, oauthError(entity.OAuthInvalidScope, "scope "+scope+" is not allowed for the client")
+		}
+		if !isOpenIDScope(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return s.tokenResponse(client, nil, scopes, nil, "", time.Time{})
+}
+
+// tokenResponse signs the access token and, when the openid scope was granted
+// to a user, the ID token.
+func (s *OAuthServiceImpl) tokenResponse(
+	client *entity.OAuthClient, user *entity.User, scopes []string,
+	refreshToken *issuedRefreshToken, nonce string, authTime time.Time,
+) (*entity.OAuthTokenResponse, *exception.Exception) {
+	claims := signature.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: client.Id},
+		Username:         client.Name,
+		Scope:            strings.Join(scopes, " "),
+		ClientID:         client.Id,
+	}
+	for _, scope := range scopes {
+		if !isOpenIDScope(scope) {
+			claims.Permissions = append(claims.Permissions, scope)
+		}
+	}
+	if user != nil {
+		claims.Subject = user.Id
+		claims.Username = user.Username
+	}
+	accessToken, err := s.signaturer.GenerateJWT(claims)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	resp := &entity.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.conf.AccessTokenTTL.Seconds()),
+		Scope:       claims.Scope,
+	}
+	if refreshToken != nil {
+		resp.RefreshToken = refreshToken.token
+	}
+	if user != nil && slices.Contains(scopes, entity.ScopeOpenID) {
+		idClaims := signature.IDTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: user.Id, Audience: jwt.ClaimStrings{client.Id}},
+			Nonce:            nonce,
+		}
+		if !authTime.IsZero() {
+			idClaims.AuthTime = jwt.NewNumericDate(authTime)
+		}
+		if slices.Contains(scopes, entity.ScopeProfile) {
+			idClaims.PreferredUsername = user.Username
+		}
+		if slices.Contains(scopes, entity.ScopeEmail) {
+			verified := user.EmailVerifiedAt != nil
+			idClaims.Email = user.Email
+			idClaims.EmailVerified = &verified
+		}
+		if resp.IdToken, err = s.signaturer.GenerateIDToken(idClaims); err != nil {
+			return nil, exception.Internal("err", err)
+		}
+	}
+	return resp, nil
+}
+
+// inactiveUserGrant refuses grants of users that may not sign in, such as
+// suspended or banned ones. Deleted users aren't found at all.
+func inactiveUserGrant(user *entity.User) *exception.Exception {
+	if exc := userStatusException(user, time.Now()); exc != nil {
+		return oauthError(entity.OAuthInvalidGrant, fmt.Sprint(exc.Message))
+	}
+	return nil
+}
+
+// replayedCode revokes the tokens issued for an authorization code that is
+// presented a second time, as RFC 6749 section 4.1.2 asks.
+func (s *OAuthServiceImpl) replayedCode(ctx context.Context, code *entity.OAuthAuthorizationCode) *exception.Exception {
+	if exc := revokeRefreshFamily(ctx, s.db, s.refreshTokenRepo, &entity.RefreshToken{
+		UserId:   code.UserId,
+		FamilyId: code.Id,
+	}); exc != nil {
+		return exc
+	}
+	return oauthError(entity.OAuthInvalidGrant, "authorization code has already been used")
+}
+
+// grantedScopes drops the permission scopes the user doesn't currently hold,
+// so a client never acts with more than the user could.
+func grantedScopes(user *entity.User, scopes []string) []string {
+	permissions := entity.PermissionsFor(user.RoleNames())
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if isOpenIDScope(scope) || slices.Contains(permissions, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+func isOpenIDScope(scope string) bool {
+	return scope == entity.ScopeOpenID || scope == entity.ScopeProfile || scope == entity.ScopeEmail
+}
+
+func verifyPKCE(verifier, challenge string) bool {
+	if len(verifier) < pkceVerifierMin || len(verifier) > pkceVerifierMax {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// withQuery adds the non-empty params to the query of uri, keeping the query
+// it was registered with.
+func withQuery(uri string, params map[string]string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	query := parsed.Query()
+	for key, value := range params {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// oauthError builds an exception carrying the RFC 6749 error. invalid_client
+// is answered with 401, every other error with 400.
+func oauthError(code, description string) *exception.Exception {
+	exc := exception.InvalidArgument(description)
+	if code == entity.OAuthInvalidClient {
+		exc = exception.Unauthenticated(description)
+	}
+	exc.Error = &entity.OAuthError{Code: code, Description: description}
+	return exc
+}
@@ -0,0 +1,869 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+// PKCE example values from RFC 7636 appendix B.
+const (
+	pkceVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	pkceChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+var oauthConfig = &service.OAuthConfig{
+	Issuer:          "https://id.example.com",
+	BaseURL:         "https://id.example.com",
+	ConsentURL:      "https://id.example.com/oauth/consent",
+	CodeTTL:         5 * time.Minute,
+	AccessTokenTTL:  time.Hour,
+	RefreshTokenTTL: 720 * time.Hour,
+}
+
+func oauthErrorCode(t *testing.T, exc *exception.Exception) string {
+	t.Helper()
+	require.NotNil(t, exc)
+	var oauthErr *entity.OAuthError
+	require.True(t, errors.As(exc.Error, &oauthErr))
+	return oauthErr.Code
+}
+
+func TestRegisterOAuthClient(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("RegisterOAuthClient Confidential Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.OAuthClientRequest{
+			Name:         "billing-portal",
+			RedirectURIs: []string{"https://billing.example.com/callback"},
+			GrantTypes:   []string{entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken},
+			Scopes:       []string{entity.ScopeOpenID, entity.PermissionUsersRead},
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(client *entity.OAuthClient) bool {
+			return client.Name == request.Name && client.SecretHash != "" && !client.Public
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.RegisterClient(mockAppCtx, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.NotEmpty(t, result.Id)
+		assert.Equal(t, signature.HashToken(result.ClientSecret), result.SecretHash)
+		mockOAuthRepository.AssertExpectations(t)
+	})
+
+	t.Run("RegisterOAuthClient Public Client Credentials", func(t *testing.T) {
+		// Set up input
+		request := &entity.OAuthClientRequest{
+			Name:       "mobile-app",
+			Public:     true,
+			GrantTypes: []string{entity.GrantTypeClientCredentials},
+			Scopes:     []string{entity.PermissionUsersRead},
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.RegisterClient(mockAppCtx, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Nil(t, result)
+		mockOAuthRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RegisterOAuthClient Unknown Scope", func(t *testing.T) {
+		// Set up input
+		request := &entity.OAuthClientRequest{
+			Name:         "billing-portal",
+			RedirectURIs: []string{"https://billing.example.com/callback"},
+			GrantTypes:   []string{entity.GrantTypeAuthorizationCode},
+			Scopes:       []string{"everything:all"},
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), new(mocks.OAuthRepository),
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.RegisterClient(mockAppCtx, request)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	mockAppCtx := context.Background()
+	client := &entity.OAuthClient{
+		Id:           "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Name:         "billing-portal",
+		RedirectURIs: []string{"https://billing.example.com/callback"},
+		GrantTypes:   []string{entity.GrantTypeAuthorizationCode},
+		Scopes:       []string{entity.ScopeOpenID, entity.PermissionUsersRead},
+	}
+	request := func() *entity.AuthorizeRequest {
+		return &entity.AuthorizeRequest{
+			ResponseType:        "code",
+			ClientId:            client.Id,
+			RedirectURI:         "https://billing.example.com/callback",
+			Scope:               "openid users:read",
+			State:               "af0ifjsldkj",
+			CodeChallenge:       pkceChallenge,
+			CodeChallengeMethod: entity.PKCEMethodS256,
+			Nonce:               "n-0S6_WzA2Mj",
+		}
+	}
+
+	t.Run("Authorize Redirects To Consent Page", func(t *testing.T) {
+		// Set up input
+		model := request()
+		model.RedirectURI, model.Scope = "", ""
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Authorize(mockAppCtx, model)
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, "id.example.com", redirect.Host)
+		assert.Equal(t, "/oauth/consent", redirect.Path)
+		assert.Equal(t, "billing-portal", redirect.Query().Get("client_name"))
+		// Left out as the client did, Consent resolves it the same way
+		assert.Empty(t, redirect.Query().Get("redirect_uri"))
+		assert.Equal(t, "openid users:read", redirect.Query().Get("scope"))
+		assert.Equal(t, pkceChallenge, redirect.Query().Get("code_challenge"))
+		assert.Equal(t, "af0ifjsldkj", redirect.Query().Get("state"))
+		assert.Empty(t, redirect.Query().Get("code"))
+		mockOAuthRepository.AssertNotCalled(t, "CreateCodeTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Authorize Unregistered Redirect URI", func(t *testing.T) {
+		// Set up input
+		model := request()
+		model.RedirectURI = "https://attacker.example.com/callback"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Authorize(mockAppCtx, model)
+
+		// Assert the result
+		assert.Empty(t, location)
+		assert.Equal(t, entity.OAuthInvalidRequest, oauthErrorCode(t, errService))
+	})
+
+	t.Run("Authorize Without PKCE", func(t *testing.T) {
+		// Set up input
+		model := request()
+		model.CodeChallenge, model.CodeChallengeMethod = "", ""
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Authorize(mockAppCtx, model)
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, "billing.example.com", redirect.Host)
+		assert.Equal(t, entity.OAuthInvalidRequest, redirect.Query().Get("error"))
+		assert.Equal(t, "af0ifjsldkj", redirect.Query().Get("state"))
+	})
+
+	t.Run("Authorize Scope Not Allowed", func(t *testing.T) {
+		// Set up input
+		model := request()
+		model.Scope = "openid users:delete"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Authorize(mockAppCtx, model)
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, entity.OAuthInvalidScope, redirect.Query().Get("error"))
+	})
+}
+
+func TestOAuthConsent(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Username: "john_doe",
+		Subject:  "123e4567-e89b-12d3-a456-426614174000",
+		IssuedAt: time.Now().Add(-time.Minute),
+	}
+	client := &entity.OAuthClient{
+		Id:           "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Name:         "billing-portal",
+		RedirectURIs: []string{"https://billing.example.com/callback"},
+		GrantTypes:   []string{entity.GrantTypeAuthorizationCode},
+		Scopes:       []string{entity.ScopeOpenID, entity.PermissionUsersRead},
+	}
+	request := func(approve bool) *entity.OAuthConsentRequest {
+		return &entity.OAuthConsentRequest{
+			AuthorizeRequest: entity.AuthorizeRequest{
+				ResponseType:        "code",
+				ClientId:            client.Id,
+				RedirectURI:         "https://billing.example.com/callback",
+				Scope:               "openid users:read",
+				State:               "af0ifjsldkj",
+				CodeChallenge:       pkceChallenge,
+				CodeChallengeMethod: entity.PKCEMethodS256,
+				Nonce:               "n-0S6_WzA2Mj",
+			},
+			Approve: approve,
+		}
+	}
+
+	t.Run("Consent Approved", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("CreateCodeTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(code *entity.OAuthAuthorizationCode) bool {
+			return code.UserId == auth.Subject && code.ClientId == client.Id &&
+				code.CodeChallenge == pkceChallenge && code.Nonce == "n-0S6_WzA2Mj" && code.RedirectURIGiven
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		location, errService := mockService.Consent(mockAppCtx, auth, request(true))
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, "billing.example.com", redirect.Host)
+		assert.NotEmpty(t, redirect.Query().Get("code"))
+		assert.Equal(t, "af0ifjsldkj", redirect.Query().Get("state"))
+		mockOAuthRepository.AssertExpectations(t)
+	})
+
+	t.Run("Consent Default Redirect URI", func(t *testing.T) {
+		// Set up input
+		model := request(true)
+		model.RedirectURI = ""
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("CreateCodeTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(code *entity.OAuthAuthorizationCode) bool {
+			return code.RedirectURI == "https://billing.example.com/callback" && !code.RedirectURIGiven
+		})).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		location, errService := mockService.Consent(mockAppCtx, auth, model)
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, "billing.example.com", redirect.Host)
+		assert.NotEmpty(t, redirect.Query().Get("code"))
+		mockOAuthRepository.AssertExpectations(t)
+	})
+
+	t.Run("Consent Denied", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Consent(mockAppCtx, auth, request(false))
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, entity.OAuthAccessDenied, redirect.Query().Get("error"))
+		assert.Equal(t, "af0ifjsldkj", redirect.Query().Get("state"))
+		assert.Empty(t, redirect.Query().Get("code"))
+		mockOAuthRepository.AssertNotCalled(t, "CreateCodeTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Consent Scope Not Allowed", func(t *testing.T) {
+		// Set up input
+		model := request(true)
+		model.Scope = "openid users:delete"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		location, errService := mockService.Consent(mockAppCtx, auth, model)
+
+		// Assert the result
+		require.Nil(t, errService)
+		redirect, err := url.Parse(location)
+		require.NoError(t, err)
+		assert.Equal(t, entity.OAuthInvalidScope, redirect.Query().Get("error"))
+		mockOAuthRepository.AssertNotCalled(t, "CreateCodeTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOAuthToken(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+		Email:    "john_doe@example.com",
+		Roles:    []string{entity.RoleUser},
+	}
+	clientSecret := "s3cr3t-s3cr3t-s3cr3t"
+	client := &entity.OAuthClient{
+		Id:           "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Name:         "billing-portal",
+		SecretHash:   signature.HashToken(clientSecret),
+		RedirectURIs: []string{"https://billing.example.com/callback"},
+		GrantTypes:   []string{entity.GrantTypeAuthorizationCode, entity.GrantTypeRefreshToken, entity.GrantTypeClientCredentials},
+		Scopes:       []string{entity.ScopeOpenID, entity.ScopeEmail, entity.PermissionUsersRead, entity.PermissionUsersDelete},
+	}
+	newCode := func() *entity.OAuthAuthorizationCode {
+		return &entity.OAuthAuthorizationCode{
+			Id:               "3d8f1c2e-5b6a-4c7d-8e9f-0a1b2c3d4e5f",
+			CodeHash:         signature.HashToken("auth_code"),
+			ClientId:         client.Id,
+			UserId:           user.Id,
+			RedirectURI:      "https://billing.example.com/callback",
+			RedirectURIGiven: true,
+			Scopes:           []string{entity.ScopeOpenID, entity.ScopeEmail, entity.PermissionUsersRead, entity.PermissionUsersDelete},
+			CodeChallenge:    pkceChallenge,
+			Nonce:            "n-0S6_WzA2Mj",
+			AuthTime:         time.Now().Add(-time.Minute),
+			ExpiresAt:        time.Now().Add(time.Minute),
+		}
+	}
+	codeRequest := func() *entity.OAuthTokenRequest {
+		return &entity.OAuthTokenRequest{
+			GrantType:    entity.GrantTypeAuthorizationCode,
+			Code:         "auth_code",
+			RedirectURI:  "https://billing.example.com/callback",
+			CodeVerifier: pkceVerifier,
+			ClientId:     client.Id,
+			ClientSecret: clientSecret,
+		}
+	}
+
+	t.Run("OAuthToken Authorization Code Success", func(t *testing.T) {
+		code := newCode()
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+		mockOAuthRepository.On("ConsumeCodeTx", mockAppCtx, mock.Anything, code.Id, mock.Anything).Return(true, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			return token.ClientId == client.Id && token.FamilyId == code.Id && len(token.Scopes) == 3
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			// users:delete is dropped, the user doesn't hold it
+			return claims.Subject == user.Id && claims.ClientID == client.Id &&
+				claims.Scope == "openid email users:read" &&
+				assert.ObjectsAreEqual([]string{entity.PermissionUsersRead}, claims.Permissions)
+		})).Return("access_token", nil)
+		mockSignaturer.On("GenerateIDToken", mock.MatchedBy(func(claims signature.IDTokenClaims) bool {
+			return claims.Subject == user.Id && claims.Audience[0] == client.Id &&
+				claims.Nonce == "n-0S6_WzA2Mj" && claims.Email == user.Email && claims.AuthTime != nil
+		})).Return("id_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, mockOAuthRepository,
+			mockRefreshTokenRepository, mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Token(mockAppCtx, codeRequest())
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "access_token", result.AccessToken)
+		assert.Equal(t, "Bearer", result.TokenType)
+		assert.Equal(t, int64(3600), result.ExpiresIn)
+		assert.Equal(t, "id_token", result.IdToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		mockSignaturer.AssertExpectations(t)
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("OAuthToken Wrong Code Verifier", func(t *testing.T) {
+		code := newCode()
+		request := codeRequest()
+		request.CodeVerifier = "a-different-verifier-that-is-long-enough-to-pass"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+		mockOAuthRepository.AssertNotCalled(t, "ConsumeCodeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OAuthToken Redirect URI Missing", func(t *testing.T) {
+		code := newCode()
+		request := codeRequest()
+		request.RedirectURI = ""
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+		mockOAuthRepository.AssertNotCalled(t, "ConsumeCodeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OAuthToken Default Redirect URI Omitted", func(t *testing.T) {
+		code := newCode()
+		code.RedirectURIGiven = false
+		request := codeRequest()
+		request.RedirectURI = ""
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+		mockOAuthRepository.On("ConsumeCodeTx", mockAppCtx, mock.Anything, code.Id, mock.Anything).Return(true, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.Anything).Return("access_token", nil)
+		mockSignaturer.On("GenerateIDToken", mock.Anything).Return("id_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, mockOAuthRepository,
+			mockRefreshTokenRepository, mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "access_token", result.AccessToken)
+		mockOAuthRepository.AssertExpectations(t)
+	})
+
+	t.Run("OAuthToken Authorization Code Of Suspended User", func(t *testing.T) {
+		code := newCode()
+		suspended := &entity.User{Id: user.Id, Username: user.Username, Status: entity.UserStatusSuspended}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(suspended, nil)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, codeRequest())
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+		mockOAuthRepository.AssertNotCalled(t, "ConsumeCodeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockSignaturer.AssertNotCalled(t, "GenerateJWT", mock.Anything)
+		mockSignaturer.AssertNotCalled(t, "GenerateIDToken", mock.Anything)
+	})
+
+	t.Run("OAuthToken Authorization Code Of Deleted User", func(t *testing.T) {
+		code := newCode()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(nil, nil)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, codeRequest())
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+		mockSignaturer.AssertNotCalled(t, "GenerateIDToken", mock.Anything)
+	})
+
+	t.Run("OAuthToken Code Reuse Revokes Issued Tokens", func(t *testing.T) {
+		code := newCode()
+		consumedAt := time.Now().Add(-time.Second)
+		code.ConsumedAt = &consumedAt
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockOAuthRepository.On("FindCodeByHash", mockAppCtx, mock.Anything, code.CodeHash).Return(code, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeFamilyTx", mockAppCtx, mock.Anything, code.Id, mock.Anything).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			mockRefreshTokenRepository, new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Token(mockAppCtx, codeRequest())
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("OAuthToken Invalid Client Secret", func(t *testing.T) {
+		request := codeRequest()
+		request.ClientSecret = "wrong"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidClient, oauthErrorCode(t, errService))
+		assert.Equal(t, 401, errService.GetHttpCode())
+	})
+
+	t.Run("OAuthToken Refresh Token Success", func(t *testing.T) {
+		request := &entity.OAuthTokenRequest{
+			GrantType:    entity.GrantTypeRefreshToken,
+			RefreshToken: "client_refresh_token",
+			Scope:        entity.PermissionUsersRead,
+			ClientId:     client.Id,
+			ClientSecret: clientSecret,
+		}
+		current := &entity.RefreshToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    user.Id,
+			FamilyId:  "3d8f1c2e-5b6a-4c7d-8e9f-0a1b2c3d4e5f",
+			ClientId:  client.Id,
+			Scopes:    []string{entity.ScopeOpenID, entity.PermissionUsersRead},
+			TokenHash: signature.HashToken(request.RefreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			return token.FamilyId == current.FamilyId && token.ClientId == client.Id && len(token.Scopes) == 2
+		})).Return(nil)
+		mockRefreshTokenRepository.On("MarkRotatedTx", mockAppCtx, mock.Anything, current.Id, mock.Anything, mock.Anything).Return(true, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			return claims.Scope == entity.PermissionUsersRead
+		})).Return("access_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, mockOAuthRepository,
+			mockRefreshTokenRepository, mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.Empty(t, result.IdToken)
+		mockSignaturer.AssertNotCalled(t, "GenerateIDToken", mock.Anything)
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("OAuthToken Refresh Token Of Another Client", func(t *testing.T) {
+		request := &entity.OAuthTokenRequest{
+			GrantType:    entity.GrantTypeRefreshToken,
+			RefreshToken: "first_party_refresh_token",
+			ClientId:     client.Id,
+			ClientSecret: clientSecret,
+		}
+		current := &entity.RefreshToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    user.Id,
+			FamilyId:  "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+			TokenHash: signature.HashToken(request.RefreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			mockRefreshTokenRepository, new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthInvalidGrant, oauthErrorCode(t, errService))
+	})
+
+	t.Run("OAuthToken Client Credentials Success", func(t *testing.T) {
+		request := &entity.OAuthTokenRequest{
+			GrantType:    entity.GrantTypeClientCredentials,
+			Scope:        entity.PermissionUsersDelete,
+			ClientId:     client.Id,
+			ClientSecret: clientSecret,
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			return claims.Subject == client.Id && claims.ClientID == client.Id &&
+				assert.ObjectsAreEqual([]string{entity.PermissionUsersDelete}, claims.Permissions)
+		})).Return("access_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), mockSignaturer, validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, "access_token", result.AccessToken)
+		assert.Empty(t, result.RefreshToken)
+		assert.Empty(t, result.IdToken)
+	})
+
+	t.Run("OAuthToken Unsupported Grant Type", func(t *testing.T) {
+		request := &entity.OAuthTokenRequest{
+			GrantType:    "password",
+			ClientId:     client.Id,
+			ClientSecret: clientSecret,
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockOAuthRepository := new(mocks.OAuthRepository)
+		mockOAuthRepository.On("FindByID", mockAppCtx, mock.Anything, client.Id).Return(client, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, new(mocks.UserRepository), mockOAuthRepository,
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.Token(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, entity.OAuthUnsupportedGrantType, oauthErrorCode(t, errService))
+	})
+}
+
+func TestOAuthUserInfo(t *testing.T) {
+	mockAppCtx := context.Background()
+	verifiedAt := time.Now().Add(-24 * time.Hour)
+	user := &entity.User{
+		Id:              "123e4567-e89b-12d3-a456-426614174000",
+		Username:        "john_doe",
+		Email:           "john_doe@example.com",
+		EmailVerifiedAt: &verifiedAt,
+	}
+
+	t.Run("UserInfo Success", func(t *testing.T) {
+		auth := &signature.JwtAuthenticationRes{Subject: user.Id, Scope: "openid email"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, new(mocks.OAuthRepository),
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.UserInfo(mockAppCtx, auth)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, user.Id, result.Subject)
+		assert.Equal(t, user.Email, result.Email)
+		assert.True(t, *result.EmailVerified)
+		assert.Empty(t, result.PreferredUsername)
+	})
+
+	t.Run("UserInfo Without OpenID Scope", func(t *testing.T) {
+		auth := &signature.JwtAuthenticationRes{Subject: user.Id}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewOAuthService(gormDB, mockUserRepository, new(mocks.OAuthRepository),
+			new(mocks.RefreshTokenRepository), new(mocksSignature.Signaturer), validate, oauthConfig)
+
+		// Call the function under test
+		result, errService := mockService.UserInfo(mockAppCtx, auth)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+	})
+}
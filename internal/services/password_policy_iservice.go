@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+type PasswordPolicyService interface {
+	// Validate checks a new password for user against the policy, including the
+	// user's recent passwords. Every broken rule is listed in one InvalidArgument
+	// exception whose message maps "password" to []xvalidator.PasswordViolation.
+	Validate(ctx context.Context, tx *gorm.DB, user *entity.User, password string) *exception.Exception
+	// RecordTx adds the user's current password hash to their history and drops
+	// entries beyond the configured depth
+	RecordTx(ctx context.Context, tx *gorm.DB, user *entity.User) *exception.Exception
+	// Expired reports whether the user's password is older than the configured maximum age
+	Expired(user *entity.User, now time.Time) bool
+	// ChangeRequired answers a login with an expired password. No tokens are
+	// issued; the returned password_change_token redeems at /auth/change-expired-password.
+	ChangeRequired(ctx context.Context, user *entity.User) (*UserLoginResponse, *exception.Exception)
+}
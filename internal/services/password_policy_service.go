@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+// PasswordPolicyConfig sets the rules for new passwords. A zero History or
+// MaxAge turns that check off.
+type PasswordPolicyConfig struct {
+	Policy *xvalidator.PasswordPolicy
+	// History is how many of the user's latest passwords can't be reused, the current one included
+	History int
+	// MaxAge is how long a password lasts before login asks for a new one
+	MaxAge time.Duration
+	// ChangeTTL bounds how long the password_change_token of an expired login stays valid
+	ChangeTTL time.Duration
+}
+
+type PasswordPolicyServiceImpl struct {
+	db            *gorm.DB
+	historyRepo   repository.PasswordHistoryRepository
+	userTokenRepo repository.UserTokenRepository
+	signaturer    signature.Signaturer
+	conf          *PasswordPolicyConfig
+}
+
+func NewPasswordPolicyService(
+	db *gorm.DB, historyRepo repository.PasswordHistoryRepository,
+	userTokenRepo repository.UserTokenRepository,
+	signaturer signature.Signaturer,
+	conf *PasswordPolicyConfig,
+) PasswordPolicyService {
+	return &PasswordPolicyServiceImpl{
+		db:            db,
+		historyRepo:   historyRepo,
+		userTokenRepo: userTokenRepo,
+		signaturer:    signaturer,
+		conf:          conf,
+	}
+}
+
+func (s *PasswordPolicyServiceImpl) Validate(
+	ctx context.Context, tx *gorm.DB, user *entity.User, password string,
+) *exception.Exception {
+	violations := s.conf.Policy.Check(password, user.Username, user.Email)
+	reused, exc := s.reused(ctx, tx, user, password)
+	if exc != nil {
+		return exc
+	}
+	if reused {
+		violations = append(violations, xvalidator.PasswordViolation{
+			Rule:    xvalidator.PasswordRuleReused,
+			Message: "must not be one of your recent passwords",
+		})
+	}
+	if len(violations) > 0 {
+		return exception.InvalidArgument(map[string][]xvalidator.PasswordViolation{"password": violations})
+	}
+	return nil
+}
+
+func (s *PasswordPolicyServiceImpl) RecordTx(ctx context.Context, tx *gorm.DB, user *entity.User) *exception.Exception {
+	if s.conf.History <= 0 {
+		return nil
+	}
+	if err := s.historyRepo.CreateTx(ctx, tx, &entity.PasswordHistory{
+		Id:           uuid.NewString(),
+		UserId:       user.Id,
+		PasswordHash: user.Password,
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := s.historyRepo.PruneTx(ctx, tx, user.Id, s.conf.History); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+func (s *PasswordPolicyServiceImpl) Expired(user *entity.User, now time.Time) bool {
+	if s.conf.MaxAge <= 0 || user.PasswordChangedAt == nil {
+		return false
+	}
+	return now.After(user.PasswordChangedAt.Add(s.conf.MaxAge))
+}
+
+func (s *PasswordPolicyServiceImpl) ChangeRequired(ctx context.Context, user *entity.User) (
+	*UserLoginResponse, *exception.Exception,
+) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	token, exc := issueUserToken(ctx, s.userTokenRepo, tx, user.Id, entity.TokenPurposePasswordChange, s.conf.ChangeTTL)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &UserLoginResponse{
+		Username:               user.Username,
+		Email:                  user.Email,
+		PasswordChangeRequired: true,
+		PasswordChangeToken:    token,
+	}, nil
+}
+
+// reused compares password with the user's current hash and their history.
+// Accounts from before history was kept only have the current hash to go by.
+func (s *PasswordPolicyServiceImpl) reused(
+	ctx context.Context, tx *gorm.DB, user *entity.User, password string,
+) (bool, *exception.Exception) {
+	if s.conf.History <= 0 || user.Password == "" {
+		return false, nil
+	}
+	history, err := s.historyRepo.FindRecentByUser(ctx, tx, user.Id, s.conf.History)
+	if err != nil {
+		return false, exception.Internal("err", err)
+	}
+	hashes := make([]string, 0, len(history)+1)
+	if len(history) == 0 || history[0].PasswordHash != user.Password {
+		hashes = append(hashes, user.Password)
+	}
+	for _, entry := range history {
+		hashes = append(hashes, entry.PasswordHash)
+	}
+	if len(hashes) > s.conf.History {
+		hashes = hashes[:s.conf.History]
+	}
+	for _, hash := range hashes {
+		if ok, _ := s.signaturer.CheckPasswordHash(password, hash); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
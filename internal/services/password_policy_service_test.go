@@ -0,0 +1,230 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+func newPasswordPolicyConfig() *service.PasswordPolicyConfig {
+	return &service.PasswordPolicyConfig{
+		Policy: &xvalidator.PasswordPolicy{
+			MinLength:      10,
+			RequireUpper:   true,
+			RequireLower:   true,
+			RequireDigit:   true,
+			RequireSymbol:  true,
+			MaxRepeated:    2,
+			RejectUserInfo: true,
+		},
+		History:   3,
+		MaxAge:    90 * 24 * time.Hour,
+		ChangeTTL: 10 * time.Minute,
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+		Email:    "johnny@example.com",
+		Password: "current_hash",
+	}
+
+	t.Run("ValidatePassword Success", func(t *testing.T) {
+		password := "Correct-Horse7"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockHistoryRepository.On("FindRecentByUser", mockAppCtx, mock.Anything, user.Id, 3).Return([]*entity.PasswordHistory{
+			{PasswordHash: "current_hash"}, {PasswordHash: "older_hash"},
+		}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", password, mock.Anything).Return(false, false)
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, newPasswordPolicyConfig())
+
+		// Call the function under test
+		errService := mockService.Validate(mockAppCtx, gormDB, user, password)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockSignaturer.AssertNumberOfCalls(t, "CheckPasswordHash", 2)
+	})
+
+	t.Run("ValidatePassword Lists Every Violation", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockHistoryRepository.On("FindRecentByUser", mockAppCtx, mock.Anything, user.Id, 3).Return(nil, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", mock.Anything, "current_hash").Return(false, false)
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, newPasswordPolicyConfig())
+
+		// Call the function under test
+		errService := mockService.Validate(mockAppCtx, gormDB, user, "johnnyyy")
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		message, ok := errService.Message.(map[string][]xvalidator.PasswordViolation)
+		require.True(t, ok)
+		var rules []string
+		for _, violation := range message["password"] {
+			rules = append(rules, violation.Rule)
+		}
+		assert.Equal(t, []string{
+			xvalidator.PasswordRuleMinLength,
+			xvalidator.PasswordRuleUppercase,
+			xvalidator.PasswordRuleDigit,
+			xvalidator.PasswordRuleSymbol,
+			xvalidator.PasswordRuleMaxRepeated,
+			xvalidator.PasswordRuleUserInfo,
+		}, rules)
+	})
+
+	t.Run("ValidatePassword Reused", func(t *testing.T) {
+		password := "Correct-Horse7"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockHistoryRepository.On("FindRecentByUser", mockAppCtx, mock.Anything, user.Id, 3).Return([]*entity.PasswordHistory{
+			{PasswordHash: "current_hash"}, {PasswordHash: "older_hash"},
+		}, nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", password, "current_hash").Return(false, false)
+		mockSignaturer.On("CheckPasswordHash", password, "older_hash").Return(true, false)
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, newPasswordPolicyConfig())
+
+		// Call the function under test
+		errService := mockService.Validate(mockAppCtx, gormDB, user, password)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, map[string][]xvalidator.PasswordViolation{"password": {
+			{Rule: xvalidator.PasswordRuleReused, Message: "must not be one of your recent passwords"},
+		}}, errService.Message)
+	})
+
+	t.Run("ValidatePassword New User Skips History", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, newPasswordPolicyConfig())
+
+		// Call the function under test
+		errService := mockService.Validate(mockAppCtx, gormDB, &entity.User{Username: "john_doe"}, "Correct-Horse7")
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockHistoryRepository.AssertNotCalled(t, "FindRecentByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRecordPassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Password: "new_hash"}
+
+	t.Run("RecordPassword Prunes History", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockHistoryRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(entry *entity.PasswordHistory) bool {
+			return entry.UserId == user.Id && entry.PasswordHash == user.Password
+		})).Return(nil)
+		mockHistoryRepository.On("PruneTx", mockAppCtx, mock.Anything, user.Id, 3).Return(nil)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, newPasswordPolicyConfig())
+
+		// Call the function under test
+		errService := mockService.RecordTx(mockAppCtx, gormDB, user)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockHistoryRepository.AssertExpectations(t)
+	})
+
+	t.Run("RecordPassword History Disabled", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockHistoryRepository := new(mocks.PasswordHistoryRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		conf := newPasswordPolicyConfig()
+		conf.History = 0
+
+		mockService := service.NewPasswordPolicyService(gormDB, mockHistoryRepository, mockUserTokenRepository, mockSignaturer, conf)
+
+		// Call the function under test
+		errService := mockService.RecordTx(mockAppCtx, gormDB, user)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockHistoryRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordExpiry(t *testing.T) {
+	mockAppCtx := context.Background()
+	now := time.Now()
+	recent := now.AddDate(0, 0, -10)
+	old := now.AddDate(0, 0, -100)
+
+	t.Run("PasswordExpired", func(t *testing.T) {
+		_, gormDB := setupSQLMock(t)
+		mockService := service.NewPasswordPolicyService(gormDB, new(mocks.PasswordHistoryRepository), new(mocks.UserTokenRepository), new(mocksSignature.Signaturer), newPasswordPolicyConfig())
+
+		assert.False(t, mockService.Expired(&entity.User{PasswordChangedAt: &recent}, now))
+		assert.True(t, mockService.Expired(&entity.User{PasswordChangedAt: &old}, now))
+		// Accounts from before the change time was tracked don't expire
+		assert.False(t, mockService.Expired(&entity.User{}, now))
+	})
+
+	t.Run("PasswordChangeRequired Issues Token", func(t *testing.T) {
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "john_doe", PasswordChangedAt: &old}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("InvalidateTx", mockAppCtx, mock.Anything, user.Id, entity.TokenPurposePasswordChange, mock.Anything).Return(nil)
+		mockUserTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.UserToken) bool {
+			return token.UserId == user.Id && token.Purpose == entity.TokenPurposePasswordChange
+		})).Return(nil)
+
+		mockService := service.NewPasswordPolicyService(gormDB, new(mocks.PasswordHistoryRepository), mockUserTokenRepository, new(mocksSignature.Signaturer), newPasswordPolicyConfig())
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.ChangeRequired(mockAppCtx, user)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.True(t, result.PasswordChangeRequired)
+		assert.NotEmpty(t, result.PasswordChangeToken)
+		assert.Empty(t, result.Token)
+		mockUserTokenRepository.AssertExpectations(t)
+	})
+}
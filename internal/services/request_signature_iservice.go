@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+)
+
+// RequestSignatureService checks the HMAC signatures partners put on
+// server-to-server requests.
+type RequestSignatureService interface {
+	// Verify accepts a request signed by a known client within the allowed
+	// clock skew, whose nonce the client has not used before
+	Verify(ctx context.Context, req *entity.SignedRequest) *exception.Exception
+	// Sign computes the signature the server expects for req. Only sandbox
+	// clients may use it, so it can't serve as a signing oracle in production.
+	Sign(ctx context.Context, req *entity.SignedRequest) (*entity.RequestSignature, *exception.Exception)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+// SignatureClient is a partner allowed to send signed requests. Sandbox
+// clients may also ask the server to compute signatures for them.
+type SignatureClient struct {
+	ID      string
+	Secret  string
+	Sandbox bool
+}
+
+// RequestSignatureConfig lists the partners and how far a request timestamp
+// may drift from the server clock.
+type RequestSignatureConfig struct {
+	Clients []*SignatureClient
+	MaxSkew time.Duration
+}
+
+type RequestSignatureServiceImpl struct {
+	nonceRepo repository.RequestNonceRepository
+	validate  *xvalidator.Validator
+	clients   map[string]*SignatureClient
+	maxSkew   time.Duration
+}
+
+func NewRequestSignatureService(
+	nonceRepo repository.RequestNonceRepository,
+	validate *xvalidator.Validator,
+	conf *RequestSignatureConfig,
+) RequestSignatureService {
+	clients := make(map[string]*SignatureClient, len(conf.Clients))
+	for _, client := range conf.Clients {
+		clients[client.ID] = client
+	}
+	return &RequestSignatureServiceImpl{
+		nonceRepo: nonceRepo,
+		validate:  validate,
+		clients:   clients,
+		maxSkew:   conf.MaxSkew,
+	}
+}
+
+func (s *RequestSignatureServiceImpl) Verify(ctx context.Context, req *entity.SignedRequest) *exception.Exception {
+	if errs := s.validate.Struct(req); errs != nil {
+		return exception.Unauthenticated(errs)
+	}
+	client, ok := s.clients[req.ClientID]
+	if !ok {
+		return exception.Unauthenticated("invalid signature")
+	}
+	signedAt, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return exception.Unauthenticated("invalid timestamp")
+	}
+	now := time.Now()
+	issued := time.Unix(signedAt, 0)
+	if issued.Before(now.Add(-s.maxSkew)) || issued.After(now.Add(s.maxSkew)) {
+		return exception.Unauthenticated("request timestamp is outside the allowed window")
+	}
+	stringToSign := signature.RequestStringToSign(req.Method, req.Path, req.Timestamp, req.Nonce, req.Body)
+	if !signature.VerifyRequestSignature(client.Secret, stringToSign, req.Signature) {
+		return exception.Unauthenticated("invalid signature")
+	}
+	// Only remember the nonce of a correctly signed request, so anyone can't
+	// burn a partner's nonces. It must outlive the timestamp window.
+	fresh, err := s.nonceRepo.Remember(ctx, client.ID, req.Nonce, issued.Add(s.maxSkew))
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if !fresh {
+		return exception.Unauthenticated("nonce has already been used")
+	}
+	return nil
+}
+
+func (s *RequestSignatureServiceImpl) Sign(
+	_ context.Context, req *entity.SignedRequest,
+) (*entity.RequestSignature, *exception.Exception) {
+	if errs := s.validate.Struct(req); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	client, ok := s.clients[req.ClientID]
+	if !ok || !client.Sandbox {
+		return nil, exception.PermissionDenied("signatures can only be computed for sandbox clients")
+	}
+	stringToSign := signature.RequestStringToSign(req.Method, req.Path, req.Timestamp, req.Nonce, req.Body)
+	return &entity.RequestSignature{
+		Signature:    signature.SignRequest(client.Secret, stringToSign),
+		StringToSign: stringToSign,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const partnerSecret = "0123456789abcdef0123456789abcdef"
+
+var signatureConfig = &service.RequestSignatureConfig{
+	Clients: []*service.SignatureClient{
+		{ID: "acme", Secret: partnerSecret},
+		{ID: "sandbox", Secret: partnerSecret, Sandbox: true},
+	},
+	MaxSkew: 5 * time.Minute,
+}
+
+func signedRequest(clientID string, at time.Time) *entity.SignedRequest {
+	req := &entity.SignedRequest{
+		ClientID:  clientID,
+		Timestamp: strconv.FormatInt(at.Unix(), 10),
+		Nonce:     "5f1c2e9a",
+		Method:    "POST",
+		Path:      "/signature/verify?debug=1",
+		Body:      []byte(`{"order_id":42}`),
+	}
+	req.Signature = signature.SignRequest(partnerSecret,
+		signature.RequestStringToSign(req.Method, req.Path, req.Timestamp, req.Nonce, req.Body))
+	return req
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("VerifyRequestSignature Success", func(t *testing.T) {
+		req := signedRequest("acme", time.Now())
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+		mockNonceRepository.On("Remember", mockAppCtx, "acme", req.Nonce, mock.Anything).Return(true, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		errService := mockService.Verify(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockNonceRepository.AssertExpectations(t)
+	})
+
+	t.Run("VerifyRequestSignature Tampered Body", func(t *testing.T) {
+		req := signedRequest("acme", time.Now())
+		req.Body = []byte(`{"order_id":43}`)
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		errService := mockService.Verify(mockAppCtx, req)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		mockNonceRepository.AssertNotCalled(t, "Remember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyRequestSignature Stale Timestamp", func(t *testing.T) {
+		req := signedRequest("acme", time.Now().Add(-10*time.Minute))
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		errService := mockService.Verify(mockAppCtx, req)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, "request timestamp is outside the allowed window", errService.Message)
+		mockNonceRepository.AssertNotCalled(t, "Remember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("VerifyRequestSignature Replayed Nonce", func(t *testing.T) {
+		req := signedRequest("acme", time.Now())
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+		mockNonceRepository.On("Remember", mockAppCtx, "acme", req.Nonce, mock.Anything).Return(false, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		errService := mockService.Verify(mockAppCtx, req)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, "nonce has already been used", errService.Message)
+	})
+
+	t.Run("VerifyRequestSignature Unknown Client", func(t *testing.T) {
+		req := signedRequest("globex", time.Now())
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		errService := mockService.Verify(mockAppCtx, req)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+}
+
+func TestSignRequest(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("SignRequest Sandbox Client", func(t *testing.T) {
+		req := signedRequest("sandbox", time.Now())
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		result, errService := mockService.Sign(mockAppCtx, req)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, req.Signature, result.Signature)
+		assert.Contains(t, result.StringToSign, "POST\n/signature/verify?debug=1\n")
+	})
+
+	t.Run("SignRequest Production Client", func(t *testing.T) {
+		req := signedRequest("acme", time.Now())
+
+		// Mocks
+		mockNonceRepository := new(mocks.RequestNonceRepository)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewRequestSignatureService(mockNonceRepository, validate, signatureConfig)
+
+		// Call the function under test
+		result, errService := mockService.Sign(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+	})
+}
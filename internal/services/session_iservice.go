@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+// SessionService lets users see where they are signed in and end sessions.
+// auth is the caller's authentication, which names the current session.
+type SessionService interface {
+	// List returns the caller's active sessions, most recently seen first
+	List(ctx context.Context, auth *signature.JwtAuthenticationRes) ([]*entity.Session, *exception.Exception)
+	// Revoke ends one of the caller's sessions, along with its tokens
+	Revoke(ctx context.Context, auth *signature.JwtAuthenticationRes, id string) *exception.Exception
+	// RevokeOthers ends every session of the caller except the current one
+	RevokeOthers(ctx context.Context, auth *signature.JwtAuthenticationRes) *exception.Exception
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+type SessionServiceImpl struct {
+	db               *gorm.DB
+	sessionRepo      repository.SessionRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+func NewSessionService(
+	db *gorm.DB, sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+) SessionService {
+	return &SessionServiceImpl{
+		db:               db,
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+func (s *SessionServiceImpl) List(
+	ctx context.Context, auth *signature.JwtAuthenticationRes,
+) ([]*entity.Session, *exception.Exception) {
+	data, err := s.sessionRepo.FindActiveByUser(ctx, s.db, auth.Subject, time.Now())
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	for _, session := range data {
+		session.Current = session.Id == auth.SessionID
+	}
+	return data, nil
+}
+
+func (s *SessionServiceImpl) Revoke(
+	ctx context.Context, auth *signature.JwtAuthenticationRes, id string,
+) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid session id, must be uuid")
+	}
+	now := time.Now()
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	revoked, err := s.sessionRepo.RevokeTx(ctx, tx, id, auth.Subject, now)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if !revoked {
+		return exception.NotFound("session not found")
+	}
+	if err := s.refreshTokenRepo.RevokeFamilyTx(ctx, tx, id, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *SessionServiceImpl) RevokeOthers(ctx context.Context, auth *signature.JwtAuthenticationRes) *exception.Exception {
+	if auth.SessionID == "" {
+		return exception.InvalidArgument("token is not bound to a session")
+	}
+	now := time.Now()
+	sessions, err := s.sessionRepo.FindActiveByUser(ctx, s.db, auth.Subject, now)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Id != auth.SessionID {
+			ids = append(ids, session.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.sessionRepo.RevokeManyTx(ctx, tx, ids, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := s.refreshTokenRepo.RevokeFamiliesTx(ctx, tx, ids, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+func TestListSessions(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		SessionID: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+	}
+
+	t.Run("ListSessions Marks Current", func(t *testing.T) {
+		sessions := []*entity.Session{
+			{Id: auth.SessionID, UserId: auth.Subject},
+			{Id: "5d41402a-bc4b-4a76-b971-9d911017c592", UserId: auth.Subject},
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("FindActiveByUser", mockAppCtx, mock.Anything, auth.Subject, mock.Anything).Return(sessions, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, auth)
+
+		// Assert the result
+		require.Nil(t, errService)
+		require.Len(t, result, 2)
+		assert.True(t, result[0].Current)
+		assert.False(t, result[1].Current)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		SessionID: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+	}
+	sessionID := "5d41402a-bc4b-4a76-b971-9d911017c592"
+
+	t.Run("RevokeSession Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeTx", mockAppCtx, mock.Anything, sessionID, auth.Subject, mock.Anything).Return(true, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeFamilyTx", mockAppCtx, mock.Anything, sessionID, mock.Anything).Return(nil)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Revoke(mockAppCtx, auth, sessionID)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("RevokeSession Of Another User", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeTx", mockAppCtx, mock.Anything, sessionID, auth.Subject, mock.Anything).Return(false, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Revoke(mockAppCtx, auth, sessionID)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+		mockRefreshTokenRepository.AssertNotCalled(t, "RevokeFamilyTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RevokeSession Invalid ID", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		errService := mockService.Revoke(mockAppCtx, auth, "not-a-uuid")
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		SessionID: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+	}
+
+	t.Run("RevokeOtherSessions Keeps Current", func(t *testing.T) {
+		others := []string{"5d41402a-bc4b-4a76-b971-9d911017c592", "7d793037-a076-4d62-9e5c-2f1c1b3d0a11"}
+		sessions := []*entity.Session{{Id: others[0]}, {Id: auth.SessionID}, {Id: others[1]}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("FindActiveByUser", mockAppCtx, mock.Anything, auth.Subject, mock.Anything).Return(sessions, nil)
+		mockSessionRepository.On("RevokeManyTx", mockAppCtx, mock.Anything, others, mock.Anything).Return(nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeFamiliesTx", mockAppCtx, mock.Anything, others, mock.Anything).Return(nil)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.RevokeOthers(mockAppCtx, auth)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockSessionRepository.AssertExpectations(t)
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("RevokeOtherSessions Without Session", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+
+		mockService := service.NewSessionService(gormDB, mockSessionRepository, mockRefreshTokenRepository)
+
+		// Call the function under test
+		errService := mockService.RevokeOthers(mockAppCtx, &signature.JwtAuthenticationRes{Subject: auth.Subject})
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
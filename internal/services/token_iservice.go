@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+)
+
+type TokenService interface {
+	// Issue starts a new session for the user, with its own refresh token family,
+	// and mints an access token bound to it
+	Issue(ctx context.Context, user *entity.User, client entity.ClientInfo) (*UserLoginResponse, *exception.Exception)
+	// Refresh rotates a refresh token, revoking its family when a rotated token is replayed
+	Refresh(ctx context.Context, model *entity.RefreshTokenRequest) (*UserLoginResponse, *exception.Exception)
+	// Authenticate verifies an access token and rejects it when it or its session has been revoked
+	Authenticate(ctx context.Context, token string) (*signature.JwtAuthenticationRes, *exception.Exception)
+	// Logout revokes the caller's access token and session and, when given, its refresh token family
+	Logout(ctx context.Context, auth *signature.JwtAuthenticationRes, model *entity.LogoutRequest) *exception.Exception
+	// RevokeAccessTokens invalidates the user's current access tokens but keeps refresh tokens,
+	// so clients pick up changed claims on their next refresh
+	RevokeAccessTokens(ctx context.Context, userID string) *exception.Exception
+	// RevokeUserSessions invalidates every access and refresh token issued to a user so far
+	RevokeUserSessions(ctx context.Context, userID string) *exception.Exception
+	// RevokeUserSessionsTx is RevokeUserSessions within the caller's transaction.
+	// It doesn't look the user up, so it also works for a user deleted in tx.
+	RevokeUserSessionsTx(ctx context.Context, tx *gorm.DB, userID string) *exception.Exception
+}
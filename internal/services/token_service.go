@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const (
+	refreshTokenBytes = 32
+	// sessionTouchInterval limits how often last_seen_at is written for a busy session
+	sessionTouchInterval = time.Minute
+	maxUserAgentLength   = 512
+)
+
+type TokenServiceImpl struct {
+	db               *gorm.DB
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	revocationRepo   repository.TokenRevocationRepository
+	sessionRepo      repository.SessionRepository
+	signaturer       signature.Signaturer
+	validate         *xvalidator.Validator
+	refreshTokenTTL  time.Duration
+	// maxSessions is how many sessions a user may have at once, 0 for no limit
+	maxSessions int
+}
+
+func NewTokenService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	revocationRepo repository.TokenRevocationRepository,
+	sessionRepo repository.SessionRepository,
+	signaturer signature.Signaturer,
+	validate *xvalidator.Validator,
+	refreshTokenTTL time.Duration,
+	maxSessions int,
+) TokenService {
+	return &TokenServiceImpl{
+		db:               db,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revocationRepo:   revocationRepo,
+		sessionRepo:      sessionRepo,
+		signaturer:       signaturer,
+		validate:         validate,
+		refreshTokenTTL:  refreshTokenTTL,
+		maxSessions:      maxSessions,
+	}
+}
+
+func (s *TokenServiceImpl) Issue(ctx context.Context, user *entity.User, client entity.ClientInfo) (
+	*UserLoginResponse, *exception.Exception,
+) {
+	now := time.Now()
+	if exc := userStatusException(user, now); exc != nil {
+		return nil, exc
+	}
+	sessionID := uuid.NewString()
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	refreshToken, exc := s.createRefreshToken(ctx, tx, user.Id, sessionID)
+	if exc != nil {
+		return nil, exc
+	}
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	if err := s.sessionRepo.CreateTx(ctx, tx, &entity.Session{
+		Id:         sessionID,
+		UserId:     user.Id,
+		UserAgent:  userAgent,
+		IpAddress:  client.IpAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  refreshToken.record.ExpiresAt,
+	}); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if exc := s.enforceSessionLimit(ctx, tx, user.Id, sessionID, now); exc != nil {
+		return nil, exc
+	}
+	resp, exc := s.loginResponse(user, refreshToken)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return resp, nil
+}
+
+func (s *TokenServiceImpl) Refresh(ctx context.Context, model *entity.RefreshTokenRequest) (
+	*UserLoginResponse, *exception.Exception,
+) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	current, err := s.refreshTokenRepo.FindByColumn(ctx, s.db, "token_hash", signature.HashToken(model.RefreshToken))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	// Tokens issued to an OAuth client are redeemed at the token endpoint, where
+	// the client authenticates and the granted scopes are kept.
+	if current == nil || current.ClientId != "" {
+		return nil, exception.Unauthenticated("invalid refresh token")
+	}
+	now := time.Now()
+	if current.RevokedAt != nil {
+		if current.ReplacedBy != "" {
+			return nil, s.revokeFamily(ctx, current)
+		}
+		return nil, exception.Unauthenticated("refresh token has been revoked")
+	}
+	if !current.IsActive(now) {
+		return nil, exception.Unauthenticated("refresh token has expired")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, current.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.Unauthenticated("invalid refresh token")
+	}
+	if exc := userStatusException(user, now); exc != nil {
+		return nil, exc
+	}
+
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	next, exc := s.createRefreshToken(ctx, tx, user.Id, current.FamilyId)
+	if exc != nil {
+		return nil, exc
+	}
+	rotated, err := s.refreshTokenRepo.MarkRotatedTx(ctx, tx, current.Id, next.record.Id, now)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !rotated {
+		// Another request rotated this token first, treat it as a replay.
+		tx.Rollback()
+		return nil, s.revokeFamily(ctx, current)
+	}
+	if exc := s.extendSession(ctx, tx, current, next.record.ExpiresAt, now); exc != nil {
+		return nil, exc
+	}
+	resp, exc := s.loginResponse(user, next)
+	if exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return resp, nil
+}
+
+func (s *TokenServiceImpl) Authenticate(ctx context.Context, token string) (
+	*signature.JwtAuthenticationRes, *exception.Exception,
+) {
+	res, exc := s.signaturer.JWTCheck(token)
+	if exc != nil {
+		return nil, exc
+	}
+	if res.TokenID != "" {
+		revoked, err := s.revocationRepo.IsTokenRevoked(ctx, res.TokenID)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if revoked {
+			return nil, exception.Unauthenticated("Invalid token, token has been revoked")
+		}
+	}
+	if res.Subject != "" {
+		if exc := s.checkSubjectRevoked(ctx, res.Subject, res.IssuedAt); exc != nil {
+			return nil, exc
+		}
+	}
+	// Signing the impersonating admin out also ends their impersonation
+	if res.Actor != nil {
+		if exc := s.checkSubjectRevoked(ctx, res.Actor.Subject, res.IssuedAt); exc != nil {
+			return nil, exc
+		}
+	}
+	if res.SessionID != "" {
+		if exc := s.checkSession(ctx, res); exc != nil {
+			return nil, exc
+		}
+	}
+	// Client credentials tokens are issued to the client itself
+	if res.Subject != "" && res.Subject != res.ClientID {
+		if exc := s.checkUserActive(ctx, res.Subject); exc != nil {
+			return nil, exc
+		}
+	}
+	return res, nil
+}
+
+func (s *TokenServiceImpl) Logout(
+	ctx context.Context, auth *signature.JwtAuthenticationRes, model *entity.LogoutRequest,
+) *exception.Exception {
+	if auth.TokenID == "" {
+		return exception.InvalidArgument("token can't be revoked, it has no jti")
+	}
+	// The caller's own session ends too, which revokes its refresh tokens
+	var familyIDs []string
+	if auth.SessionID != "" {
+		familyIDs = append(familyIDs, auth.SessionID)
+	}
+	if model.RefreshToken != "" {
+		current, err := s.refreshTokenRepo.FindByColumn(ctx, s.db, "token_hash", signature.HashToken(model.RefreshToken))
+		if err != nil {
+			return exception.Internal("err", err)
+		}
+		if current != nil {
+			if current.UserId != auth.Subject {
+				return exception.PermissionDenied("refresh token belongs to another user")
+			}
+			if current.FamilyId != auth.SessionID {
+				familyIDs = append(familyIDs, current.FamilyId)
+			}
+		}
+	}
+	if len(familyIDs) > 0 {
+		now := time.Now()
+		tx := s.db.Begin()
+		defer tx.Rollback()
+		for _, familyID := range familyIDs {
+			if err := s.refreshTokenRepo.RevokeFamilyTx(ctx, tx, familyID, now); err != nil {
+				return exception.Internal("err", err)
+			}
+			if _, err := s.sessionRepo.RevokeTx(ctx, tx, familyID, auth.Subject, now); err != nil {
+				return exception.Internal("err", err)
+			}
+		}
+		if err := tx.Commit().Error; err != nil {
+			return exception.Internal("commit transaction", err)
+		}
+	}
+	if err := s.revocationRepo.RevokeToken(ctx, auth.TokenID, auth.ExpiresAt); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+func (s *TokenServiceImpl) RevokeUserSessions(ctx context.Context, userID string) *exception.Exception {
+	if _, err := uuid.Parse(userID); err != nil {
+		return exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if exc := s.RevokeUserSessionsTx(ctx, tx, userID); exc != nil {
+		return exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *TokenServiceImpl) RevokeUserSessionsTx(ctx context.Context, tx *gorm.DB, userID string) *exception.Exception {
+	now := time.Now()
+	if err := s.refreshTokenRepo.RevokeByUserTx(ctx, tx, userID, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := s.sessionRepo.RevokeByUserTx(ctx, tx, userID, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	// The revocation store isn't part of tx. Revoking early errs on the safe
+	// side when the caller's transaction fails later.
+	if err := s.revocationRepo.RevokeSubject(ctx, userID, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+func (s *TokenServiceImpl) RevokeAccessTokens(ctx context.Context, userID string) *exception.Exception {
+	if err := s.revocationRepo.RevokeSubject(ctx, userID, time.Now()); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+// enforceSessionLimit revokes the user's least recently seen sessions, other
+// than the one just created, until at most maxSessions remain.
+func (s *TokenServiceImpl) enforceSessionLimit(
+	ctx context.Context, tx *gorm.DB, userID, sessionID string, now time.Time,
+) *exception.Exception {
+	if s.maxSessions <= 0 {
+		return nil
+	}
+	sessions, err := s.sessionRepo.FindActiveByUser(ctx, tx, userID, now)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	var evicted []string
+	kept := 1
+	for _, session := range sessions {
+		if session.Id == sessionID {
+			continue
+		}
+		if kept < s.maxSessions {
+			kept++
+			continue
+		}
+		evicted = append(evicted, session.Id)
+	}
+	if len(evicted) == 0 {
+		return nil
+	}
+	slog.Info("session limit reached, revoking oldest sessions", "user_id", userID, "count", len(evicted))
+	if err := s.sessionRepo.RevokeManyTx(ctx, tx, evicted, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := s.refreshTokenRepo.RevokeFamiliesTx(ctx, tx, evicted, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+// extendSession moves the expiry of the session a refresh token belongs to.
+// Families started before sessions existed get one on their first refresh.
+func (s *TokenServiceImpl) extendSession(
+	ctx context.Context, tx *gorm.DB, token *entity.RefreshToken, expiresAt, now time.Time,
+) *exception.Exception {
+	session, err := s.sessionRepo.FindByID(ctx, tx, token.FamilyId)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if session == nil {
+		if err := s.sessionRepo.CreateTx(ctx, tx, &entity.Session{
+			Id:         token.FamilyId,
+			UserId:     token.UserId,
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiresAt:  expiresAt,
+		}); err != nil {
+			return exception.Internal("err", err)
+		}
+		return nil
+	}
+	if session.RevokedAt != nil {
+		return exception.Unauthenticated("session has been revoked")
+	}
+	if err := s.sessionRepo.ExtendTx(ctx, tx, session.Id, now, expiresAt); err != nil {
+		return exception.Internal("err", err)
+	}
+	return nil
+}
+
+// checkSubjectRevoked rejects a token issued to, or on behalf of, subject
+// before all of its tokens were revoked.
+func (s *TokenServiceImpl) checkSubjectRevoked(
+	ctx context.Context, subject string, issuedAt time.Time,
+) *exception.Exception {
+	revokedAt, err := s.revocationRepo.SubjectRevokedAt(ctx, subject)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	// iat only has second precision, so a token from the same second as the
+	// revocation is rejected too rather than risk accepting an older one.
+	if revokedAt != nil && !issuedAt.After(revokedAt.Truncate(time.Second)) {
+		return exception.Unauthenticated("Invalid token, token has been revoked")
+	}
+	return nil
+}
+
+// checkUserActive rejects the tokens of a user that was deleted or isn't
+// active, such as one suspended while its access token is still valid.
+func (s *TokenServiceImpl) checkUserActive(ctx context.Context, userID string) *exception.Exception {
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.Unauthenticated("Invalid token, user not found")
+	}
+	return userStatusException(user, time.Now())
+}
+
+// checkSession rejects a token whose session has been revoked or has expired.
+func (s *TokenServiceImpl) checkSession(ctx context.Context, res *signature.JwtAuthenticationRes) *exception.Exception {
+	session, err := s.sessionRepo.FindByID(ctx, s.db, res.SessionID)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	now := time.Now()
+	if session == nil || session.UserId != res.Subject || !session.IsActive(now) {
+		return exception.Unauthenticated("Invalid token, session has ended")
+	}
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := s.sessionRepo.TouchTx(ctx, s.db, session.Id, now); err != nil {
+			slog.Error("failed to record session use", "session_id", session.Id, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+type issuedRefreshToken struct {
+	record *entity.RefreshToken
+	token  string
+}
+
+func (s *TokenServiceImpl) createRefreshToken(
+	ctx context.Context, tx *gorm.DB, userID, familyID string,
+) (*issuedRefreshToken, *exception.Exception) {
+	return createRefreshToken(ctx, tx, s.refreshTokenRepo, &entity.RefreshToken{
+		UserId:   userID,
+		FamilyId: familyID,
+	}, s.refreshTokenTTL)
+}
+
+// createRefreshToken stores a new token for record, which names the user,
+// family and, for OAuth clients, the client and scopes it is bound to.
+func createRefreshToken(
+	ctx context.Context, tx *gorm.DB, repo repository.RefreshTokenRepository,
+	record *entity.RefreshToken, ttl time.Duration,
+) (*issuedRefreshToken, *exception.Exception) {
+	token, err := signature.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return nil, exception.Internal("can't create refresh token", err)
+	}
+	now := time.Now()
+	record.Id = uuid.NewString()
+	record.TokenHash = signature.HashToken(token)
+	record.ExpiresAt = now.Add(ttl)
+	record.CreatedAt = now
+	if err := repo.CreateTx(ctx, tx, record); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return &issuedRefreshToken{record: record, token: token}, nil
+}
+
+// revokeFamily answers a replayed refresh token like revokeRefreshFamily and
+// also ends the session the family belongs to, so the access tokens issued to
+// whoever holds the stolen token stop working as well.
+func (s *TokenServiceImpl) revokeFamily(ctx context.Context, token *entity.RefreshToken) *exception.Exception {
+	slog.Warn("refresh token reuse detected", "user_id", token.UserId, "family_id", token.FamilyId)
+	now := time.Now()
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.refreshTokenRepo.RevokeFamilyTx(ctx, tx, token.FamilyId, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if _, err := s.sessionRepo.RevokeTx(ctx, tx, token.FamilyId, token.UserId, now); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return exception.Unauthenticated("refresh token reuse detected, please login again")
+}
+
+// revokeRefreshFamily answers a replayed refresh token by revoking every
+// token of its family.
+func revokeRefreshFamily(
+	ctx context.Context, db *gorm.DB, repo repository.RefreshTokenRepository, token *entity.RefreshToken,
+) *exception.Exception {
+	slog.Warn("refresh token reuse detected", "user_id", token.UserId, "family_id", token.FamilyId)
+	tx := db.Begin()
+	defer tx.Rollback()
+	if err := repo.RevokeFamilyTx(ctx, tx, token.FamilyId, time.Now()); err != nil {
+		return exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+func (s *TokenServiceImpl) loginResponse(user *entity.User, refreshToken *issuedRefreshToken) (
+	*UserLoginResponse, *exception.Exception,
+) {
+	roles := user.RoleNames()
+	jwtToken, err := s.signaturer.GenerateJWT(signature.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: user.Id},
+		Username:         user.Username,
+		Roles:            roles,
+		Permissions:      entity.PermissionsFor(roles),
+		SessionID:        refreshToken.record.FamilyId,
+	})
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return &UserLoginResponse{
+		Username:              user.Username,
+		Email:                 user.Email,
+		Token:                 jwtToken,
+		RefreshToken:          refreshToken.token,
+		RefreshTokenExpiresAt: &refreshToken.record.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,628 @@
+package service_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	mocksSignature "user-simple-crud/pkg/mocks"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+func TestIssueToken(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+	}
+	client := entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+
+	t.Run("IssueToken Success", func(t *testing.T) {
+		var sessionID string
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			sessionID = token.FamilyId
+			return token.UserId == user.Id && token.FamilyId != "" && token.TokenHash != ""
+		})).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(session *entity.Session) bool {
+			return session.Id == sessionID && session.UserId == user.Id &&
+				session.IpAddress == client.IpAddress && session.UserAgent == client.UserAgent
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			return claims.Subject == user.Id && claims.Username == user.Username && claims.SessionID == sessionID
+		})).Return("jwt_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Issue(mockAppCtx, user, client)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+		assert.NotEmpty(t, result.RefreshToken)
+		mockSessionRepository.AssertNotCalled(t, "FindActiveByUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("IssueToken Session Limit Revokes Oldest", func(t *testing.T) {
+		var sessionID string
+		recent := &entity.Session{Id: "5d41402a-bc4b-4a76-b971-9d911017c592", UserId: user.Id}
+		oldest := &entity.Session{Id: "7d793037-a076-4d62-9e5c-2f1c1b3d0a11", UserId: user.Id}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			sessionID = token.FamilyId
+			return true
+		})).Return(nil)
+		mockRefreshTokenRepository.On("RevokeFamiliesTx", mockAppCtx, mock.Anything, []string{oldest.Id}, mock.Anything).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockSessionRepository.On("FindActiveByUser", mockAppCtx, mock.Anything, user.Id, mock.Anything).
+			Return(func(context.Context, *gorm.DB, string, time.Time) []*entity.Session {
+				return []*entity.Session{{Id: sessionID, UserId: user.Id}, recent, oldest}
+			}, nil)
+		mockSessionRepository.On("RevokeManyTx", mockAppCtx, mock.Anything, []string{oldest.Id}, mock.Anything).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.Anything).Return("jwt_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 2)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Issue(mockAppCtx, user, client)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+		mockSessionRepository.AssertCalled(t, "RevokeManyTx", mockAppCtx, mock.Anything, []string{oldest.Id}, mock.Anything)
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+	}
+
+	t.Run("RefreshToken Success", func(t *testing.T) {
+		request := &entity.RefreshTokenRequest{RefreshToken: "refresh_token"}
+		current := &entity.RefreshToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    user.Id,
+			FamilyId:  "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+			TokenHash: signature.HashToken(request.RefreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+		mockRefreshTokenRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+			return token.FamilyId == current.FamilyId
+		})).Return(nil)
+		mockRefreshTokenRepository.On("MarkRotatedTx", mockAppCtx, mock.Anything, current.Id, mock.Anything, mock.Anything).Return(true, nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("FindByID", mockAppCtx, mock.Anything, current.FamilyId).Return(&entity.Session{Id: current.FamilyId, UserId: user.Id}, nil)
+		mockSessionRepository.On("ExtendTx", mockAppCtx, mock.Anything, current.FamilyId, mock.Anything, mock.Anything).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("GenerateJWT", mock.MatchedBy(func(claims signature.JWTClaims) bool {
+			return claims.Subject == user.Id && claims.Username == user.Username && claims.SessionID == current.FamilyId
+		})).Return("jwt_token", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Refresh(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+		assert.NotEqual(t, request.RefreshToken, result.RefreshToken)
+		mockSessionRepository.AssertCalled(t, "ExtendTx", mockAppCtx, mock.Anything, current.FamilyId, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RefreshToken Reuse Revokes Family And Session", func(t *testing.T) {
+		request := &entity.RefreshTokenRequest{RefreshToken: "rotated_refresh_token"}
+		revokedAt := time.Now().Add(-time.Minute)
+		current := &entity.RefreshToken{
+			Id:         "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:     user.Id,
+			FamilyId:   "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+			TokenHash:  signature.HashToken(request.RefreshToken),
+			ReplacedBy: "5d41402a-bc4b-4a76-b971-9d911017c592",
+			ExpiresAt:  time.Now().Add(time.Hour),
+			RevokedAt:  &revokedAt,
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+		mockRefreshTokenRepository.On("RevokeFamilyTx", mockAppCtx, mock.Anything, current.FamilyId, mock.Anything).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeTx", mockAppCtx, mock.Anything, current.FamilyId, user.Id, mock.Anything).Return(true, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Refresh(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		mockRefreshTokenRepository.AssertCalled(t, "RevokeFamilyTx", mockAppCtx, mock.Anything, current.FamilyId, mock.Anything)
+		mockSessionRepository.AssertCalled(t, "RevokeTx", mockAppCtx, mock.Anything, current.FamilyId, user.Id, mock.Anything)
+	})
+
+	t.Run("RefreshToken Expired", func(t *testing.T) {
+		request := &entity.RefreshTokenRequest{RefreshToken: "expired_refresh_token"}
+		current := &entity.RefreshToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    user.Id,
+			FamilyId:  "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+			TokenHash: signature.HashToken(request.RefreshToken),
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Refresh(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+
+	t.Run("RefreshToken Issued To OAuth Client", func(t *testing.T) {
+		request := &entity.RefreshTokenRequest{RefreshToken: "client_refresh_token"}
+		current := &entity.RefreshToken{
+			Id:        "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:    user.Id,
+			FamilyId:  "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+			ClientId:  "0f8fad5b-d9cb-469f-a165-70867728950e",
+			Scopes:    []string{entity.ScopeOpenID},
+			TokenHash: signature.HashToken(request.RefreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", current.TokenHash).Return(current, nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Refresh(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+		mockRefreshTokenRepository.AssertNotCalled(t, "MarkRotatedTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RefreshToken Not Found", func(t *testing.T) {
+		request := &entity.RefreshTokenRequest{RefreshToken: "unknown_refresh_token"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", signature.HashToken(request.RefreshToken)).Return(nil, nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Refresh(mockAppCtx, request)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Username:  "john_doe",
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		TokenID:   "6f1d2c3b-4a5e-4f60-8a7b-9c0d1e2f3a4b",
+		IssuedAt:  time.Now().Add(-time.Minute),
+		ExpiresAt: time.Now().Add(time.Hour),
+		Token:     "jwt_token",
+	}
+
+	t.Run("AuthenticateToken Success", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, auth.Subject).Return(&entity.User{Id: auth.Subject, Status: entity.UserStatusActive}, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, auth.Subject).Return(nil, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(auth, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, auth, result)
+	})
+
+	t.Run("AuthenticateToken Suspended User", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, auth.Subject).Return(&entity.User{Id: auth.Subject, Status: entity.UserStatusSuspended}, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, auth.Subject).Return(nil, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(auth, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+	})
+
+	t.Run("AuthenticateToken Client Credentials", func(t *testing.T) {
+		clientToken := *auth
+		clientToken.Subject = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+		clientToken.ClientID = clientToken.Subject
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, clientToken.Subject).Return(nil, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(&clientToken, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.NotNil(t, result)
+		mockUserRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("AuthenticateToken Revoked Token", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(true, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(auth, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+
+	t.Run("AuthenticateToken Revoked Subject", func(t *testing.T) {
+		revokedAt := time.Now()
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, auth.Subject).Return(&revokedAt, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(auth, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+
+	t.Run("AuthenticateToken Revoked Actor", func(t *testing.T) {
+		revokedAt := time.Now()
+		impersonated := *auth
+		impersonated.Actor = &signature.ActorClaim{Subject: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33", Username: "admin"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, auth.Subject).Return(nil, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, impersonated.Actor.Subject).Return(&revokedAt, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(&impersonated, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+
+	t.Run("AuthenticateToken Revoked Session", func(t *testing.T) {
+		revokedAt := time.Now()
+		withSession := *auth
+		withSession.SessionID = "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("IsTokenRevoked", mockAppCtx, auth.TokenID).Return(false, nil)
+		mockRevocationRepository.On("SubjectRevokedAt", mockAppCtx, auth.Subject).Return(nil, nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("FindByID", mockAppCtx, mock.Anything, withSession.SessionID).Return(&entity.Session{
+			Id:        withSession.SessionID,
+			UserId:    auth.Subject,
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("JWTCheck", auth.Token).Return(&withSession, nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		result, errService := mockService.Authenticate(mockAppCtx, auth.Token)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	mockAppCtx := context.Background()
+	auth := &signature.JwtAuthenticationRes{
+		Username:  "john_doe",
+		Subject:   "123e4567-e89b-12d3-a456-426614174000",
+		TokenID:   "6f1d2c3b-4a5e-4f60-8a7b-9c0d1e2f3a4b",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("Logout With Refresh Token Success", func(t *testing.T) {
+		request := &entity.LogoutRequest{RefreshToken: "refresh_token"}
+		current := &entity.RefreshToken{
+			Id:       "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:   auth.Subject,
+			FamilyId: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", signature.HashToken(request.RefreshToken)).Return(current, nil)
+		mockRefreshTokenRepository.On("RevokeFamilyTx", mockAppCtx, mock.Anything, current.FamilyId, mock.Anything).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("RevokeToken", mockAppCtx, auth.TokenID, auth.ExpiresAt).Return(nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeTx", mockAppCtx, mock.Anything, current.FamilyId, auth.Subject, mock.Anything).Return(true, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Logout(mockAppCtx, auth, request)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRevocationRepository.AssertCalled(t, "RevokeToken", mockAppCtx, auth.TokenID, auth.ExpiresAt)
+	})
+
+	t.Run("Logout Ends Session", func(t *testing.T) {
+		withSession := *auth
+		withSession.SessionID = "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeFamilyTx", mockAppCtx, mock.Anything, withSession.SessionID, mock.Anything).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("RevokeToken", mockAppCtx, auth.TokenID, auth.ExpiresAt).Return(nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeTx", mockAppCtx, mock.Anything, withSession.SessionID, auth.Subject, mock.Anything).Return(true, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Logout(mockAppCtx, &withSession, &entity.LogoutRequest{})
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockSessionRepository.AssertCalled(t, "RevokeTx", mockAppCtx, mock.Anything, withSession.SessionID, auth.Subject, mock.Anything)
+	})
+
+	t.Run("Logout Refresh Token Of Another User", func(t *testing.T) {
+		request := &entity.LogoutRequest{RefreshToken: "refresh_token"}
+		current := &entity.RefreshToken{
+			Id:       "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11",
+			UserId:   "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d",
+			FamilyId: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("FindByColumn", mockAppCtx, mock.Anything, "token_hash", signature.HashToken(request.RefreshToken)).Return(current, nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		errService := mockService.Logout(mockAppCtx, auth, request)
+
+		// Assert the result
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockRevocationRepository.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	mockAppCtx := context.Background()
+	user := &entity.User{
+		Id:       "123e4567-e89b-12d3-a456-426614174000",
+		Username: "john_doe",
+	}
+
+	t.Run("RevokeUserSessions Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(user, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeByUserTx", mockAppCtx, mock.Anything, user.Id, mock.Anything).Return(nil)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockRevocationRepository.On("RevokeSubject", mockAppCtx, user.Id, mock.Anything).Return(nil)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("RevokeByUserTx", mockAppCtx, mock.Anything, user.Id, mock.Anything).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.RevokeUserSessions(mockAppCtx, user.Id)
+
+		// Assert the result
+		assert.Nil(t, errService)
+	})
+
+	t.Run("RevokeUserSessions User Not Found", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, user.Id).Return(nil, nil)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockService := service.NewTokenService(gormDB, mockUserRepository, mockRefreshTokenRepository, mockRevocationRepository, mockSessionRepository, mockSignaturer, validate, time.Hour, 0)
+
+		// Call the function under test
+		errService := mockService.RevokeUserSessions(mockAppCtx, user.Id)
+
+		// Assert the result
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
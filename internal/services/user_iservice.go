@@ -2,35 +2,86 @@ package service
 
 import (
 	"context"
+	"time"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/model"
 	"user-simple-crud/pkg/exception"
 )
 
 type UserService interface {
-	// Register-Login operations for User
+	// Register-Login operations for User. The password must follow the password policy.
+	// Every change below is written to the audit log in the same transaction, attributed to actor.
 	Create(
-		ctx context.Context, model *entity.UserLogin,
+		ctx context.Context, model *entity.UserLogin, actor entity.AuditActor,
 	) *exception.Exception
-	Login(ctx context.Context, model *entity.UserLogin) (*UserLoginResponse, *exception.Exception)
+	// Login checks the password, counting failures against the account and the client's IP,
+	// and starts a session for the client. An expired password gets a password change token instead.
+	Login(ctx context.Context, model *entity.UserLogin, client entity.ClientInfo) (*UserLoginResponse, *exception.Exception)
 
-	// CRUD operations for User
+	// CRUD operations for User. Patch applies a JSON Merge Patch of entity.UserProfile and only
+	// checks uniqueness of the fields it changes; a changed email has to be verified again.
+	// Patch, Update and Delete fail with a precondition exception when version isn't zero and the user's
+	// version has moved on, or when another request changes the user while they run.
+	Patch(
+		ctx context.Context, id string, patch []byte, version int64, actor entity.AuditActor,
+	) (*entity.User, *exception.Exception)
+	// Update replaces the username and email, an empty one is cleared. It is kept for clients of
+	// PUT /users/{id} from before Patch and refuses a password, those go through ChangePassword.
 	Update(
-		ctx context.Context, id string, model *entity.UserLogin,
+		ctx context.Context, id string, model *entity.UserUpdateRequest, version int64, actor entity.AuditActor,
+	) (*entity.User, *exception.Exception)
+	// ChangePassword sets a new password once the current one is confirmed. Wrong passwords count
+	// towards the lockout like failed logins, and a change ends all of the user's sessions.
+	ChangePassword(
+		ctx context.Context, id string, model *entity.ChangePasswordRequest, actor entity.AuditActor,
 	) *exception.Exception
+	// Delete soft deletes the user and ends its sessions. Restore brings the user back as long as
+	// PurgeDeleted hasn't removed it and its username and email weren't taken in the meantime.
 	Delete(
-		ctx context.Context, id string,
+		ctx context.Context, id string, version int64, actor entity.AuditActor,
 	) *exception.Exception
+	Restore(ctx context.Context, id string, actor entity.AuditActor) (*entity.User, *exception.Exception)
+	// PurgeDeleted removes users deleted more than retention ago for good, along with their sessions,
+	// tokens, credentials and group memberships, and returns how many.
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, *exception.Exception)
+	// List validates the status and group filters; a group filter keeps the group's members.
 	List(ctx context.Context, req model.ListReq) (
 		*ListUserResp, *exception.Exception,
 	)
-	FindOne(ctx context.Context, id string) (*entity.User, *exception.Exception)
+	// FindOne returns nil when the user doesn't exist. include names the relations to load along,
+	// only "groups" for now.
+	FindOne(ctx context.Context, id string, include ...string) (*entity.User, *exception.Exception)
+
+	// Suspend stops the user from signing in and ends its sessions, until ExpiresAt if set. With Ban
+	// the user stays banned until reactivated. Reactivate lets a suspended, banned or pending user in.
+	Suspend(
+		ctx context.Context, id string, model *entity.SuspendRequest, actor entity.AuditActor,
+	) (*entity.User, *exception.Exception)
+	Reactivate(
+		ctx context.Context, id string, model *entity.ReactivateRequest, actor entity.AuditActor,
+	) (*entity.User, *exception.Exception)
+
+	// Role management for User
+	AssignRole(
+		ctx context.Context, id string, model *entity.RoleRequest, actor entity.AuditActor,
+	) (*entity.User, *exception.Exception)
+	RevokeRole(ctx context.Context, id string, role string, actor entity.AuditActor) (*entity.User, *exception.Exception)
 }
 
 type UserLoginResponse struct {
 	Username string `json:"username" example:"john_doe"`
 	Email    string `json:"email" example:"john_doe@example.com"`
-	Token    string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"` // JWT token example
+	Token    string `json:"token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"` // JWT token example
+	// RefreshToken is shown once; exchange it at /auth/refresh for a new pair
+	RefreshToken          string     `json:"refresh_token,omitempty" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+	RefreshTokenExpiresAt *time.Time `json:"refresh_token_expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	// MFARequired means no tokens were issued yet; send MFAToken with a code to /auth/mfa/verify
+	MFARequired bool   `json:"mfa_required,omitempty" example:"false"`
+	MFAToken    string `json:"mfa_token,omitempty" example:"Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"`
+	// PasswordChangeRequired means the password expired and no tokens were issued;
+	// send PasswordChangeToken with a new password to /auth/change-expired-password
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty" example:"false"`
+	PasswordChangeToken    string `json:"password_change_token,omitempty" example:"0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E3q2-7wYl"`
 }
 
 type ListUserResp struct {
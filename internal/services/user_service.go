@@ -1,41 +1,91 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/google/uuid"
+	"log/slog"
+	"strings"
+	"time"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/model"
 	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/mergepatch"
 	"user-simple-crud/pkg/signature"
 
 	//"user-simple-crud/pkg/exception"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"user-simple-crud/pkg/exception"
 	"user-simple-crud/pkg/xvalidator"
 )
 
+// userIncludes maps the include names FindOne accepts to the associations they preload.
+var userIncludes = map[string]string{
+	"groups": entity.UserGroupsAssociation,
+}
+
+// userFilterColumns are the fields users can be filtered and sorted on and the
+// columns they map to. The group filter is resolved by the repository, so
+// users can't be sorted by group.
+var userFilterColumns = map[string]string{
+	"id":       "id",
+	"username": "username",
+	"email":    "email",
+	"status":   "status",
+	"group":    "group",
+}
+
 type UserServiceImpl struct {
-	db         *gorm.DB
-	userRepo   repository.UserRepository
-	signaturer signature.Signaturer
-	validate   *xvalidator.Validator
+	db             *gorm.DB
+	userRepo       repository.UserRepository
+	signaturer     signature.Signaturer
+	tokenService   TokenService
+	accountService AccountService
+	mfaService     MFAService
+	lockoutService LockoutService
+	passwordPolicy PasswordPolicyService
+	auditService   AuditService
+	validate       *xvalidator.Validator
+	// bootstrapAdmins lists usernames or emails that receive the admin role on registration
+	bootstrapAdmins []string
+	// requireVerifiedEmail makes registration require an email and login reject unverified ones
+	requireVerifiedEmail bool
 }
 
 func NewUserService(
 	db *gorm.DB, repo repository.UserRepository,
 	signaturer signature.Signaturer,
+	tokenService TokenService,
+	accountService AccountService,
+	mfaService MFAService,
+	lockoutService LockoutService,
+	passwordPolicy PasswordPolicyService,
+	auditService AuditService,
 	validate *xvalidator.Validator,
+	bootstrapAdmins []string,
+	requireVerifiedEmail bool,
 ) UserService {
 	return &UserServiceImpl{
-		db:         db,
-		userRepo:   repo,
-		signaturer: signaturer,
-		validate:   validate,
+		db:                   db,
+		userRepo:             repo,
+		signaturer:           signaturer,
+		tokenService:         tokenService,
+		accountService:       accountService,
+		mfaService:           mfaService,
+		lockoutService:       lockoutService,
+		passwordPolicy:       passwordPolicy,
+		auditService:         auditService,
+		validate:             validate,
+		bootstrapAdmins:      bootstrapAdmins,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}
 }
 
 func (s *UserServiceImpl) Create(
-	ctx context.Context, model *entity.UserLogin,
+	ctx context.Context, model *entity.UserLogin, actor entity.AuditActor,
 ) *exception.Exception {
 	tx := s.db.Begin()
 	defer tx.Rollback()
@@ -45,6 +95,9 @@ func (s *UserServiceImpl) Create(
 	if model.Email == "" && model.Username == "" {
 		return exception.InvalidArgument("either email or username must be filled")
 	}
+	if s.requireVerifiedEmail && model.Email == "" {
+		return exception.InvalidArgument("email must be filled")
+	}
 	duplicateCheck, err := s.userRepo.FindByName(ctx, s.db, "username", model.Username)
 	if err != nil {
 		return exception.Internal("err", err)
@@ -59,27 +112,44 @@ func (s *UserServiceImpl) Create(
 	if duplicateCheck != nil {
 		return exception.PermissionDenied("email already exists")
 	}
-	password, err := s.signaturer.HashBscryptPassword(model.Password)
-	if err != nil {
-		return exception.Internal("can't create password", err)
-	}
 	body := &entity.User{
 		Id:       uuid.NewString(),
 		Username: model.Username,
 		Email:    model.Email,
-		Password: password,
+		Roles:    s.initialRoles(model),
+		Status:   entity.UserStatusActive,
 	}
+	if s.requireVerifiedEmail {
+		body.Status = entity.UserStatusPending
+	}
+	if exc := s.passwordPolicy.Validate(ctx, tx, body, model.Password); exc != nil {
+		return exc
+	}
+	password, err := s.signaturer.HashPassword(model.Password)
+	if err != nil {
+		return exception.Internal("can't create password", err)
+	}
+	now := time.Now()
+	body.Password = password
+	body.PasswordChangedAt = &now
 	if err := s.userRepo.CreateTx(ctx, tx, body); err != nil {
 		return exception.Internal("err", err)
 	}
+	if exc := s.passwordPolicy.RecordTx(ctx, tx, body); exc != nil {
+		return exc
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditUserCreate, body.Id, nil, body); exc != nil {
+		return exc
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		return exception.Internal("commit transaction", err)
 	}
+	s.sendEmailVerification(ctx, body)
 	return nil
 }
 
-func (s *UserServiceImpl) Login(ctx context.Context, model *entity.UserLogin) (
+func (s *UserServiceImpl) Login(ctx context.Context, model *entity.UserLogin, client entity.ClientInfo) (
 	*UserLoginResponse, *exception.Exception,
 ) {
 	if errs := s.validate.Struct(model); errs != nil {
@@ -94,73 +164,234 @@ func (s *UserServiceImpl) Login(ctx context.Context, model *entity.UserLogin) (
 		if err != nil {
 			return nil, exception.Internal("err", err)
 		}
-		if result == nil {
-			return nil, exception.NotFound("username/email not found")
+	}
+	var userID string
+	if result != nil {
+		userID = result.Id
+	}
+	if exc := s.lockoutService.Check(ctx, userID, client.IpAddress); exc != nil {
+		return nil, exc
+	}
+	if result == nil {
+		if exc := s.lockoutService.RecordFailure(ctx, "", client.IpAddress); exc != nil {
+			return nil, exc
 		}
+		return nil, exception.NotFound("username/email not found")
 	}
-	if ok := s.signaturer.CheckBscryptPasswordHash(model.Password, result.Password); !ok {
+	ok, needsRehash := s.signaturer.CheckPasswordHash(model.Password, result.Password)
+	if !ok {
+		if exc := s.lockoutService.RecordFailure(ctx, result.Id, client.IpAddress); exc != nil {
+			return nil, exc
+		}
 		return nil, exception.PermissionDenied("username/password unmatched")
 	}
-	jwtToken, err := s.signaturer.GenerateJWT(result.Username)
+	if needsRehash {
+		s.rehashPassword(ctx, result, model.Password)
+	}
+	if s.requireVerifiedEmail && result.EmailVerifiedAt == nil {
+		return nil, exception.PermissionDenied("email address has not been verified")
+	}
+	if exc := userStatusException(result, time.Now()); exc != nil {
+		return nil, exc
+	}
+	mfaEnabled, exc := s.mfaService.Enabled(ctx, result.Id)
+	if exc != nil {
+		return nil, exc
+	}
+	// With MFA the failures are kept until the second factor passes too, so
+	// guessing codes can't be spread over password logins that reset them.
+	if mfaEnabled {
+		return s.mfaService.Challenge(ctx, result)
+	}
+	if exc := s.lockoutService.RecordSuccess(ctx, result.Id); exc != nil {
+		return nil, exc
+	}
+	if s.passwordPolicy.Expired(result, time.Now()) {
+		return s.passwordPolicy.ChangeRequired(ctx, result)
+	}
+	return s.tokenService.Issue(ctx, result, client)
+}
+
+func (s *UserServiceImpl) Patch(
+	ctx context.Context, id string, patch []byte, version int64, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	existing, err := s.userRepo.FindByID(ctx, s.db, id)
 	if err != nil {
 		return nil, exception.Internal("err", err)
 	}
-	return &UserLoginResponse{
-		Username: result.Username,
-		Token:    jwtToken,
-	}, nil
+	if existing == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	if exc := checkVersion(existing, version); exc != nil {
+		return nil, exc
+	}
+	current, err := json.Marshal(entity.UserProfile{Username: existing.Username, Email: existing.Email})
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	merged, err := mergepatch.Apply(current, patch)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	// Members other than the profile's, such as password or roles, are rejected
+	// rather than ignored so a client doesn't believe they were changed.
+	profile := entity.UserProfile{}
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, exception.InvalidArgument("invalid patch, only username and email can be changed: " + err.Error())
+	}
+	return s.updateProfile(ctx, existing, profile, actor)
 }
 
 func (s *UserServiceImpl) Update(
-	ctx context.Context, id string, model *entity.UserLogin,
-) *exception.Exception {
+	ctx context.Context, id string, model *entity.UserUpdateRequest, version int64, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	if model.Password != "" {
+		return nil, exception.InvalidArgument("the password can't be updated here, use /users/" + id + "/password")
+	}
+	existing, err := s.userRepo.FindByID(ctx, s.db, id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if existing == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	if exc := checkVersion(existing, version); exc != nil {
+		return nil, exc
+	}
+	return s.updateProfile(ctx, existing, model.UserProfile, actor)
+}
+
+// updateProfile replaces existing's username and email with profile's. Only
+// changed values are checked for uniqueness and a new email address has to be
+// verified again.
+func (s *UserServiceImpl) updateProfile(
+	ctx context.Context, existing *entity.User, profile entity.UserProfile, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	id := existing.Id
+	if errs := s.validate.Struct(profile); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	if profile.Email == "" && profile.Username == "" {
+		return nil, exception.InvalidArgument("either email or username must be filled")
+	}
+	if s.requireVerifiedEmail && profile.Email == "" {
+		return nil, exception.InvalidArgument("email must be filled")
+	}
+	usernameChanged := profile.Username != existing.Username
+	emailChanged := profile.Email != existing.Email
+	if !usernameChanged && !emailChanged {
+		return existing, nil
+	}
+	if usernameChanged && profile.Username != "" {
+		duplicateCheck, err := s.userRepo.FindByName(ctx, s.db, "username", profile.Username)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if duplicateCheck != nil && duplicateCheck.Id != id {
+			return nil, exception.PermissionDenied("username already exists")
+		}
+	}
+	if emailChanged && profile.Email != "" {
+		duplicateCheck, err := s.userRepo.FindByName(ctx, s.db, "email", profile.Email)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if duplicateCheck != nil && duplicateCheck.Id != id {
+			return nil, exception.PermissionDenied("email already exists")
+		}
+	}
+	// A change of case only still reaches the same inbox.
+	reverify := !strings.EqualFold(existing.Email, profile.Email)
+	before := cloneUser(existing)
+	existing.Username = profile.Username
+	existing.Email = profile.Email
+	if reverify {
+		existing.EmailVerifiedAt = nil
+	}
 	tx := s.db.Begin()
 	defer tx.Rollback()
+	if err := s.userRepo.UpdateTx(ctx, tx, existing); err != nil {
+		return nil, updateException(err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditUserUpdate, id, before, existing); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	if reverify {
+		s.sendEmailVerification(ctx, existing)
+	}
+	return existing, nil
+}
+
+func (s *UserServiceImpl) ChangePassword(
+	ctx context.Context, id string, model *entity.ChangePasswordRequest, actor entity.AuditActor,
+) *exception.Exception {
 	if errs := s.validate.Struct(model); errs != nil {
 		return exception.InvalidArgument(errs)
 	}
-	_, err := uuid.Parse(id)
-	if err != nil {
+	if _, err := uuid.Parse(id); err != nil {
 		return exception.InvalidArgument("invalid user id, must be uuid")
 	}
-	if model.Email == "" && model.Username == "" {
-		return exception.InvalidArgument("either email or username must be filled")
+	if exc := s.lockoutService.Check(ctx, id, actor.IpAddress); exc != nil {
+		return exc
 	}
-	duplicateCheck, err := s.userRepo.FindByName(ctx, s.db, "username", model.Username)
+	user, err := s.userRepo.FindByID(ctx, s.db, id)
 	if err != nil {
 		return exception.Internal("err", err)
 	}
-	if duplicateCheck != nil && duplicateCheck.Id != id {
-		return exception.PermissionDenied("username already exists")
+	if user == nil {
+		return exception.NotFound("user not found")
 	}
-	duplicateCheck, err = s.userRepo.FindByName(ctx, s.db, "email", model.Email)
-	if err != nil {
-		return exception.Internal("err", err)
+	if ok, _ := s.signaturer.CheckPasswordHash(model.CurrentPassword, user.Password); !ok {
+		if exc := s.lockoutService.RecordFailure(ctx, user.Id, actor.IpAddress); exc != nil {
+			return exc
+		}
+		return exception.PermissionDenied("current password is incorrect")
 	}
-	if duplicateCheck != nil && duplicateCheck.Id != id {
-		return exception.PermissionDenied("email already exists")
+	if exc := s.lockoutService.RecordSuccess(ctx, user.Id); exc != nil {
+		return exc
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if exc := s.passwordPolicy.Validate(ctx, tx, user, model.NewPassword); exc != nil {
+		return exc
 	}
-	password, err := s.signaturer.HashBscryptPassword(model.Password)
+	password, err := s.signaturer.HashPassword(model.NewPassword)
 	if err != nil {
 		return exception.Internal("can't create password", err)
 	}
-	body := &entity.User{
-		Id:       id,
-		Username: model.Username,
-		Email:    model.Email,
-		Password: password,
+	before := cloneUser(user)
+	now := time.Now()
+	user.Password = password
+	user.PasswordChangedAt = &now
+	if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+		return updateException(err)
 	}
-	if err := s.userRepo.UpdateTx(ctx, tx, body); err != nil {
-		return exception.Internal("err", err)
+	if exc := s.passwordPolicy.RecordTx(ctx, tx, user); exc != nil {
+		return exc
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditUserPasswordChange, id, before, user); exc != nil {
+		return exc
 	}
 	if err := tx.Commit().Error; err != nil {
 		return exception.Internal("commit transaction", err)
 	}
-	return nil
+	// Whoever knew the old password may still hold a session.
+	return s.tokenService.RevokeUserSessions(ctx, user.Id)
 }
 
 func (s *UserServiceImpl) Delete(
-	ctx context.Context, id string,
+	ctx context.Context, id string, version int64, actor entity.AuditActor,
 ) *exception.Exception {
 	tx := s.db.Begin()
 	defer tx.Rollback()
@@ -168,19 +399,219 @@ func (s *UserServiceImpl) Delete(
 	if err != nil {
 		return exception.InvalidArgument("invalid user id, must be uuid")
 	}
+	// The row stays locked so it can't change between the version check and the delete.
+	user, err := s.userRepo.FindByID(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), id)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if user == nil {
+		return exception.NotFound("user not found")
+	}
+	if exc := checkVersion(user, version); exc != nil {
+		return exc
+	}
 	if err := s.userRepo.DeleteByIDTx(ctx, tx, id); err != nil {
 		return exception.Internal("err", err)
 	}
+	deleted := cloneUser(user)
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	deleted.Version++
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditUserDelete, id, user, deleted); exc != nil {
+		return exc
+	}
+	// The row stays around for restore, the tokens issued to it must not. They
+	// are revoked in tx as the user can't be looked up once it's deleted.
+	if exc := s.tokenService.RevokeUserSessionsTx(ctx, tx, id); exc != nil {
+		return exc
+	}
 	if err := tx.Commit().Error; err != nil {
 		return exception.Internal("commit transaction", err)
 	}
 	return nil
 }
 
+func (s *UserServiceImpl) Restore(ctx context.Context, id string, actor entity.AuditActor) (
+	*entity.User, *exception.Exception,
+) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	user, err := s.userRepo.FindByID(ctx, tx.Unscoped(), id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	if !user.DeletedAt.Valid {
+		return user, nil
+	}
+	// Deleted users don't hold on to their username and email, someone may
+	// have registered with them since.
+	if user.Username != "" {
+		duplicateCheck, err := s.userRepo.FindByName(ctx, tx, "username", user.Username)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if duplicateCheck != nil {
+			return nil, exception.PermissionDenied("username is in use by another user")
+		}
+	}
+	if user.Email != "" {
+		duplicateCheck, err := s.userRepo.FindByName(ctx, tx, "email", user.Email)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if duplicateCheck != nil {
+			return nil, exception.PermissionDenied("email is in use by another user")
+		}
+	}
+	before := cloneUser(user)
+	user.DeletedAt = gorm.DeletedAt{}
+	if err := s.userRepo.UpdateTx(ctx, tx.Unscoped(), user); err != nil {
+		return nil, updateException(err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, entity.AuditUserRestore, id, before, user); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return user, nil
+}
+
+func (s *UserServiceImpl) Suspend(
+	ctx context.Context, id string, model *entity.SuspendRequest, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	now := time.Now()
+	if model.ExpiresAt != nil && !model.ExpiresAt.After(now) {
+		return nil, exception.InvalidArgument("expires_at must be in the future")
+	}
+	if id == actor.UserId {
+		return nil, exception.PermissionDenied("you can't suspend your own account")
+	}
+	status, action := entity.UserStatusSuspended, entity.AuditUserSuspend
+	if model.Ban {
+		status, action = entity.UserStatusBanned, entity.AuditUserBan
+	}
+	user, exc := s.setStatus(ctx, id, status, model.Reason, model.ExpiresAt, action, actor, now)
+	if exc != nil {
+		return nil, exc
+	}
+	// Signed in clients are turned away by their next request, this also ends
+	// the refresh tokens so they don't come back once the suspension is over.
+	if exc := s.tokenService.RevokeUserSessions(ctx, id); exc != nil {
+		return nil, exc
+	}
+	return user, nil
+}
+
+func (s *UserServiceImpl) Reactivate(
+	ctx context.Context, id string, model *entity.ReactivateRequest, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	return s.setStatus(ctx, id, entity.UserStatusActive, model.Reason, nil, entity.AuditUserReactivate, actor, time.Now())
+}
+
+// setStatus moves the user to status if the transition is allowed.
+func (s *UserServiceImpl) setStatus(
+	ctx context.Context, id, status, reason string, expiresAt *time.Time, action string,
+	actor entity.AuditActor, now time.Time,
+) (*entity.User, *exception.Exception) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, exception.InvalidArgument("invalid user id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	user, err := s.userRepo.FindByID(ctx, tx, id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	// Reactivating an active user changes nothing, one whose suspension ran
+	// out is still stored as suspended and gets cleared.
+	if status == entity.UserStatusActive && user.StatusAt(now) == status && user.StatusExpiresAt == nil {
+		return user, nil
+	}
+	if !user.CanTransition(status) {
+		return nil, exception.InvalidArgument("user is " + user.StatusAt(now) + " and can't become " + status)
+	}
+	before := cloneUser(user)
+	user.Status = status
+	user.StatusReason = reason
+	user.StatusExpiresAt = expiresAt
+	if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+		return nil, updateException(err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, action, id, before, user); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return user, nil
+}
+
+func (s *UserServiceImpl) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, *exception.Exception) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	purged, err := s.userRepo.PurgeDeletedTx(ctx, tx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return 0, exception.Internal("commit transaction", err)
+	}
+	return purged, nil
+}
+
 func (s *UserServiceImpl) List(ctx context.Context, req model.ListReq) (
 	*ListUserResp, *exception.Exception,
 ) {
-	result, err := s.userRepo.FindByPagination(ctx, s.db, req.Page, req.Order, req.Filter)
+	for _, filter := range req.Filter {
+		switch filter.Field {
+		case "status":
+			for _, status := range strings.Split(filter.Value, ",") {
+				if !entity.IsValidUserStatus(status) {
+					return nil, exception.InvalidArgument("unknown status " + status)
+				}
+			}
+		case "group":
+			if filter.Operator != "=" && filter.Operator != "in" {
+				return nil, exception.InvalidArgument("group can only be filtered with eq or in")
+			}
+			for _, group := range strings.Split(filter.Value, ",") {
+				if _, err := uuid.Parse(group); err != nil {
+					return nil, exception.InvalidArgument("invalid group id, must be uuid")
+				}
+			}
+		}
+	}
+	filter, err := req.Filter.Resolve(userFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	if req.Order.OrderBy == "group" {
+		return nil, exception.InvalidArgument("cannot sort on group")
+	}
+	order, err := req.Order.Resolve(userFilterColumns)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	db := s.db
+	if req.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	result, err := s.userRepo.FindByPagination(ctx, db, req.Page, order, filter)
 	if err != nil {
 		return nil, exception.Internal("failed to get User", err)
 	}
@@ -196,14 +627,172 @@ func (s *UserServiceImpl) List(ctx context.Context, req model.ListReq) (
 	}, nil
 }
 
-func (s *UserServiceImpl) FindOne(ctx context.Context, id string) (*entity.User, *exception.Exception) {
+func (s *UserServiceImpl) FindOne(ctx context.Context, id string, include ...string) (*entity.User, *exception.Exception) {
 	_, err := uuid.Parse(id)
 	if err != nil {
 		return nil, exception.InvalidArgument("invalid user id, must be uuid")
 	}
-	result, err := s.userRepo.FindByID(ctx, s.db, id)
+	preload := make([]string, 0, len(include))
+	for _, name := range include {
+		association, ok := userIncludes[name]
+		if !ok {
+			return nil, exception.InvalidArgument("unknown include " + name)
+		}
+		preload = append(preload, association)
+	}
+	result, err := s.userRepo.FindByID(ctx, s.db, id, preload...)
 	if err != nil {
 		return nil, exception.Internal("err", err)
 	}
 	return result, nil
 }
+
+func (s *UserServiceImpl) AssignRole(ctx context.Context, id string, model *entity.RoleRequest, actor entity.AuditActor) (
+	*entity.User, *exception.Exception,
+) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	if !entity.IsValidRole(model.Role) {
+		return nil, exception.InvalidArgument("unknown role " + model.Role)
+	}
+	user, exc := s.FindOne(ctx, id)
+	if exc != nil {
+		return nil, exc
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	if len(user.Roles) > 0 && user.HasRole(model.Role) {
+		return user, nil
+	}
+	before := cloneUser(user)
+	user.Roles = append(user.Roles, model.Role)
+	return s.saveRoles(ctx, before, user, entity.AuditUserRoleAssign, actor)
+}
+
+func (s *UserServiceImpl) RevokeRole(ctx context.Context, id string, role string, actor entity.AuditActor) (
+	*entity.User, *exception.Exception,
+) {
+	user, exc := s.FindOne(ctx, id)
+	if exc != nil {
+		return nil, exc
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	roles := make([]string, 0, len(user.Roles))
+	for _, r := range user.Roles {
+		if r != role {
+			roles = append(roles, r)
+		}
+	}
+	if len(roles) == len(user.Roles) {
+		return nil, exception.NotFound("user doesn't have role " + role)
+	}
+	before := cloneUser(user)
+	user.Roles = roles
+	return s.saveRoles(ctx, before, user, entity.AuditUserRoleRevoke, actor)
+}
+
+func (s *UserServiceImpl) saveRoles(
+	ctx context.Context, before, user *entity.User, action string, actor entity.AuditActor,
+) (*entity.User, *exception.Exception) {
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.userRepo.UpdateTx(ctx, tx, user); err != nil {
+		return nil, updateException(err)
+	}
+	if exc := s.auditService.RecordTx(ctx, tx, actor, action, user.Id, before, user); exc != nil {
+		return nil, exc
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	// Tokens carry roles as claims, force clients to refresh so the change applies now.
+	if exc := s.tokenService.RevokeAccessTokens(ctx, user.Id); exc != nil {
+		return nil, exc
+	}
+	return user, nil
+}
+
+// checkVersion fails when the caller expects a version of user, from If-Match,
+// other than the current one. A zero version skips the check.
+func checkVersion(user *entity.User, version int64) *exception.Exception {
+	if version != 0 && user.Version != version {
+		return exception.PreconditionFailed("user was changed since it was read, reload it and try again")
+	}
+	return nil
+}
+
+// userStatusException turns away a user that isn't active at now.
+func userStatusException(user *entity.User, now time.Time) *exception.Exception {
+	switch user.StatusAt(now) {
+	case entity.UserStatusActive:
+		return nil
+	case entity.UserStatusSuspended:
+		if user.StatusExpiresAt != nil {
+			return exception.PermissionDenied("account is suspended until " + user.StatusExpiresAt.Format(time.RFC3339))
+		}
+		return exception.PermissionDenied("account is suspended")
+	case entity.UserStatusBanned:
+		return exception.PermissionDenied("account is banned")
+	case entity.UserStatusPending:
+		return exception.PermissionDenied("account is pending activation")
+	}
+	return exception.PermissionDenied("account is " + user.Status)
+}
+
+// updateException reports a write that lost a race against another request
+// as a failed precondition, the client should reload the record and retry.
+func updateException(err error) *exception.Exception {
+	if errors.Is(err, repository.ErrStaleVersion) {
+		return exception.PreconditionFailed("record was changed by another request, reload it and try again")
+	}
+	return exception.Internal("err", err)
+}
+
+// cloneUser copies user for the audit log's before state.
+func cloneUser(user *entity.User) *entity.User {
+	clone := *user
+	clone.Roles = append([]string(nil), user.Roles...)
+	return &clone
+}
+
+// sendEmailVerification runs after the user is committed. A delivery failure
+// is only logged, the user can ask for the email again.
+func (s *UserServiceImpl) sendEmailVerification(ctx context.Context, user *entity.User) {
+	if user.Email == "" {
+		return
+	}
+	if exc := s.accountService.SendEmailVerification(ctx, user); exc != nil {
+		slog.Error("failed to send verification email", "user_id", user.Id, "message", exc.Message)
+	}
+}
+
+// rehashPassword upgrades a hash made under an outdated policy while the
+// plaintext is at hand. The login already succeeded, so failures are only logged.
+func (s *UserServiceImpl) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	hash, err := s.signaturer.HashPassword(password)
+	if err != nil {
+		slog.Error("failed to rehash password", "user_id", user.Id, "error", err.Error())
+		return
+	}
+	if err := s.userRepo.ReplacePasswordTx(ctx, s.db, user.Id, user.Password, hash); err != nil {
+		slog.Error("failed to store rehashed password", "user_id", user.Id, "error", err.Error())
+		return
+	}
+	user.Password = hash
+}
+
+func (s *UserServiceImpl) initialRoles(model *entity.UserLogin) []string {
+	for _, admin := range s.bootstrapAdmins {
+		if admin == "" {
+			continue
+		}
+		if strings.EqualFold(admin, model.Username) || strings.EqualFold(admin, model.Email) {
+			return []string{entity.RoleAdmin}
+		}
+	}
+	return []string{entity.RoleUser}
+}
@@ -4,19 +4,33 @@ import (
 	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 	"testing"
+	"time"
 	"user-simple-crud/internal/entity"
 	"user-simple-crud/internal/mocks"
 	"user-simple-crud/internal/model"
+	"user-simple-crud/internal/repository"
 	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
 	mocksSignature "user-simple-crud/pkg/mocks"
 	"user-simple-crud/pkg/xvalidator"
 )
 
+// auditActor is who the user service tests make changes as.
+var auditActor = entity.AuditActor{
+	UserId:     "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33",
+	Username:   "admin",
+	RequestId:  "5f0c6a1e-9a4b-4c8e-8a43-0e2f4b7f5d21",
+	ClientInfo: entity.ClientInfo{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"},
+}
+
 func setupSQLMock(t *testing.T) (sqlmock.Sqlmock, *gorm.DB) {
 	// Setup SQL mock
 	db, mockSql, err := sqlmock.New()
@@ -54,18 +68,159 @@ func TestCreateUser(t *testing.T) {
 		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
 		mockRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockSignaturer.On("HashBscryptPassword", request.Password).Return("$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", nil)
+		mockSignaturer.On("HashPassword", request.Password).Return("$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockAccountService.On("SendEmailVerification", mockAppCtx, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Email == request.Email && user.EmailVerifiedAt == nil
+		})).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserCreate, mock.Anything, nil, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username == request.Username
+		})).Return(nil)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Create(mockAppCtx, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+	})
+
+	t.Run("CreateUser Pending Until Verified", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "SecurePass123!",
+			Email:    "john@example.com",
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		mockRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Status == entity.UserStatusPending
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("HashPassword", request.Password).Return("$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockAccountService.On("SendEmailVerification", mockAppCtx, mock.Anything).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserCreate, mock.Anything, nil, mock.Anything).Return(nil)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, true)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Create(mockAppCtx, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("CreateUser Bootstrap Admin", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserLogin{
+			Username: "root",
+			Password: "SecurePass123!",
+			Email:    "root@example.com",
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		mockRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.HasRole(entity.RoleAdmin)
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("HashPassword", request.Password).Return("$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", nil)
 
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockAccountService.On("SendEmailVerification", mockAppCtx, mock.Anything).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, []string{"ROOT@example.com"}, false)
 
 		// Call the function under test
 		mockSql.ExpectBegin()
 		mockSql.ExpectCommit()
-		errService := mockService.Create(mockAppCtx, request)
+		errService := mockService.Create(mockAppCtx, request, auditActor)
 
 		// Assert the result
 		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("CreateUser Password Policy Violated", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "john_doe1!",
+			Email:    "john@example.com",
+		}
+		violations := map[string][]xvalidator.PasswordViolation{"password": {
+			{Rule: xvalidator.PasswordRuleUppercase, Message: "must contain an uppercase letter"},
+			{Rule: xvalidator.PasswordRuleUserInfo, Message: "must not contain the username or email"},
+		}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username == request.Username && user.Email == request.Email
+		}), request.Password).Return(exception.InvalidArgument(violations))
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Create(mockAppCtx, request, auditActor)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Equal(t, violations, errService.Message)
+		mockRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("CreateUser Username and Email Empty", func(t *testing.T) {
@@ -79,12 +234,18 @@ func TestCreateUser(t *testing.T) {
 		mockRepository := new(mocks.UserRepository)
 		validate, _ := xvalidator.NewValidator()
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
 		mockSql.ExpectBegin()
 		mockSql.ExpectRollback()
-		errService := mockService.Create(mockAppCtx, request)
+		errService := mockService.Create(mockAppCtx, request, auditActor)
 
 		// Assert the result
 		assert.NotNil(t, errService)
@@ -110,12 +271,18 @@ func TestCreateUser(t *testing.T) {
 
 		validate, _ := xvalidator.NewValidator()
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
 		mockSql.ExpectBegin()
 		mockSql.ExpectRollback()
-		errService := mockService.Create(mockAppCtx, request)
+		errService := mockService.Create(mockAppCtx, request, auditActor)
 
 		// Assert the result
 		assert.NotNil(t, errService)
@@ -124,6 +291,8 @@ func TestCreateUser(t *testing.T) {
 
 func TestLoginUser(t *testing.T) {
 	mockAppCtx := context.Background()
+	clientIP := "203.0.113.7"
+	client := entity.ClientInfo{IpAddress: clientIP, UserAgent: "Mozilla/5.0"}
 
 	t.Run("LoginUser Success", func(t *testing.T) {
 		// Set up input
@@ -142,395 +311,2295 @@ func TestLoginUser(t *testing.T) {
 		}
 		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockSignaturer.On("CheckBscryptPasswordHash", request.Password, existingUser.Password).Return(true)
-		mockSignaturer.On("GenerateJWT", existingUser.Username).Return("jwt_token", nil)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, existingUser.Id).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(false, nil)
+		mockTokenService.On("Issue", mockAppCtx, existingUser, client).Return(&service.UserLoginResponse{
+			Username:     existingUser.Username,
+			Token:        "jwt_token",
+			RefreshToken: "refresh_token",
+		}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Expired", existingUser, mock.Anything).Return(false)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.Login(mockAppCtx, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
 		assert.Nil(t, errService)
 		assert.NotNil(t, result)
 	})
 
-	t.Run("LoginUser By Email Success", func(t *testing.T) {
+	t.Run("LoginUser Rehashes Outdated Password", func(t *testing.T) {
 		// Set up input
 		request := &entity.UserLogin{
-			Email:    "john@example.com",
+			Username: "john_doe",
 			Password: "SecurePass123!",
 		}
+		newHash := "$argon2id$v=19$m=65536,t=3,p=2$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaGhhc2hoYXNoaGFzaGhhc2g"
 
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
 		existingUser := &entity.User{
 			Id:       "123e4567-e89b-12d3-a456-426614174000",
-			Email:    "john@example.com",
+			Username: "john_doe",
 			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
 		}
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "").Return(nil, nil)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(existingUser, nil)
+		oldHash := existingUser.Password
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
+		mockRepository.On("ReplacePasswordTx", mockAppCtx, mock.Anything, existingUser.Id, oldHash, newHash).Return(nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockSignaturer.On("CheckBscryptPasswordHash", request.Password, existingUser.Password).Return(true)
-		mockSignaturer.On("GenerateJWT", existingUser.Username).Return("jwt_token", nil)
+		mockSignaturer.On("CheckPasswordHash", request.Password, oldHash).Return(true, true)
+		mockSignaturer.On("HashPassword", request.Password).Return(newHash, nil)
 
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, existingUser.Id).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(false, nil)
+		mockTokenService.On("Issue", mockAppCtx, existingUser, client).Return(&service.UserLoginResponse{
+			Username: existingUser.Username,
+			Token:    "jwt_token",
+		}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.Password).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockPasswordPolicyService.On("Expired", existingUser, mock.Anything).Return(false)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.Login(mockAppCtx, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
 		assert.Nil(t, errService)
 		assert.NotNil(t, result)
-		assert.Equal(t, "jwt_token", result.Token)
+		assert.Equal(t, newHash, existingUser.Password)
+		mockRepository.AssertExpectations(t)
 	})
 
-	t.Run("LoginUser Username/Email Not Found", func(t *testing.T) {
+	t.Run("LoginUser By Email Success", func(t *testing.T) {
 		// Set up input
 		request := &entity.UserLogin{
-			Username: "non_existent",
+			Email:    "john@example.com",
 			Password: "SecurePass123!",
 		}
 
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		existingUser := &entity.User{
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
+			Email:    "john@example.com",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "").Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(existingUser, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, existingUser.Id).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(false, nil)
+		mockTokenService.On("Issue", mockAppCtx, existingUser, client).Return(&service.UserLoginResponse{
+			Username:     existingUser.Username,
+			Token:        "jwt_token",
+			RefreshToken: "refresh_token",
+		}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Expired", existingUser, mock.Anything).Return(false)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.Login(mockAppCtx, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.NotNil(t, errService)
-		assert.Nil(t, result)
+		assert.Nil(t, errService)
+		assert.NotNil(t, result)
+		assert.Equal(t, "jwt_token", result.Token)
 	})
-}
 
-func TestUpdateUser(t *testing.T) {
-	mockAppCtx := context.Background()
-
-	t.Run("UpdateUser Success", func(t *testing.T) {
+	t.Run("LoginUser MFA Required", func(t *testing.T) {
 		// Set up input
 		request := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Email:    "john_doe@example.com",
-			Password: "NewSecurePass123!",
+			Username: "john_doe",
+			Password: "SecurePass123!",
 		}
-		id := "123e4567-e89b-12d3-a456-426614174000"
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
-		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		existingUser := &entity.User{
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
+			Username: "john_doe",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockSignaturer.On("HashBscryptPassword", request.Password).Return("$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", nil)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(true, nil)
+		mockMFAService.On("Challenge", mockAppCtx, existingUser).Return(&service.UserLoginResponse{
+			Username:    existingUser.Username,
+			MFARequired: true,
+			MFAToken:    "mfa_token",
+		}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectCommit()
-		errService := mockService.Update(mockAppCtx, id, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
 		assert.Nil(t, errService)
+		assert.True(t, result.MFARequired)
+		assert.Empty(t, result.Token)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+		mockLockoutService.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
 	})
 
-	t.Run("UpdateUser Invalid UUID", func(t *testing.T) {
-		// Set up input with invalid UUID
+	t.Run("LoginUser Password Expired", func(t *testing.T) {
+		// Set up input
 		request := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Email:    "john_doe@example.com",
-			Password: "NewSecurePass123!",
+			Username: "john_doe",
+			Password: "SecurePass123!",
 		}
-		id := "invalid-uuid"
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
+		changedAt := time.Now().AddDate(0, -6, 0)
+		existingUser := &entity.User{
+			Id:                "123e4567-e89b-12d3-a456-426614174000",
+			Username:          "john_doe",
+			Password:          "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+			PasswordChangedAt: &changedAt,
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
+
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, existingUser.Id).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(false, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Expired", existingUser, mock.Anything).Return(true)
+		mockPasswordPolicyService.On("ChangeRequired", mockAppCtx, existingUser).Return(&service.UserLoginResponse{
+			Username:               existingUser.Username,
+			PasswordChangeRequired: true,
+			PasswordChangeToken:    "change_token",
+		}, nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectRollback()
-		errService := mockService.Update(mockAppCtx, id, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.NotNil(t, errService)
+		assert.Nil(t, errService)
+		assert.True(t, result.PasswordChangeRequired)
+		assert.Empty(t, result.Token)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("UpdateUser Username Exists", func(t *testing.T) {
-		// Set up input
+	t.Run("LoginUser Skips Policy For Existing Password", func(t *testing.T) {
+		// Set up input, a password that no longer meets the policy
 		request := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Email:    "john_doe@example.com",
-			Password: "NewSecurePass123!",
+			Username: "john_doe",
+			Password: "legacy",
 		}
-		id := "123e4567-e89b-12d3-a456-426614174000"
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
 		existingUser := &entity.User{
-			Id:       "different-id",
-			Username: "john_doe_updated",
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
+			Username: "john_doe",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
 		}
 		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
-		validate, _ := xvalidator.NewValidator()
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
+
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, existingUser.Id).Return(nil)
+		mockMFAService.On("Enabled", mockAppCtx, existingUser.Id).Return(false, nil)
+		mockTokenService.On("Issue", mockAppCtx, existingUser, client).Return(&service.UserLoginResponse{Token: "jwt_token"}, nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Expired", existingUser, mock.Anything).Return(false)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectRollback()
-		errService := mockService.Update(mockAppCtx, id, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.NotNil(t, errService)
+		require.Nil(t, errService)
+		assert.Equal(t, "jwt_token", result.Token)
+		mockPasswordPolicyService.AssertNotCalled(t, "Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("UpdateUser HashPassword Failed", func(t *testing.T) {
+	t.Run("LoginUser Email Not Verified", func(t *testing.T) {
 		// Set up input
 		request := &entity.UserLogin{
-			Username: "john_doe_updated",
-			Email:    "john_doe@example.com",
-			Password: "NewSecurePass123!",
+			Username: "john_doe",
+			Password: "SecurePass123!",
 		}
-		id := "123e4567-e89b-12d3-a456-426614174000"
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
-		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+		existingUser := &entity.User{
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
+			Username: "john_doe",
+			Email:    "john_doe@example.com",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockSignaturer.On("HashBscryptPassword", request.Password).Return("", errors.New("hash error"))
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, true)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectRollback()
-		errService := mockService.Update(mockAppCtx, id, request)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
 		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		assert.Nil(t, result)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
 	})
-}
-
-func TestDeleteUser(t *testing.T) {
-	mockAppCtx := context.Background()
 
-	t.Run("DeleteUser Success", func(t *testing.T) {
+	t.Run("LoginUser Suspended", func(t *testing.T) {
 		// Set up input
-		id := "123e4567-e89b-12d3-a456-426614174000"
+		request := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "SecurePass123!",
+		}
+		expiresAt := time.Now().Add(24 * time.Hour)
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("DeleteByIDTx", mockAppCtx, mock.Anything, id).Return(nil)
+		existingUser := &entity.User{
+			Id:              "123e4567-e89b-12d3-a456-426614174000",
+			Username:        "john_doe",
+			Password:        "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+			Status:          entity.UserStatusSuspended,
+			StatusExpiresAt: &expiresAt,
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(true, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectCommit()
-		errService := mockService.Delete(mockAppCtx, id)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.Nil(t, errService)
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		assert.Contains(t, errService.Message, "suspended until")
+		assert.Nil(t, result)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("DeleteUser Invalid UUID", func(t *testing.T) {
-		// Set up input with invalid UUID
-		id := "invalid-uuid"
+	t.Run("LoginUser Username/Email Not Found", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserLogin{
+			Username: "non_existent",
+			Password: "SecurePass123!",
+		}
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", request.Email).Return(nil, nil)
+
 		validate, _ := xvalidator.NewValidator()
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, "", clientIP).Return(nil)
+		mockLockoutService.On("RecordFailure", mockAppCtx, "", clientIP).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectRollback()
-		errService := mockService.Delete(mockAppCtx, id)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
 		assert.NotNil(t, errService)
+		assert.Nil(t, result)
+		mockLockoutService.AssertExpectations(t)
 	})
 
-	t.Run("DeleteUser Repository Error", func(t *testing.T) {
+	t.Run("LoginUser Wrong Password", func(t *testing.T) {
 		// Set up input
-		id := "123e4567-e89b-12d3-a456-426614174000"
+		request := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "WrongPass123!",
+		}
 
 		// Mocks
-		mockSql, gormDB := setupSQLMock(t)
+		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("DeleteByIDTx", mockAppCtx, mock.Anything, id).Return(errors.New("test error"))
+		existingUser := &entity.User{
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
+			Username: "john_doe",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
+		}
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.Password, existingUser.Password).Return(false, false)
 
 		validate, _ := xvalidator.NewValidator()
-		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockLockoutService.On("RecordFailure", mockAppCtx, existingUser.Id, clientIP).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		mockSql.ExpectBegin()
-		mockSql.ExpectRollback()
-		errService := mockService.Delete(mockAppCtx, id)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.NotNil(t, errService)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		assert.Nil(t, result)
+		mockLockoutService.AssertExpectations(t)
+		mockLockoutService.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
 	})
-}
-
-func TestFindOneUser(t *testing.T) {
-	mockAppCtx := context.Background()
 
-	t.Run("FindOneUser Success", func(t *testing.T) {
+	t.Run("LoginUser Account Locked", func(t *testing.T) {
 		// Set up input
-		id := "123e4567-e89b-12d3-a456-426614174000"
+		request := &entity.UserLogin{
+			Username: "john_doe",
+			Password: "SecurePass123!",
+		}
 
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
 		existingUser := &entity.User{
-			Id:       id,
+			Id:       "123e4567-e89b-12d3-a456-426614174000",
 			Username: "john_doe",
-			Email:    "john_doe@example.com",
+			Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu", // Hashed password
 		}
-		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
-		validate, _ := xvalidator.NewValidator()
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", request.Username).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, existingUser.Id, clientIP).Return(exception.Locked("account is temporarily locked, try again later", time.Minute))
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.FindOne(mockAppCtx, id)
+		result, errService := mockService.Login(mockAppCtx, request, client)
 
 		// Assert the result
-		assert.Nil(t, errService)
-		assert.NotNil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.LockedCode, errService.Code)
+		assert.Equal(t, time.Minute, errService.RetryAfter)
+		assert.Nil(t, result)
+		mockSignaturer.AssertNotCalled(t, "CheckPasswordHash", mock.Anything, mock.Anything)
 	})
+}
 
-	t.Run("FindOneUser Invalid UUID", func(t *testing.T) {
-		// Set up input with invalid UUID
-		id := "invalid-uuid"
+func TestUpdateUser(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("UpdateUser Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe_updated", Email: "john_doe@example.com"}}
+		existingUser := &entity.User{
+			Id: id, Username: "john_doe", Email: "john_doe@example.com", Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu",
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "john_doe_updated").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username == "john_doe_updated" && user.Password == "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu"
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserUpdate, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Update(mockAppCtx, id, request, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "john_doe_updated", result.Username)
+		mockRepository.AssertExpectations(t)
+		mockSignaturer.AssertNotCalled(t, "HashPassword", mock.Anything)
+	})
+
+	t.Run("UpdateUser Password Refused", func(t *testing.T) {
+		// Set up input
+		request := &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe"}, Password: "NewSecurePass123!"}
 
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
 		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.Update(mockAppCtx, id, request, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateUser Not Found", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.FindOne(mockAppCtx, id)
+		result, errService := mockService.Update(mockAppCtx, id, &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe"}}, 0, auditActor)
 
 		// Assert the result
-		assert.NotNil(t, errService)
 		assert.Nil(t, result)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
 	})
 
-	t.Run("FindOneUser Repository Error", func(t *testing.T) {
-		// Set up input
-		id := "123e4567-e89b-12d3-a456-426614174000"
+	t.Run("UpdateUser Stale Version", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Version: 3}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.Update(mockAppCtx, id, &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "jane_doe"}}, 2, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.PreconditionCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
 
+	t.Run("UpdateUser Invalid UUID", func(t *testing.T) {
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, errors.New("test error"))
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.Update(mockAppCtx, "invalid-uuid", &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "john_doe"}}, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
 
+	t.Run("UpdateUser Username Exists", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "jane_doe").Return(&entity.User{Id: "other-id", Username: "jane_doe"}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
 		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.Update(mockAppCtx, id, &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "jane_doe"}}, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateUser Repository Error", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "jane_doe").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.Anything).Return(errors.New("update failed"))
 		mockSignaturer := new(mocksSignature.Signaturer)
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.FindOne(mockAppCtx, id)
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Update(mockAppCtx, id, &entity.UserUpdateRequest{UserProfile: entity.UserProfile{Username: "jane_doe"}}, 0, auditActor)
 
 		// Assert the result
-		assert.NotNil(t, errService)
 		assert.Nil(t, result)
+		assert.Equal(t, exception.InternalErrorCode, errService.Code)
+		mockAuditService.AssertNotCalled(t, "RecordTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
 
-func TestListUser(t *testing.T) {
+func TestPatchUser(t *testing.T) {
 	mockAppCtx := context.Background()
-	req := model.ListReq{
-		Page: model.PaginationParam{
-			Page:     1,
-			PageSize: 1,
-		},
-		Order: model.OrderParam{
-			Order:   "username",
-			OrderBy: "asc",
-		},
-	}
-	users := []*entity.User{
-		{
-			Id:       "0b8d3f3d-d343-4390-964c-4f05c4c803d6",
-			Username: "john_doe",
-			Email:    "john@example.com",
-		},
-	}
+	id := "123e4567-e89b-12d3-a456-426614174000"
 
-	t.Run("ListUser Success", func(t *testing.T) {
-		// Setup the expected response from the repository
-		response := &model.PaginationData[entity.User]{
-			Page:             1,
-			PageSize:         1,
-			TotalPage:        1,
-			TotalDataPerPage: 1,
-			TotalData:        1,
-			Data:             users,
+	t.Run("PatchUser Success", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"username":"john_doe_updated"}`)
+		verifiedAt := time.Now().Add(-time.Hour)
+		existingUser := &entity.User{
+			Id: id, Username: "john_doe", Email: "john_doe@example.com", Password: "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu",
+			Roles: []string{entity.RoleUser}, EmailVerifiedAt: &verifiedAt,
+		}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "john_doe_updated").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username == "john_doe_updated" && user.Email == "john_doe@example.com" &&
+				user.Password == "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu" && user.EmailVerifiedAt != nil
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "john_doe_updated", result.Username)
+		mockRepository.AssertExpectations(t)
+		// The unchanged email is not checked for uniqueness
+		mockRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, "email", mock.Anything)
+		mockSignaturer.AssertNotCalled(t, "HashPassword", mock.Anything)
+	})
+
+	t.Run("PatchUser Email Changed", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"email":"john_new@example.com"}`)
+		verifiedAt := time.Now().Add(-time.Hour)
+		existingUser := &entity.User{
+			Id: id, Username: "john_doe", Email: "john_doe@example.com", Roles: []string{entity.RoleUser}, EmailVerifiedAt: &verifiedAt,
 		}
 
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", "john_new@example.com").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Email == "john_new@example.com" && user.EmailVerifiedAt == nil
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockAccountService.On("SendEmailVerification", mockAppCtx, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Email == "john_new@example.com"
+		})).Return(nil)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, "john_new@example.com", result.Email)
+		mockRepository.AssertExpectations(t)
+		mockAccountService.AssertExpectations(t)
+		mockRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, "username", mock.Anything)
+	})
+
+	t.Run("PatchUser Null Clears Email", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"email":null}`)
+		existingUser := &entity.User{Id: id, Username: "john_doe", Email: "john_doe@example.com", Roles: []string{entity.RoleUser}}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Username == "john_doe" && user.Email == ""
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockAccountService.On("SendEmailVerification", mockAppCtx, mock.Anything).Return(nil)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Empty(t, result.Email)
+		mockRepository.AssertExpectations(t)
+		mockRepository.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Nothing Changed", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"username":"john_doe"}`)
+		existingUser := &entity.User{Id: id, Username: "john_doe", Email: "john_doe@example.com", Roles: []string{entity.RoleUser}}
+
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).Return(response, nil)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.List(mockAppCtx, req)
+		result, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
 
 		// Assert the result
 		assert.Nil(t, errService)
-		assert.NotNil(t, result)
+		assert.Equal(t, existingUser, result)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
 	})
 
-	t.Run("ListUser Failed Repository", func(t *testing.T) {
+	t.Run("PatchUser Password Rejected", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"password":"NewSecurePass123!"}`)
+
 		// Mocks
 		_, gormDB := setupSQLMock(t)
 		mockRepository := new(mocks.UserRepository)
-		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).Return(nil, errors.New("test error"))
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Roles: []string{entity.RoleUser}}, nil)
 		mockSignaturer := new(mocksSignature.Signaturer)
 		validate, _ := xvalidator.NewValidator()
-		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, validate)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
 
 		// Call the function under test
-		result, errService := mockService.List(mockAppCtx, req)
+		result, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		assert.Nil(t, result)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Both Cleared", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"username":null,"email":null}`)
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", Email: "john_doe@example.com", Roles: []string{entity.RoleUser},
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("PatchUser Invalid JSON", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Roles: []string{entity.RoleUser}}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, id, []byte(`{"username":`), 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("PatchUser Invalid UUID", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, "invalid-uuid", []byte(`{"username":"john_doe_updated"}`), 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("PatchUser Username Exists", func(t *testing.T) {
+		// Set up input
+		patch := []byte(`{"username":"jane_doe"}`)
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Roles: []string{entity.RoleUser}}, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "jane_doe").Return(&entity.User{
+			Id: "7c9e6679-7425-40de-944b-e07fc1f90ae7", Username: "jane_doe",
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, id, patch, 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Not Found", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, id, []byte(`{"username":"john_doe_updated"}`), 0, auditActor)
 
 		// Assert the result
 		assert.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+
+	t.Run("PatchUser Stale Version", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Version: 3}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Patch(mockAppCtx, id, []byte(`{"username":"john_doe_updated"}`), 2, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PreconditionCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PatchUser Concurrent Update", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Version: 3}, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "john_doe_updated").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.Anything).Return(repository.ErrStaleVersion)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		_, errService := mockService.Patch(mockAppCtx, id, []byte(`{"username":"john_doe_updated"}`), 3, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PreconditionCode, errService.Code)
+		mockAuditService.AssertNotCalled(t, "RecordTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+	clientIP := "203.0.113.7"
+	actor := entity.AuditActor{UserId: id, Username: "john_doe", ClientInfo: entity.ClientInfo{IpAddress: clientIP, UserAgent: "Mozilla/5.0"}}
+	currentHash := "$2a$12$eixZaYVK1fsbw1ZfbX3OXe.PZyWJQ0Zf10hErsTQ6FVRHiA2vwLHu"
+
+	t.Run("ChangePassword Success", func(t *testing.T) {
+		// Set up input
+		request := &entity.ChangePasswordRequest{CurrentPassword: "SecurePass123!", NewPassword: "NewSecurePass123!"}
+		changedAt := time.Now().Add(-24 * time.Hour)
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", Password: currentHash, Roles: []string{entity.RoleUser}, PasswordChangedAt: &changedAt,
+		}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Password == "$2a$12$newhash" && user.PasswordChangedAt.After(changedAt)
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.CurrentPassword, currentHash).Return(true, false)
+		mockSignaturer.On("HashPassword", request.NewPassword).Return("$2a$12$newhash", nil)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("RevokeUserSessions", mockAppCtx, id).Return(nil)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, id).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, actor, entity.AuditUserPasswordChange, id, mock.Anything, mock.Anything).Return(nil)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.NewPassword).Return(nil)
+		mockPasswordPolicyService.On("RecordTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.ChangePassword(mockAppCtx, id, request, actor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockRepository.AssertExpectations(t)
+		mockPasswordPolicyService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("ChangePassword HashPassword Failed", func(t *testing.T) {
+		// Set up input
+		request := &entity.ChangePasswordRequest{CurrentPassword: "SecurePass123!", NewPassword: "NewSecurePass123!"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", Password: currentHash, Roles: []string{entity.RoleUser},
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.CurrentPassword, currentHash).Return(true, false)
+		mockSignaturer.On("HashPassword", request.NewPassword).Return("", errors.New("hash failed"))
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, id).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.NewPassword).Return(nil)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.ChangePassword(mockAppCtx, id, request, actor)
+
+		// Assert the result
+		assert.Equal(t, exception.InternalErrorCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+		mockTokenService.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword Wrong Current Password", func(t *testing.T) {
+		// Set up input
+		request := &entity.ChangePasswordRequest{CurrentPassword: "WrongPass123!", NewPassword: "NewSecurePass123!"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", Password: currentHash, Roles: []string{entity.RoleUser},
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.CurrentPassword, currentHash).Return(false, false)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, id, clientIP).Return(nil)
+		mockLockoutService.On("RecordFailure", mockAppCtx, id, clientIP).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		errService := mockService.ChangePassword(mockAppCtx, id, request, actor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockLockoutService.AssertExpectations(t)
+		mockSignaturer.AssertNotCalled(t, "HashPassword", mock.Anything)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword Locked", func(t *testing.T) {
+		// Set up input
+		request := &entity.ChangePasswordRequest{CurrentPassword: "SecurePass123!", NewPassword: "NewSecurePass123!"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, id, clientIP).Return(exception.Locked("account is temporarily locked", time.Minute))
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		errService := mockService.ChangePassword(mockAppCtx, id, request, actor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.LockedCode, errService.Code)
+		mockSignaturer.AssertNotCalled(t, "CheckPasswordHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ChangePassword Policy Violation", func(t *testing.T) {
+		// Set up input
+		request := &entity.ChangePasswordRequest{CurrentPassword: "SecurePass123!", NewPassword: "short"}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", Password: currentHash, Roles: []string{entity.RoleUser},
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockSignaturer.On("CheckPasswordHash", request.CurrentPassword, currentHash).Return(true, false)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockLockoutService.On("Check", mockAppCtx, id, clientIP).Return(nil)
+		mockLockoutService.On("RecordSuccess", mockAppCtx, id).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockPasswordPolicyService.On("Validate", mockAppCtx, mock.Anything, mock.Anything, request.NewPassword).
+			Return(exception.InvalidArgument("password is too short"))
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.ChangePassword(mockAppCtx, id, request, actor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("DeleteUser Success", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+		mockRepository.On("DeleteByIDTx", mockAppCtx, mock.Anything, id).Return(nil)
+
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("RevokeUserSessionsTx", mockAppCtx, mock.Anything, id).Return(nil)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserDelete, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		errService := mockService.Delete(mockAppCtx, id, 0, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		mockTokenService.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteUser Revokes Sessions In Database", func(t *testing.T) {
+		// Set up a real database, the mocked TokenService can't show that the
+		// tokens of a user deleted in the same request are revoked
+		db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&entity.User{}, &entity.Group{}, &entity.RefreshToken{}, &entity.Session{}, &entity.AuditLog{}))
+		user := &entity.User{Id: "123e4567-e89b-12d3-a456-426614174000", Username: "john_doe", Email: "john_doe@example.com"}
+		session := &entity.Session{Id: "3d8f1c2e-5b6a-4c7d-8e9f-0a1b2c3d4e5f", UserId: user.Id, ExpiresAt: time.Now().Add(time.Hour)}
+		refreshToken := &entity.RefreshToken{
+			Id: "0b9e2d1c-6a55-4f5e-9d0f-0b4e0e7f2c11", UserId: user.Id, FamilyId: session.Id,
+			TokenHash: "refresh_token_hash", ExpiresAt: time.Now().Add(time.Hour),
+		}
+		require.NoError(t, db.Create(user).Error)
+		require.NoError(t, db.Create(session).Error)
+		require.NoError(t, db.Create(refreshToken).Error)
+
+		userRepository := repository.NewUserSQLRepository()
+		validate, _ := xvalidator.NewValidator()
+		tokenService := service.NewTokenService(
+			db, userRepository, repository.NewRefreshTokenSQLRepository(), repository.NewTokenRevocationMemoryRepository(),
+			repository.NewSessionSQLRepository(), new(mocksSignature.Signaturer), validate, time.Hour, 0,
+		)
+		auditService := service.NewAuditService(db, repository.NewAuditSQLRepository())
+		userService := service.NewUserService(db, userRepository, new(mocksSignature.Signaturer), tokenService, new(mocks.AccountService), new(mocks.MFAService), new(mocks.LockoutService), new(mocks.PasswordPolicyService), auditService, validate, nil, false)
+
+		// Call the function under test
+		errService := userService.Delete(mockAppCtx, user.Id, 0, auditActor)
+		require.Nil(t, errService)
+		_, errService = userService.Restore(mockAppCtx, user.Id, auditActor)
+		require.Nil(t, errService)
+
+		// Assert the result
+		var storedToken entity.RefreshToken
+		require.NoError(t, db.First(&storedToken, "id = ?", refreshToken.Id).Error)
+		assert.NotNil(t, storedToken.RevokedAt)
+		var storedSession entity.Session
+		require.NoError(t, db.First(&storedSession, "id = ?", session.Id).Error)
+		assert.NotNil(t, storedSession.RevokedAt)
+	})
+
+	t.Run("DeleteUser Invalid UUID", func(t *testing.T) {
+		// Set up input with invalid UUID
+		id := "invalid-uuid"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Delete(mockAppCtx, id, 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+	})
+
+	t.Run("DeleteUser Repository Error", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+		mockRepository.On("DeleteByIDTx", mockAppCtx, mock.Anything, id).Return(errors.New("test error"))
+
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Delete(mockAppCtx, id, 0, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+	})
+
+	t.Run("DeleteUser Stale Version", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe", Version: 3}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		errService := mockService.Delete(mockAppCtx, id, 2, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PreconditionCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "DeleteByIDTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRestoreUser(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("RestoreUser Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		deletedUser := &entity.User{
+			Id: id, Username: "john_doe", Email: "john_doe@example.com",
+			DeletedAt: gorm.DeletedAt{Time: time.Now().Add(-time.Hour), Valid: true},
+		}
+		mockRepository.On("FindByID", mockAppCtx, mock.MatchedBy(func(db *gorm.DB) bool {
+			return db.Statement.Unscoped
+		}), id).Return(deletedUser, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "john_doe").Return(nil, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "email", "john_doe@example.com").Return(nil, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return !user.DeletedAt.Valid
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserRestore, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Restore(mockAppCtx, id, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.False(t, result.DeletedAt.Valid)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("RestoreUser Username Taken", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{
+			Id: id, Username: "john_doe", DeletedAt: gorm.DeletedAt{Time: time.Now().Add(-time.Hour), Valid: true},
+		}, nil)
+		mockRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "john_doe").Return(&entity.User{
+			Id: "7c9e6679-7425-40de-944b-e07fc1f90ae7", Username: "john_doe",
+		}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Restore(mockAppCtx, id, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		assert.Nil(t, result)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RestoreUser Not Deleted", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Username: "john_doe"}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Restore(mockAppCtx, id, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, id, result.Id)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RestoreUser Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		_, errService := mockService.Restore(mockAppCtx, id, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
+
+func TestPurgeDeletedUsers(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("PurgeDeleted Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("PurgeDeletedTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+			return time.Since(cutoff) >= 720*time.Hour && time.Since(cutoff) < 721*time.Hour
+		})).Return(int64(2), nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		purged, errService := mockService.PurgeDeleted(mockAppCtx, 720*time.Hour)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, int64(2), purged)
+		mockRepository.AssertExpectations(t)
+	})
+}
+
+func TestFindOneUser(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("FindOneUser Success", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		existingUser := &entity.User{
+			Id:       id,
+			Username: "john_doe",
+			Email:    "john_doe@example.com",
+		}
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(existingUser, nil)
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.FindOne(mockAppCtx, id)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("FindOneUser Invalid UUID", func(t *testing.T) {
+		// Set up input with invalid UUID
+		id := "invalid-uuid"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.FindOne(mockAppCtx, id)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Nil(t, result)
+	})
+
+	t.Run("FindOneUser Repository Error", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, errors.New("test error"))
+
+		validate, _ := xvalidator.NewValidator()
+		mockSignaturer := new(mocksSignature.Signaturer)
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.FindOne(mockAppCtx, id)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Nil(t, result)
+	})
+	t.Run("FindOneUser Include Groups", func(t *testing.T) {
+		// Set up input
+		id := "123e4567-e89b-12d3-a456-426614174000"
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		existingUser := &entity.User{
+			Id:     id,
+			Groups: []*entity.Group{{Id: "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", Name: "engineering"}},
+		}
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id, entity.UserGroupsAssociation).Return(existingUser, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.FindOne(mockAppCtx, id, "groups")
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Len(t, result.Groups, 1)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("FindOneUser Unknown Include", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.FindOne(mockAppCtx, "123e4567-e89b-12d3-a456-426614174000", "sessions")
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListUser(t *testing.T) {
+	mockAppCtx := context.Background()
+	req := model.ListReq{
+		Page: model.PaginationParam{
+			Page:     1,
+			PageSize: 1,
+		},
+		Order: model.OrderParam{
+			Order:   "asc",
+			OrderBy: "username",
+		},
+	}
+	users := []*entity.User{
+		{
+			Id:       "0b8d3f3d-d343-4390-964c-4f05c4c803d6",
+			Username: "john_doe",
+			Email:    "john@example.com",
+		},
+	}
+
+	t.Run("ListUser Success", func(t *testing.T) {
+		// Setup the expected response from the repository
+		response := &model.PaginationData[entity.User]{
+			Page:             1,
+			PageSize:         1,
+			TotalPage:        1,
+			TotalDataPerPage: 1,
+			TotalData:        1,
+			Data:             users,
+		}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).Return(response, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("ListUser Include Deleted", func(t *testing.T) {
+		// Setup the expected response from the repository
+		response := &model.PaginationData[entity.User]{Page: 1, PageSize: 1, TotalPage: 1, TotalDataPerPage: 1, TotalData: 1, Data: users}
+		deletedReq := req
+		deletedReq.IncludeDeleted = true
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.MatchedBy(func(db *gorm.DB) bool {
+			return db.Statement.Unscoped
+		}), req.Page, req.Order, req.Filter).Return(response, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, deletedReq)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.NotNil(t, result)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ListUser Failed Repository", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, req.Page, req.Order, req.Filter).Return(nil, errors.New("test error"))
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, req)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Nil(t, result)
+	})
+
+	t.Run("ListUser Unknown Status", func(t *testing.T) {
+		statusReq := req
+		statusReq.Filter = model.FilterParams{{Field: "status", Value: "active,deactivated", Operator: "in"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, statusReq)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListUser Unknown Filter", func(t *testing.T) {
+		filterReq := req
+		filterReq.Filter = model.FilterParams{{Field: "password", Value: "$2a$", Operator: "like"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, filterReq)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListUser Unknown Sort", func(t *testing.T) {
+		sortReq := req
+		sortReq.Order = model.OrderParam{OrderBy: "password", Order: "asc"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, sortReq)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListUser Sort By Group", func(t *testing.T) {
+		sortReq := req
+		sortReq.Order = model.OrderParam{OrderBy: "group", Order: "asc"}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, sortReq)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ListUser Group Filter", func(t *testing.T) {
+		groupReq := req
+		groupReq.Filter = model.FilterParams{{Field: "group", Value: "3c5e1f0a-7a2b-4d8e-9f61-2b7c4d9e8a10", Operator: "="}}
+		response := &model.PaginationData[entity.User]{Page: 1, PageSize: 1, Data: users}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByPagination", mockAppCtx, mock.Anything, groupReq.Page, groupReq.Order, groupReq.Filter).Return(response, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, groupReq)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Len(t, result.Data, 1)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ListUser Group Filter Operator", func(t *testing.T) {
+		groupReq := req
+		groupReq.Filter = model.FilterParams{{Field: "group", Value: "eng", Operator: "like"}}
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.List(mockAppCtx, groupReq)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "FindByPagination", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSuspendUser(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("SuspendUser Success", func(t *testing.T) {
+		// Set up input
+		expiresAt := time.Now().Add(7 * 24 * time.Hour)
+		request := &entity.SuspendRequest{Reason: "Chargeback on the last invoice", ExpiresAt: &expiresAt}
+
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Status: entity.UserStatusActive}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Status == entity.UserStatusSuspended && user.StatusReason == request.Reason && user.StatusExpiresAt == &expiresAt
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockTokenService.On("RevokeUserSessions", mockAppCtx, id).Return(nil)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserSuspend, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Suspend(mockAppCtx, id, request, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, entity.UserStatusSuspended, result.Status)
+		mockRepository.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+		mockAuditService.AssertExpectations(t)
+	})
+
+	t.Run("SuspendUser Ban", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Status: entity.UserStatusSuspended}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Status == entity.UserStatusBanned && user.StatusExpiresAt == nil
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockTokenService.On("RevokeUserSessions", mockAppCtx, id).Return(nil)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserBan, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Suspend(mockAppCtx, id, &entity.SuspendRequest{Reason: "Fraud", Ban: true}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, entity.UserStatusBanned, result.Status)
+	})
+
+	t.Run("SuspendUser Ban With Expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Suspend(mockAppCtx, id, &entity.SuspendRequest{Reason: "Fraud", Ban: true, ExpiresAt: &expiresAt}, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("SuspendUser Expiry In The Past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Suspend(mockAppCtx, id, &entity.SuspendRequest{Reason: "Spam", ExpiresAt: &expiresAt}, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("SuspendUser Self", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		_, errService := mockService.Suspend(mockAppCtx, auditActor.UserId, &entity.SuspendRequest{Reason: "Testing"}, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+	})
+
+	t.Run("SuspendUser Banned", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Status: entity.UserStatusBanned}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		_, errService := mockService.Suspend(mockAppCtx, id, &entity.SuspendRequest{Reason: "Spam"}, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReactivateUser(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("ReactivateUser Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Status: entity.UserStatusBanned, StatusReason: "Fraud"}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.Status == entity.UserStatusActive && user.StatusReason == "Appeal accepted"
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserReactivate, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.Reactivate(mockAppCtx, id, &entity.ReactivateRequest{Reason: "Appeal accepted"}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, entity.UserStatusActive, result.Status)
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("ReactivateUser Already Active", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Status: entity.UserStatusActive}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		result, errService := mockService.Reactivate(mockAppCtx, id, &entity.ReactivateRequest{Reason: "Appeal accepted"}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, entity.UserStatusActive, result.Status)
+		mockRepository.AssertNotCalled(t, "UpdateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReactivateUser Not Found", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(nil, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectRollback()
+		_, errService := mockService.Reactivate(mockAppCtx, id, &entity.ReactivateRequest{Reason: "Appeal accepted"}, auditActor)
+
+		// Assert the result
+		assert.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
+
+func TestAssignRole(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("AssignRole Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Roles: []string{entity.RoleUser}}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(user *entity.User) bool {
+			return user.HasRole(entity.RoleAdmin) && user.HasRole(entity.RoleUser)
+		})).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockTokenService.On("RevokeAccessTokens", mockAppCtx, id).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserRoleAssign, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.AssignRole(mockAppCtx, id, &entity.RoleRequest{Role: entity.RoleAdmin}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, []string{entity.RoleUser, entity.RoleAdmin}, result.Roles)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("AssignRole Unknown Role", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.AssignRole(mockAppCtx, id, &entity.RoleRequest{Role: "superuser"}, auditActor)
+
+		// Assert the result
+		assert.Nil(t, result)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+}
+
+func TestRevokeRole(t *testing.T) {
+	mockAppCtx := context.Background()
+	id := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("RevokeRole Success", func(t *testing.T) {
+		// Mocks
+		mockSql, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Roles: []string{entity.RoleUser, entity.RoleAdmin}}, nil)
+		mockRepository.On("UpdateTx", mockAppCtx, mock.Anything, mock.Anything).Return(nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockTokenService.On("RevokeAccessTokens", mockAppCtx, id).Return(nil)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockAuditService.On("RecordTx", mockAppCtx, mock.Anything, auditActor, entity.AuditUserRoleRevoke, id, mock.Anything, mock.Anything).Return(nil)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+		result, errService := mockService.RevokeRole(mockAppCtx, id, entity.RoleAdmin, auditActor)
+
+		// Assert the result
+		assert.Nil(t, errService)
+		assert.Equal(t, []string{entity.RoleUser}, result.Roles)
+	})
+
+	t.Run("RevokeRole Not Assigned", func(t *testing.T) {
+		// Mocks
+		_, gormDB := setupSQLMock(t)
+		mockRepository := new(mocks.UserRepository)
+		mockRepository.On("FindByID", mockAppCtx, mock.Anything, id).Return(&entity.User{Id: id, Roles: []string{entity.RoleUser}}, nil)
+		mockSignaturer := new(mocksSignature.Signaturer)
+		validate, _ := xvalidator.NewValidator()
+		mockTokenService := new(mocks.TokenService)
+		mockAccountService := new(mocks.AccountService)
+		mockMFAService := new(mocks.MFAService)
+		mockLockoutService := new(mocks.LockoutService)
+		mockPasswordPolicyService := new(mocks.PasswordPolicyService)
+		mockAuditService := new(mocks.AuditService)
+		mockService := service.NewUserService(gormDB, mockRepository, mockSignaturer, mockTokenService, mockAccountService, mockMFAService, mockLockoutService, mockPasswordPolicyService, mockAuditService, validate, nil, false)
+
+		// Call the function under test
+		result, errService := mockService.RevokeRole(mockAppCtx, id, entity.RoleAdmin, auditActor)
+
+		// Assert the result
 		assert.Nil(t, result)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
 	})
 }
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/webauthn"
+)
+
+// WebAuthnService registers passkeys and security keys and logs users in
+// with them. Each ceremony is started with a Begin call whose options are
+// passed to the browser, and completed with what the browser returns.
+type WebAuthnService interface {
+	// BeginRegistration returns the options for navigator.credentials.create
+	BeginRegistration(ctx context.Context, userID string) (*WebAuthnRegistrationOptions, *exception.Exception)
+	// FinishRegistration verifies the new credential and stores it for the user
+	FinishRegistration(
+		ctx context.Context, userID string, model *entity.WebAuthnRegistrationRequest,
+	) (*entity.WebAuthnCredential, *exception.Exception)
+	// BeginLogin returns the options for navigator.credentials.get
+	BeginLogin(ctx context.Context, model *entity.WebAuthnLoginRequest) (*WebAuthnLoginOptions, *exception.Exception)
+	// FinishLogin verifies the assertion and issues tokens as a password login
+	// would. A second factor is only asked for when the authenticator didn't
+	// verify the user.
+	FinishLogin(
+		ctx context.Context, model *entity.WebAuthnAssertion, client entity.ClientInfo,
+	) (*UserLoginResponse, *exception.Exception)
+	List(ctx context.Context, userID string) ([]*entity.WebAuthnCredential, *exception.Exception)
+	Remove(ctx context.Context, userID, id string) *exception.Exception
+}
+
+// WebAuthnRegistrationOptions is passed as is to navigator.credentials.create,
+// after decoding the base64url values to ArrayBuffers.
+type WebAuthnRegistrationOptions struct {
+	PublicKey *webauthn.CreationOptions `json:"publicKey"`
+}
+
+// WebAuthnLoginOptions is passed as is to navigator.credentials.get, after
+// decoding the base64url values to ArrayBuffers.
+type WebAuthnLoginOptions struct {
+	PublicKey *webauthn.RequestOptions `json:"publicKey"`
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/repository"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/webauthn"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+type WebAuthnServiceImpl struct {
+	db           *gorm.DB
+	userRepo     repository.UserRepository
+	webAuthnRepo repository.WebAuthnRepository
+	tokenService TokenService
+	mfaService   MFAService
+	rp           *webauthn.RelyingParty
+	validate     *xvalidator.Validator
+	// challengeTTL is how long the browser has to complete a ceremony
+	challengeTTL time.Duration
+}
+
+func NewWebAuthnService(
+	db *gorm.DB, userRepo repository.UserRepository,
+	webAuthnRepo repository.WebAuthnRepository,
+	tokenService TokenService,
+	mfaService MFAService,
+	rp *webauthn.RelyingParty,
+	validate *xvalidator.Validator,
+	challengeTTL time.Duration,
+) WebAuthnService {
+	return &WebAuthnServiceImpl{
+		db:           db,
+		userRepo:     userRepo,
+		webAuthnRepo: webAuthnRepo,
+		tokenService: tokenService,
+		mfaService:   mfaService,
+		rp:           rp,
+		validate:     validate,
+		challengeTTL: challengeTTL,
+	}
+}
+
+// decodeBase64URL accepts base64url with or without padding, as browsers
+// and libraries differ on it.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func credentialDescriptors(credentials []*entity.WebAuthnCredential) []webauthn.CredentialDescriptor {
+	descriptors := make([]webauthn.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, webauthn.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         credential.CredentialId,
+			Transports: credential.Transports,
+		})
+	}
+	return descriptors
+}
+
+func (s *WebAuthnServiceImpl) BeginRegistration(
+	ctx context.Context, userID string,
+) (*WebAuthnRegistrationOptions, *exception.Exception) {
+	user, err := s.userRepo.FindByID(ctx, s.db, userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.NotFound("user not found")
+	}
+	credentials, err := s.webAuthnRepo.FindByUser(ctx, s.db, user.Id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	challenge, exc := s.newChallenge(ctx, &user.Id, entity.WebAuthnPurposeRegistration)
+	if exc != nil {
+		return nil, exc
+	}
+	options := s.rp.CreationOptions(challenge, webauthn.UserEntity{
+		ID:          base64.RawURLEncoding.EncodeToString([]byte(user.Id)),
+		Name:        user.Username,
+		DisplayName: user.Username,
+	}, credentialDescriptors(credentials))
+	return &WebAuthnRegistrationOptions{PublicKey: options}, nil
+}
+
+func (s *WebAuthnServiceImpl) FinishRegistration(
+	ctx context.Context, userID string, model *entity.WebAuthnRegistrationRequest,
+) (*entity.WebAuthnCredential, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	clientData, err := decodeBase64URL(model.Credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, exception.InvalidArgument("clientDataJSON is not base64url encoded")
+	}
+	attestationObject, err := decodeBase64URL(model.Credential.Response.AttestationObject)
+	if err != nil {
+		return nil, exception.InvalidArgument("attestationObject is not base64url encoded")
+	}
+	challenge, exc := s.consumeChallenge(ctx, clientData, entity.WebAuthnPurposeRegistration)
+	if exc != nil {
+		return nil, exc
+	}
+	if challenge.UserId == nil || *challenge.UserId != userID {
+		return nil, exception.PermissionDenied("challenge is invalid or has expired")
+	}
+	credential, err := s.rp.VerifyRegistration(clientData, attestationObject, challenge.value)
+	if err != nil {
+		return nil, exception.InvalidArgument("credential could not be verified: " + err.Error())
+	}
+	credentialID := base64.RawURLEncoding.EncodeToString(credential.ID)
+	existing, err := s.webAuthnRepo.FindByCredentialID(ctx, s.db, credentialID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if existing != nil {
+		return nil, exception.InvalidArgument("credential is already registered")
+	}
+	aaguid, err := uuid.FromBytes(credential.AAGUID)
+	if err != nil {
+		return nil, exception.InvalidArgument("credential has an invalid aaguid")
+	}
+	data := &entity.WebAuthnCredential{
+		Id:             uuid.NewString(),
+		UserId:         userID,
+		Name:           model.Name,
+		CredentialId:   credentialID,
+		PublicKey:      credential.PublicKey,
+		Algorithm:      credential.Algorithm,
+		SignCount:      credential.SignCount,
+		AAGUID:         aaguid.String(),
+		Transports:     model.Credential.Response.Transports,
+		BackupEligible: credential.BackupEligible,
+		BackedUp:       credential.BackedUp,
+		CreatedAt:      time.Now(),
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.webAuthnRepo.CreateTx(ctx, tx, data); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return data, nil
+}
+
+// BeginLogin lists the user's credentials when a username is given. An
+// unknown username gets the same answer as a login without one, so it doesn't
+// tell whether the account exists.
+func (s *WebAuthnServiceImpl) BeginLogin(
+	ctx context.Context, model *entity.WebAuthnLoginRequest,
+) (*WebAuthnLoginOptions, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	var userID *string
+	var allow []webauthn.CredentialDescriptor
+	if model.Username != "" {
+		user, err := s.userRepo.FindByName(ctx, s.db, "username", model.Username)
+		if err != nil {
+			return nil, exception.Internal("err", err)
+		}
+		if user != nil {
+			credentials, err := s.webAuthnRepo.FindByUser(ctx, s.db, user.Id)
+			if err != nil {
+				return nil, exception.Internal("err", err)
+			}
+			if len(credentials) > 0 {
+				userID = &user.Id
+				allow = credentialDescriptors(credentials)
+			}
+		}
+	}
+	challenge, exc := s.newChallenge(ctx, userID, entity.WebAuthnPurposeLogin)
+	if exc != nil {
+		return nil, exc
+	}
+	return &WebAuthnLoginOptions{PublicKey: s.rp.RequestOptions(challenge, allow)}, nil
+}
+
+func (s *WebAuthnServiceImpl) FinishLogin(
+	ctx context.Context, model *entity.WebAuthnAssertion, client entity.ClientInfo,
+) (*UserLoginResponse, *exception.Exception) {
+	if errs := s.validate.Struct(model); errs != nil {
+		return nil, exception.InvalidArgument(errs)
+	}
+	clientData, err := decodeBase64URL(model.Response.ClientDataJSON)
+	if err != nil {
+		return nil, exception.InvalidArgument("clientDataJSON is not base64url encoded")
+	}
+	authData, err := decodeBase64URL(model.Response.AuthenticatorData)
+	if err != nil {
+		return nil, exception.InvalidArgument("authenticatorData is not base64url encoded")
+	}
+	sig, err := decodeBase64URL(model.Response.Signature)
+	if err != nil {
+		return nil, exception.InvalidArgument("signature is not base64url encoded")
+	}
+	rawID, err := decodeBase64URL(model.RawId)
+	if err != nil {
+		return nil, exception.InvalidArgument("rawId is not base64url encoded")
+	}
+	challenge, exc := s.consumeChallenge(ctx, clientData, entity.WebAuthnPurposeLogin)
+	if exc != nil {
+		return nil, exc
+	}
+	credential, err := s.webAuthnRepo.FindByCredentialID(ctx, s.db, base64.RawURLEncoding.EncodeToString(rawID))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if credential == nil || (challenge.UserId != nil && *challenge.UserId != credential.UserId) {
+		return nil, exception.Unauthenticated("unknown credential")
+	}
+	if model.Response.UserHandle != "" {
+		userHandle, err := decodeBase64URL(model.Response.UserHandle)
+		if err != nil || string(userHandle) != credential.UserId {
+			return nil, exception.Unauthenticated("credential does not belong to the user")
+		}
+	}
+	assertion, err := s.rp.VerifyAssertion(
+		clientData, authData, sig, challenge.value, credential.PublicKey, credential.Algorithm,
+	)
+	if err != nil {
+		return nil, exception.Unauthenticated("assertion could not be verified: " + err.Error())
+	}
+	// Authenticators without a counter always report zero. Otherwise the count
+	// has to move forward, or two copies of the key are in use.
+	if (assertion.SignCount != 0 || credential.SignCount != 0) && assertion.SignCount <= credential.SignCount {
+		slog.Warn("webauthn sign count did not increase, credential may be cloned",
+			"credential_id", credential.Id, "user_id", credential.UserId,
+			"stored", credential.SignCount, "received", assertion.SignCount)
+		return nil, exception.Unauthenticated("credential sign count did not increase")
+	}
+	user, err := s.userRepo.FindByID(ctx, s.db, credential.UserId)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if user == nil {
+		return nil, exception.Unauthenticated("unknown credential")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.webAuthnRepo.TouchTx(ctx, tx, credential.Id, assertion.SignCount, time.Now()); err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	// A verified user has proven both possession and a PIN or biometric, which
+	// is as strong as a password with a TOTP code.
+	if !assertion.UserVerified {
+		mfaEnabled, exc := s.mfaService.Enabled(ctx, user.Id)
+		if exc != nil {
+			return nil, exc
+		}
+		if mfaEnabled {
+			return s.mfaService.Challenge(ctx, user)
+		}
+	}
+	return s.tokenService.Issue(ctx, user, client)
+}
+
+func (s *WebAuthnServiceImpl) List(ctx context.Context, userID string) ([]*entity.WebAuthnCredential, *exception.Exception) {
+	data, err := s.webAuthnRepo.FindByUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	return data, nil
+}
+
+func (s *WebAuthnServiceImpl) Remove(ctx context.Context, userID, id string) *exception.Exception {
+	if _, err := uuid.Parse(id); err != nil {
+		return exception.InvalidArgument("invalid credential id, must be uuid")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	deleted, err := s.webAuthnRepo.DeleteTx(ctx, tx, id, userID)
+	if err != nil {
+		return exception.Internal("err", err)
+	}
+	if !deleted {
+		return exception.NotFound("credential not found")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return exception.Internal("commit transaction", err)
+	}
+	return nil
+}
+
+// webAuthnChallenge is a stored challenge together with its plain value,
+// which is only known once the browser sends it back.
+type webAuthnChallenge struct {
+	*entity.WebAuthnChallenge
+	value string
+}
+
+func (s *WebAuthnServiceImpl) newChallenge(
+	ctx context.Context, userID *string, purpose string,
+) (string, *exception.Exception) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return "", exception.Internal("can't generate challenge", err)
+	}
+	now := time.Now()
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	if err := s.webAuthnRepo.CreateChallengeTx(ctx, tx, &entity.WebAuthnChallenge{
+		Id:            uuid.NewString(),
+		ChallengeHash: signature.HashToken(challenge),
+		UserId:        userID,
+		Purpose:       purpose,
+		ExpiresAt:     now.Add(s.challengeTTL),
+		CreatedAt:     now,
+	}); err != nil {
+		return "", exception.Internal("err", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return "", exception.Internal("commit transaction", err)
+	}
+	return challenge, nil
+}
+
+// consumeChallenge finds the challenge clientDataJSON answers and deletes it,
+// so a ceremony can't be completed twice whether or not it succeeds.
+func (s *WebAuthnServiceImpl) consumeChallenge(
+	ctx context.Context, clientDataJSON []byte, purpose string,
+) (*webAuthnChallenge, *exception.Exception) {
+	value, err := webauthn.ClientDataChallenge(clientDataJSON)
+	if err != nil {
+		return nil, exception.InvalidArgument(err.Error())
+	}
+	challenge, err := s.webAuthnRepo.FindChallengeByHash(ctx, s.db, signature.HashToken(value))
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if challenge == nil || challenge.Purpose != purpose || time.Now().After(challenge.ExpiresAt) {
+		return nil, exception.PermissionDenied("challenge is invalid or has expired")
+	}
+	tx := s.db.Begin()
+	defer tx.Rollback()
+	deleted, err := s.webAuthnRepo.DeleteChallengeTx(ctx, tx, challenge.Id)
+	if err != nil {
+		return nil, exception.Internal("err", err)
+	}
+	if !deleted {
+		return nil, exception.PermissionDenied("challenge is invalid or has expired")
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, exception.Internal("commit transaction", err)
+	}
+	return &webAuthnChallenge{WebAuthnChallenge: challenge, value: value}, nil
+}
@@ -0,0 +1,411 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/internal/entity"
+	"user-simple-crud/internal/mocks"
+	service "user-simple-crud/internal/services"
+	"user-simple-crud/pkg/exception"
+	"user-simple-crud/pkg/signature"
+	"user-simple-crud/pkg/webauthn"
+	"user-simple-crud/pkg/webauthn/webauthntest"
+	"user-simple-crud/pkg/xvalidator"
+)
+
+const (
+	webAuthnRPID   = "example.com"
+	webAuthnOrigin = "https://app.example.com"
+)
+
+var webAuthnUser = &entity.User{
+	Id:       "123e4567-e89b-12d3-a456-426614174000",
+	Username: "john_doe",
+	Email:    "john_doe@example.com",
+}
+
+func newWebAuthnService(
+	t *testing.T, webAuthnRepo *mocks.WebAuthnRepository, userRepo *mocks.UserRepository,
+	tokenService *mocks.TokenService, mfaService *mocks.MFAService,
+) (service.WebAuthnService, func()) {
+	mockSql, gormDB := setupSQLMock(t)
+	validate, _ := xvalidator.NewValidator()
+	rp := webauthn.NewRelyingParty(&webauthn.Config{
+		RPID:             webAuthnRPID,
+		RPName:           "user-simple-crud",
+		Origins:          []string{webAuthnOrigin},
+		Timeout:          5 * time.Minute,
+		UserVerification: webauthn.UserVerificationPreferred,
+	})
+	expectTx := func() {
+		mockSql.ExpectBegin()
+		mockSql.ExpectCommit()
+	}
+	return service.NewWebAuthnService(gormDB, userRepo, webAuthnRepo, tokenService, mfaService, rp, validate, 5*time.Minute), expectTx
+}
+
+func storedChallenge(challenge, purpose string, userID *string) *entity.WebAuthnChallenge {
+	return &entity.WebAuthnChallenge{
+		Id:            "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		ChallengeHash: signature.HashToken(challenge),
+		UserId:        userID,
+		Purpose:       purpose,
+		ExpiresAt:     time.Now().Add(5 * time.Minute),
+	}
+}
+
+func attestationRequest(authenticator *webauthntest.Authenticator, challenge string) *entity.WebAuthnRegistrationRequest {
+	clientData, attestation := authenticator.Register(challenge)
+	return &entity.WebAuthnRegistrationRequest{
+		Name: "Security key",
+		Credential: entity.WebAuthnAttestation{
+			Id:    authenticator.EncodedID(),
+			RawId: authenticator.EncodedID(),
+			Type:  "public-key",
+			Response: entity.WebAuthnAttestationResponse{
+				ClientDataJSON:    base64.RawURLEncoding.EncodeToString(clientData),
+				AttestationObject: base64.RawURLEncoding.EncodeToString(attestation),
+				Transports:        []string{"usb"},
+			},
+		},
+	}
+}
+
+func assertionRequest(t *testing.T, authenticator *webauthntest.Authenticator, challenge string) *entity.WebAuthnAssertion {
+	clientData, authData, sig, err := authenticator.Assert(challenge)
+	require.NoError(t, err)
+	return &entity.WebAuthnAssertion{
+		Id:    authenticator.EncodedID(),
+		RawId: authenticator.EncodedID(),
+		Type:  "public-key",
+		Response: entity.WebAuthnAssertionResponse{
+			ClientDataJSON:    base64.RawURLEncoding.EncodeToString(clientData),
+			AuthenticatorData: base64.RawURLEncoding.EncodeToString(authData),
+			Signature:         base64.RawURLEncoding.EncodeToString(sig),
+			UserHandle:        base64.RawURLEncoding.EncodeToString([]byte(webAuthnUser.Id)),
+		},
+	}
+}
+
+// registeredCredential runs a registration ceremony with the authenticator and
+// returns the credential the service stored.
+func registeredCredential(t *testing.T, authenticator *webauthntest.Authenticator) *entity.WebAuthnCredential {
+	mockAppCtx := context.Background()
+	var stored *entity.WebAuthnCredential
+	challenge, _ := webauthn.NewChallenge()
+	mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+	mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken(challenge)).
+		Return(storedChallenge(challenge, entity.WebAuthnPurposeRegistration, &webAuthnUser.Id), nil)
+	mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+	mockWebAuthnRepository.On("FindByCredentialID", mockAppCtx, mock.Anything, authenticator.EncodedID()).Return(nil, nil)
+	mockWebAuthnRepository.On("CreateTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(data *entity.WebAuthnCredential) bool {
+		stored = data
+		return true
+	})).Return(nil)
+
+	mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+	expectTx()
+	expectTx()
+	_, errService := mockService.FinishRegistration(mockAppCtx, webAuthnUser.Id, attestationRequest(authenticator, challenge))
+	require.Nil(t, errService)
+	return stored
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("BeginRegistration Success", func(t *testing.T) {
+		// Mocks
+		existing := &entity.WebAuthnCredential{CredentialId: "mT3eGz3bVqHc0y1pJ2xk6w", Transports: []string{"internal"}}
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, webAuthnUser.Id).Return(webAuthnUser, nil)
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindByUser", mockAppCtx, mock.Anything, webAuthnUser.Id).Return([]*entity.WebAuthnCredential{existing}, nil)
+		var stored *entity.WebAuthnChallenge
+		mockWebAuthnRepository.On("CreateChallengeTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(data *entity.WebAuthnChallenge) bool {
+			stored = data
+			return data.Purpose == entity.WebAuthnPurposeRegistration && *data.UserId == webAuthnUser.Id
+		})).Return(nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, mockUserRepository, new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.BeginRegistration(mockAppCtx, webAuthnUser.Id)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, signature.HashToken(result.PublicKey.Challenge), stored.ChallengeHash)
+		assert.Equal(t, webAuthnRPID, result.PublicKey.RP.ID)
+		assert.Equal(t, webAuthnUser.Username, result.PublicKey.User.Name)
+		assert.Equal(t, existing.CredentialId, result.PublicKey.ExcludeCredentials[0].ID)
+	})
+
+	t.Run("FinishRegistration Success", func(t *testing.T) {
+		authenticator, err := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		require.NoError(t, err)
+
+		// Call the function under test
+		stored := registeredCredential(t, authenticator)
+
+		// Assert the result
+		assert.Equal(t, webAuthnUser.Id, stored.UserId)
+		assert.Equal(t, authenticator.EncodedID(), stored.CredentialId)
+		assert.Equal(t, webauthn.AlgES256, stored.Algorithm)
+		assert.Equal(t, []string{"usb"}, stored.Transports)
+		assert.NotEmpty(t, stored.PublicKey)
+	})
+
+	t.Run("FinishRegistration Challenge Of Another User", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		otherUserID := "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeRegistration, &otherUserID), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.FinishRegistration(mockAppCtx, webAuthnUser.Id, attestationRequest(authenticator, "challenge"))
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+		mockWebAuthnRepository.AssertNotCalled(t, "CreateTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("FinishRegistration Foreign Origin", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, "https://evil.test")
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeRegistration, &webAuthnUser.Id), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.FinishRegistration(mockAppCtx, webAuthnUser.Id, attestationRequest(authenticator, "challenge"))
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.InvalidArgumentCode, errService.Code)
+	})
+
+	t.Run("FinishRegistration Expired Challenge", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		expired := storedChallenge("challenge", entity.WebAuthnPurposeRegistration, &webAuthnUser.Id)
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).Return(expired, nil)
+
+		mockService, _ := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		result, errService := mockService.FinishRegistration(mockAppCtx, webAuthnUser.Id, attestationRequest(authenticator, "challenge"))
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, "challenge is invalid or has expired", errService.Message)
+	})
+}
+
+func TestWebAuthnLogin(t *testing.T) {
+	mockAppCtx := context.Background()
+	client := entity.ClientInfo{IpAddress: "127.0.0.1", UserAgent: "test"}
+
+	t.Run("BeginLogin Unknown Username", func(t *testing.T) {
+		// Mocks
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByName", mockAppCtx, mock.Anything, "username", "nobody").Return(nil, nil)
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("CreateChallengeTx", mockAppCtx, mock.Anything, mock.MatchedBy(func(data *entity.WebAuthnChallenge) bool {
+			return data.Purpose == entity.WebAuthnPurposeLogin && data.UserId == nil
+		})).Return(nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, mockUserRepository, new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.BeginLogin(mockAppCtx, &entity.WebAuthnLoginRequest{Username: "nobody"})
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Empty(t, result.PublicKey.AllowCredentials)
+		assert.Equal(t, webAuthnRPID, result.PublicKey.RPID)
+	})
+
+	t.Run("FinishLogin Success", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		credential := registeredCredential(t, authenticator)
+		loginResponse := &service.UserLoginResponse{Username: webAuthnUser.Username, Token: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"}
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeLogin, nil), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+		mockWebAuthnRepository.On("FindByCredentialID", mockAppCtx, mock.Anything, credential.CredentialId).Return(credential, nil)
+		mockWebAuthnRepository.On("TouchTx", mockAppCtx, mock.Anything, credential.Id, uint32(1), mock.Anything).Return(nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, webAuthnUser.Id).Return(webAuthnUser, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockTokenService.On("Issue", mockAppCtx, webAuthnUser, client).Return(loginResponse, nil)
+		mockMFAService := new(mocks.MFAService)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, mockUserRepository, mockTokenService, mockMFAService)
+
+		// Call the function under test
+		expectTx()
+		expectTx()
+		result, errService := mockService.FinishLogin(mockAppCtx, assertionRequest(t, authenticator, "challenge"), client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, loginResponse, result)
+		mockWebAuthnRepository.AssertExpectations(t)
+		mockMFAService.AssertNotCalled(t, "Enabled", mock.Anything, mock.Anything)
+	})
+
+	t.Run("FinishLogin Without User Verification Asks For MFA", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		credential := registeredCredential(t, authenticator)
+		authenticator.UserVerified = false
+		mfaResponse := &service.UserLoginResponse{MFARequired: true, MFAToken: "Jm6cXl2pV0xq0E3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0"}
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeLogin, nil), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+		mockWebAuthnRepository.On("FindByCredentialID", mockAppCtx, mock.Anything, credential.CredentialId).Return(credential, nil)
+		mockWebAuthnRepository.On("TouchTx", mockAppCtx, mock.Anything, credential.Id, uint32(1), mock.Anything).Return(nil)
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("FindByID", mockAppCtx, mock.Anything, webAuthnUser.Id).Return(webAuthnUser, nil)
+		mockTokenService := new(mocks.TokenService)
+		mockMFAService := new(mocks.MFAService)
+		mockMFAService.On("Enabled", mockAppCtx, webAuthnUser.Id).Return(true, nil)
+		mockMFAService.On("Challenge", mockAppCtx, webAuthnUser).Return(mfaResponse, nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, mockUserRepository, mockTokenService, mockMFAService)
+
+		// Call the function under test
+		expectTx()
+		expectTx()
+		result, errService := mockService.FinishLogin(mockAppCtx, assertionRequest(t, authenticator, "challenge"), client)
+
+		// Assert the result
+		require.Nil(t, errService)
+		assert.Equal(t, mfaResponse, result)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("FinishLogin Sign Count Regression", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		credential := registeredCredential(t, authenticator)
+		credential.SignCount = 5
+		authenticator.SignCount = 2
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeLogin, nil), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+		mockWebAuthnRepository.On("FindByCredentialID", mockAppCtx, mock.Anything, credential.CredentialId).Return(credential, nil)
+		mockTokenService := new(mocks.TokenService)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), mockTokenService, new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.FinishLogin(mockAppCtx, assertionRequest(t, authenticator, "challenge"), client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, "credential sign count did not increase", errService.Message)
+		mockWebAuthnRepository.AssertNotCalled(t, "TouchTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockTokenService.AssertNotCalled(t, "Issue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("FinishLogin Credential Of Another User", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+		credential := registeredCredential(t, authenticator)
+		otherUserID := "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33"
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeLogin, &otherUserID), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(true, nil)
+		mockWebAuthnRepository.On("FindByCredentialID", mockAppCtx, mock.Anything, credential.CredentialId).Return(credential, nil)
+
+		mockService, expectTx := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		expectTx()
+		result, errService := mockService.FinishLogin(mockAppCtx, assertionRequest(t, authenticator, "challenge"), client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.UnauthenticatedCode, errService.Code)
+	})
+
+	t.Run("FinishLogin Challenge Already Used", func(t *testing.T) {
+		authenticator, _ := webauthntest.NewAuthenticator(webAuthnRPID, webAuthnOrigin)
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("FindChallengeByHash", mockAppCtx, mock.Anything, signature.HashToken("challenge")).
+			Return(storedChallenge("challenge", entity.WebAuthnPurposeLogin, nil), nil)
+		mockWebAuthnRepository.On("DeleteChallengeTx", mockAppCtx, mock.Anything, mock.Anything).Return(false, nil)
+
+		mockService, _ := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		result, errService := mockService.FinishLogin(mockAppCtx, assertionRequest(t, authenticator, "challenge"), client)
+
+		// Assert the result
+		assert.Nil(t, result)
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.PermissionDeniedCode, errService.Code)
+	})
+}
+
+func TestRemoveWebAuthnCredential(t *testing.T) {
+	mockAppCtx := context.Background()
+
+	t.Run("RemoveWebAuthnCredential Not Found", func(t *testing.T) {
+		id := "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+		// Mocks
+		mockWebAuthnRepository := new(mocks.WebAuthnRepository)
+		mockWebAuthnRepository.On("DeleteTx", mockAppCtx, mock.Anything, id, webAuthnUser.Id).Return(false, nil)
+
+		mockService, _ := newWebAuthnService(t, mockWebAuthnRepository, new(mocks.UserRepository), new(mocks.TokenService), new(mocks.MFAService))
+
+		// Call the function under test
+		errService := mockService.Remove(mockAppCtx, webAuthnUser.Id, id)
+
+		// Assert the result
+		require.NotNil(t, errService)
+		assert.Equal(t, exception.NotFoundCode, errService.Code)
+	})
+}
@@ -8,6 +8,26 @@ import (
 func AutoMigration(CpmDB *database.Database) {
 	CpmDB.MigrateDB(
 
-		&entity.User{})
+		&entity.User{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.RevokedSubject{},
+		&entity.UserToken{},
+		&entity.UserMFA{},
+		&entity.MFARecoveryCode{},
+		&entity.LoginAttempt{},
+		&entity.RateLimit{},
+		&entity.APIKey{},
+		&entity.OAuthClient{},
+		&entity.OAuthAuthorizationCode{},
+		&entity.FederatedIdentity{},
+		&entity.FederatedLoginState{},
+		&entity.Session{},
+		&entity.PasswordHistory{},
+		&entity.RequestNonce{},
+		&entity.WebAuthnCredential{},
+		&entity.WebAuthnChallenge{},
+		&entity.AuditLog{},
+		&entity.Group{})
 	//&entity.SMSLog{}
 }
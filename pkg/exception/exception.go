@@ -1,26 +1,33 @@
 package exception
 
+import "time"
+
 // Code is a type alias for string, representing the error code of an exception.
 type Code string
 
 // Predefined error codes.
 const (
-	InvalidArgumentCode  Code = "INVALID_ARGUMENT"  // Represents an invalid argument error.
-	NotFoundCode         Code = "NOT_FOUND"         // Represents a not found error.
-	AlreadyExistsCode    Code = "ALREADY_EXISTS"    // Represents an already exists error.
-	PermissionDeniedCode Code = "PERMISSION_DENIED" // Represents a permission denied error.
-	UnauthenticatedCode  Code = "UNAUTHENTICATED"   // Represents an unauthenticated error.
-	InternalErrorCode    Code = "INTERNAL"          // Represents an internal error.
+	InvalidArgumentCode  Code = "INVALID_ARGUMENT"    // Represents an invalid argument error.
+	NotFoundCode         Code = "NOT_FOUND"           // Represents a not found error.
+	AlreadyExistsCode    Code = "ALREADY_EXISTS"      // Represents an already exists error.
+	PermissionDeniedCode Code = "PERMISSION_DENIED"   // Represents a permission denied error.
+	UnauthenticatedCode  Code = "UNAUTHENTICATED"     // Represents an unauthenticated error.
+	InternalErrorCode    Code = "INTERNAL"            // Represents an internal error.
+	TooManyRequestsCode  Code = "RESOURCE_EXHAUSTED"  // Represents a rate limited request.
+	LockedCode           Code = "LOCKED"              // Represents a temporarily locked resource.
+	PreconditionCode     Code = "FAILED_PRECONDITION" // Represents a write against a stale version.
 )
 
 // Exception is a struct to represent exception/error from service.
 // Code is the error code of the exception.
 // Message is the error message of the exception.
 // Error is the original error that caused the exception, if any.
+// RetryAfter tells the client how long to wait before trying again, if set.
 type Exception struct {
-	Code    Code
-	Message any
-	Error   error
+	Code       Code
+	Message    any
+	Error      error
+	RetryAfter time.Duration
 }
 
 func (e *Exception) GetError() *string {
@@ -43,6 +50,12 @@ func (e *Exception) GetHttpCode() int {
 		return 403
 	case UnauthenticatedCode:
 		return 401
+	case TooManyRequestsCode:
+		return 429
+	case LockedCode:
+		return 423
+	case PreconditionCode:
+		return 412
 	case InternalErrorCode:
 		return 500
 	default:
@@ -107,3 +120,29 @@ func Conflict(message any) *Exception {
 		Message: message,
 	}
 }
+
+// TooManyRequests creates a new Exception with the TooManyRequestsCode error code.
+func TooManyRequests(message any, retryAfter time.Duration) *Exception {
+	return &Exception{
+		Code:       TooManyRequestsCode,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+// Locked creates a new Exception with the LockedCode error code.
+func Locked(message any, retryAfter time.Duration) *Exception {
+	return &Exception{
+		Code:       LockedCode,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+// PreconditionFailed creates a new Exception with the PreconditionCode error code.
+func PreconditionFailed(message any) *Exception {
+	return &Exception{
+		Code:    PreconditionCode,
+		Message: message,
+	}
+}
@@ -0,0 +1,47 @@
+// Package mergepatch applies JSON Merge Patch documents (RFC 7396).
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ContentType is the media type of a merge patch document.
+const ContentType = "application/merge-patch+json"
+
+// Apply merges patch into the JSON document target and returns the result.
+// Members of a patch object replace those of the target, null removes them,
+// and a patch that isn't an object replaces the target as a whole.
+func Apply(target, patch []byte) ([]byte, error) {
+	var patchValue any
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, errors.New("merge patch is not valid JSON")
+	}
+	var targetValue any
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &targetValue); err != nil {
+			return nil, errors.New("merge patch target is not valid JSON")
+		}
+	}
+	return json.Marshal(merge(targetValue, patchValue))
+}
+
+// merge is the MergePatch function of RFC 7396 section 2.
+func merge(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
@@ -15,45 +15,55 @@ type Signaturer struct {
 	mock.Mock
 }
 
-// CheckBscryptPasswordHash provides a mock function with given fields: password, hash
-func (_m *Signaturer) CheckBscryptPasswordHash(password string, hash string) bool {
+// CheckPasswordHash provides a mock function with given fields: password, hash
+func (_m *Signaturer) CheckPasswordHash(password string, hash string) (bool, bool) {
 	ret := _m.Called(password, hash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckBscryptPasswordHash")
+		panic("no return value specified for CheckPasswordHash")
 	}
 
 	var r0 bool
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string, string) (bool, bool)); ok {
+		return rf(password, hash)
+	}
 	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
 		r0 = rf(password, hash)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string, string) bool); ok {
+		r1 = rf(password, hash)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
 }
 
-// GenerateJWT provides a mock function with given fields: username
-func (_m *Signaturer) GenerateJWT(username string) (string, error) {
-	ret := _m.Called(username)
+// GenerateIDToken provides a mock function with given fields: claims
+func (_m *Signaturer) GenerateIDToken(claims signature.IDTokenClaims) (string, error) {
+	ret := _m.Called(claims)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GenerateJWT")
+		panic("no return value specified for GenerateIDToken")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(signature.IDTokenClaims) (string, error)); ok {
+		return rf(claims)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(signature.IDTokenClaims) string); ok {
+		r0 = rf(claims)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(signature.IDTokenClaims) error); ok {
+		r1 = rf(claims)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,27 +71,27 @@ func (_m *Signaturer) GenerateJWT(username string) (string, error) {
 	return r0, r1
 }
 
-// HashBscryptPassword provides a mock function with given fields: password
-func (_m *Signaturer) HashBscryptPassword(password string) (string, error) {
-	ret := _m.Called(password)
+// GenerateJWT provides a mock function with given fields: claims
+func (_m *Signaturer) GenerateJWT(claims signature.JWTClaims) (string, error) {
+	ret := _m.Called(claims)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HashBscryptPassword")
+		panic("no return value specified for GenerateJWT")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(password)
+	if rf, ok := ret.Get(0).(func(signature.JWTClaims) (string, error)); ok {
+		return rf(claims)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(password)
+	if rf, ok := ret.Get(0).(func(signature.JWTClaims) string); ok {
+		r0 = rf(claims)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(password)
+	if rf, ok := ret.Get(1).(func(signature.JWTClaims) error); ok {
+		r1 = rf(claims)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,87 +99,77 @@ func (_m *Signaturer) HashBscryptPassword(password string) (string, error) {
 	return r0, r1
 }
 
-// JWTCheck provides a mock function with given fields: token
-func (_m *Signaturer) JWTCheck(token string) (*signature.JwtAuthenticationRes, *exception.Exception) {
-	ret := _m.Called(token)
+// HashPassword provides a mock function with given fields: password
+func (_m *Signaturer) HashPassword(password string) (string, error) {
+	ret := _m.Called(password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for JWTCheck")
+		panic("no return value specified for HashPassword")
 	}
 
-	var r0 *signature.JwtAuthenticationRes
-	var r1 *exception.Exception
-	if rf, ok := ret.Get(0).(func(string) (*signature.JwtAuthenticationRes, *exception.Exception)); ok {
-		return rf(token)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(password)
 	}
-	if rf, ok := ret.Get(0).(func(string) *signature.JwtAuthenticationRes); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*signature.JwtAuthenticationRes)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) *exception.Exception); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(password)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*exception.Exception)
-		}
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// SignHMAC512 provides a mock function with given fields: httpMethod, bodyJson, token
-func (_m *Signaturer) SignHMAC512(httpMethod string, bodyJson string, token string) (string, error) {
-	ret := _m.Called(httpMethod, bodyJson, token)
+// JWKS provides a mock function with given fields:
+func (_m *Signaturer) JWKS() *signature.JSONWebKeySet {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for SignHMAC512")
+		panic("no return value specified for JWKS")
 	}
 
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
-		return rf(httpMethod, bodyJson, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = rf(httpMethod, bodyJson, token)
+	var r0 *signature.JSONWebKeySet
+	if rf, ok := ret.Get(0).(func() *signature.JSONWebKeySet); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(httpMethod, bodyJson, token)
-	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*signature.JSONWebKeySet)
+		}
 	}
 
-	return r0, r1
+	return r0
 }
 
-// VerifyHMAC512 provides a mock function with given fields: httpMethod, bodyJson, token, hash
-func (_m *Signaturer) VerifyHMAC512(httpMethod string, bodyJson string, token string, hash string) (bool, *exception.Exception) {
-	ret := _m.Called(httpMethod, bodyJson, token, hash)
+// JWTCheck provides a mock function with given fields: token
+func (_m *Signaturer) JWTCheck(token string) (*signature.JwtAuthenticationRes, *exception.Exception) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for VerifyHMAC512")
+		panic("no return value specified for JWTCheck")
 	}
 
-	var r0 bool
+	var r0 *signature.JwtAuthenticationRes
 	var r1 *exception.Exception
-	if rf, ok := ret.Get(0).(func(string, string, string, string) (bool, *exception.Exception)); ok {
-		return rf(httpMethod, bodyJson, token, hash)
+	if rf, ok := ret.Get(0).(func(string) (*signature.JwtAuthenticationRes, *exception.Exception)); ok {
+		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, string) bool); ok {
-		r0 = rf(httpMethod, bodyJson, token, hash)
+	if rf, ok := ret.Get(0).(func(string) *signature.JwtAuthenticationRes); ok {
+		r0 = rf(token)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*signature.JwtAuthenticationRes)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, string) *exception.Exception); ok {
-		r1 = rf(httpMethod, bodyJson, token, hash)
+	if rf, ok := ret.Get(1).(func(string) *exception.Exception); ok {
+		r1 = rf(token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*exception.Exception)
@@ -0,0 +1,306 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"user-simple-crud/pkg/signature"
+)
+
+const (
+	// keyRefreshInterval limits how often an unknown kid triggers a JWKS fetch
+	keyRefreshInterval = time.Minute
+	maxResponseBytes   = 1 << 20
+)
+
+// Algorithms accepted on ID tokens. HMAC is never accepted as this service
+// doesn't share a secret with providers for signing.
+var validMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "EdDSA"}
+
+// Config registers this service as a client of a provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	RedirectURL  string
+}
+
+// Provider is an external OpenID Connect provider users can sign in with.
+type Provider interface {
+	// AuthCodeURL returns the authorization endpoint URL to send the user to
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code at the token endpoint and returns
+	// the claims of the verified ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*IDTokenClaims, error)
+}
+
+// IDTokenClaims are the ID token claims this service reads.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty   string `json:"azp,omitempty"`
+	Nonce             string `json:"nonce,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     bool   `json:"email_verified,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+}
+
+// Metadata is the part of the discovery document this service uses.
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IdToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type provider struct {
+	conf   *Config
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *Metadata
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewProvider returns a provider that discovers its endpoints on first use.
+// client defaults to one with a 10 second timeout.
+func NewProvider(conf *Config, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &provider{conf: conf, client: client}
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	endpoint, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := endpoint.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.conf.ClientID)
+	query.Set("redirect_uri", p.conf.RedirectURL)
+	query.Set("scope", strings.Join(p.conf.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*IDTokenClaims, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.conf.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.conf.ClientID), url.QueryEscape(p.conf.ClientSecret))
+	var token tokenResponse
+	status, err := p.do(req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("token endpoint answered %d: %s %s", status, token.Error, token.ErrorDescription)
+	}
+	if token.IdToken == "" {
+		return nil, errors.New("token endpoint returned no id_token")
+	}
+	return p.verify(ctx, token.IdToken, nonce)
+}
+
+// verify checks the signature against the provider's JWKS and the claims
+// required by OpenID Connect Core section 3.1.3.7.
+func (p *provider) verify(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	claims := &IDTokenClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(validMethods))
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		return p.verificationKey(ctx, token)
+	}); err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+	if claims.Issuer != p.conf.Issuer {
+		return nil, fmt.Errorf("unexpected id token issuer %q", claims.Issuer)
+	}
+	if !claims.VerifyAudience(p.conf.ClientID, true) {
+		return nil, errors.New("id token is not intended for this client")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.conf.ClientID {
+		return nil, errors.New("id token was issued to another party")
+	}
+	if claims.Subject == "" || claims.ExpiresAt == nil || claims.IssuedAt == nil {
+		return nil, errors.New("id token has no subject, expiry or issued at")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("id token nonce does not match")
+	}
+	return claims, nil
+}
+
+// verificationKey finds the key named by the token's kid, fetching the JWKS
+// again when the provider may have rotated its keys.
+func (p *provider) verificationKey(ctx context.Context, token *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := token.Header["kid"].(string)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := p.lookupKey(kid)
+	if key == nil && time.Since(p.keysFetchedAt) >= keyRefreshInterval {
+		if err := p.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		key = p.lookupKey(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		_, isRSA := token.Method.(*jwt.SigningMethodRSA)
+		_, isPSS := token.Method.(*jwt.SigningMethodRSAPSS)
+		if isRSA || isPSS {
+			return key, nil
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); ok {
+			return key, nil
+		}
+	case ed25519.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("signing key %q does not match algorithm %s", kid, token.Method.Alg())
+}
+
+// lookupKey must be called with mu held. A token without a kid is only
+// accepted when the provider publishes a single key.
+func (p *provider) lookupKey(kid string) crypto.PublicKey {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return p.keys[kid]
+}
+
+// fetchKeys must be called with mu held.
+func (p *provider) fetchKeys(ctx context.Context) error {
+	metadata, err := p.discoverLocked(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadata.JwksURI, nil)
+	if err != nil {
+		return err
+	}
+	var set signature.JSONWebKeySet
+	status, err := p.do(req, &set)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("jwks endpoint answered %d", status)
+	}
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// Providers may publish key types this service doesn't use
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	return nil
+}
+
+func (p *provider) discover(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.discoverLocked(ctx)
+}
+
+// discoverLocked must be called with mu held. A failed discovery isn't cached
+// so the next login tries again.
+func (p *provider) discoverLocked(ctx context.Context) (*Metadata, error) {
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(p.conf.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var metadata Metadata
+	status, err := p.do(req, &metadata)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery endpoint answered %d", status)
+	}
+	if metadata.Issuer != p.conf.Issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", metadata.Issuer, p.conf.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JwksURI == "" {
+		return nil, errors.New("discovery document is missing an endpoint")
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+func (p *provider) do(req *http.Request, dest any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if err := json.Unmarshal(body, dest); err != nil {
+		return resp.StatusCode, fmt.Errorf("decode %s: %w", req.URL, err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,192 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"user-simple-crud/pkg/signature"
+)
+
+const (
+	testClientID     = "user-simple-crud"
+	testClientSecret = "s3cr3t+/="
+	testNonce        = "n-0S6_WzA2Mj"
+	testSubject      = "248289761001"
+)
+
+// mockServer is a minimal OpenID Connect provider. idToken builds the claims
+// returned by the token endpoint so each test can tamper with them.
+type mockServer struct {
+	*httptest.Server
+	signer  signature.Signaturer
+	idToken func() signature.IDTokenClaims
+	form    url.Values
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := signature.NewSigningKey("mock-key", key, time.Time{})
+	require.NoError(t, err)
+	keySet, err := signature.NewKeySet([]*signature.SigningKey{signingKey}, time.Hour, "", time.Time{})
+	require.NoError(t, err)
+
+	m := &mockServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Metadata{
+			Issuer:                m.URL,
+			AuthorizationEndpoint: m.URL + "/authorize",
+			TokenEndpoint:         m.URL + "/token",
+			JwksURI:               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(m.signer.JWKS())
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if id, _ := url.QueryUnescape(clientID); id != testClientID {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		if secret, _ := url.QueryUnescape(clientSecret); secret != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		_ = r.ParseForm()
+		m.form = r.PostForm
+		idToken, err := m.signer.GenerateIDToken(m.idToken())
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+
+	m.signer = signature.NewSignature(keySet, &signature.Config{Issuer: m.URL, AccessTokenTTL: time.Minute}, nil)
+	m.idToken = func() signature.IDTokenClaims {
+		verified := true
+		return signature.IDTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:  testSubject,
+				Audience: jwt.ClaimStrings{testClientID},
+			},
+			Nonce:             testNonce,
+			Email:             "jane@example.com",
+			EmailVerified:     &verified,
+			PreferredUsername: "jane",
+		}
+	}
+	return m
+}
+
+func (m *mockServer) provider() Provider {
+	return NewProvider(&Config{
+		Issuer:       m.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		Scopes:       []string{"openid", "email"},
+		RedirectURL:  "http://localhost:9004/auth/oidc/corp/callback",
+	}, m.Client())
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	m := newMockServer(t)
+
+	location, err := m.provider().AuthCodeURL(context.Background(), "state", testNonce, "challenge")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(location)
+	require.NoError(t, err)
+	assert.Equal(t, m.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, testNonce, query.Get("nonce"))
+	assert.Equal(t, "challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Run("Exchange Success", func(t *testing.T) {
+		m := newMockServer(t)
+
+		claims, err := m.provider().Exchange(context.Background(), "code", "verifier", testNonce)
+		require.NoError(t, err)
+		assert.Equal(t, testSubject, claims.Subject)
+		assert.Equal(t, "jane@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "jane", claims.PreferredUsername)
+		assert.Equal(t, "authorization_code", m.form.Get("grant_type"))
+		assert.Equal(t, "code", m.form.Get("code"))
+		assert.Equal(t, "verifier", m.form.Get("code_verifier"))
+	})
+
+	t.Run("Exchange Error - Nonce Mismatch", func(t *testing.T) {
+		m := newMockServer(t)
+
+		_, err := m.provider().Exchange(context.Background(), "code", "verifier", "another-nonce")
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("Exchange Error - Wrong Audience", func(t *testing.T) {
+		m := newMockServer(t)
+		idToken := m.idToken
+		m.idToken = func() signature.IDTokenClaims {
+			claims := idToken()
+			claims.Audience = jwt.ClaimStrings{"another-client"}
+			return claims
+		}
+
+		_, err := m.provider().Exchange(context.Background(), "code", "verifier", testNonce)
+		assert.ErrorContains(t, err, "not intended for this client")
+	})
+
+	t.Run("Exchange Error - Signed By Another Key", func(t *testing.T) {
+		m := newMockServer(t)
+		p := m.provider()
+		// Prime the key cache, then rotate the provider key without publishing it
+		_, err := p.Exchange(context.Background(), "code", "verifier", testNonce)
+		require.NoError(t, err)
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		signingKey, err := signature.NewSigningKey("mock-key", key, time.Time{})
+		require.NoError(t, err)
+		keySet, err := signature.NewKeySet([]*signature.SigningKey{signingKey}, time.Hour, "", time.Time{})
+		require.NoError(t, err)
+		m.signer = signature.NewSignature(keySet, &signature.Config{Issuer: m.URL, AccessTokenTTL: time.Minute}, nil)
+
+		_, err = p.Exchange(context.Background(), "code", "verifier", testNonce)
+		assert.ErrorContains(t, err, "invalid id token")
+	})
+
+	t.Run("Exchange Error - Wrong Client Secret", func(t *testing.T) {
+		m := newMockServer(t)
+		p := NewProvider(&Config{Issuer: m.URL, ClientID: testClientID, ClientSecret: "wrong"}, m.Client())
+
+		_, err := p.Exchange(context.Background(), "code", "verifier", testNonce)
+		assert.ErrorContains(t, err, "invalid_client")
+	})
+
+	t.Run("Exchange Error - Issuer Mismatch", func(t *testing.T) {
+		m := newMockServer(t)
+		p := NewProvider(&Config{Issuer: m.URL + "/", ClientID: testClientID, ClientSecret: testClientSecret}, m.Client())
+
+		_, err := p.Exchange(context.Background(), "code", "verifier", testNonce)
+		assert.ErrorContains(t, err, "expected")
+	})
+}
@@ -0,0 +1,178 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SigningKey is an asymmetric key identified by Kid. The key signs new tokens
+// from ActiveFrom until a newer key becomes active, and keeps verifying tokens
+// for the retirement window of the KeySet after that.
+type SigningKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	Private    crypto.Signer
+	ActiveFrom time.Time
+}
+
+// KeySet holds every key the service knows about, ordered by activation time.
+// An HMAC secret may be kept next to the asymmetric keys until a cutoff so
+// tokens issued before the migration to asymmetric signing stay valid until
+// they expire.
+type KeySet struct {
+	keys        []*SigningKey
+	hmacSecret  []byte
+	hmacUntil   time.Time
+	retireAfter time.Duration
+}
+
+// NewHMACKeySet signs and verifies with a single HS256 shared secret.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{hmacSecret: []byte(secret)}
+}
+
+// NewKeySet builds a rotating key set. retireAfter is how long a key keeps
+// verifying tokens once its successor is active, it should be at least the
+// access token lifetime. hmacSecret may be empty; otherwise HS256 tokens are
+// accepted until hmacUntil, which should be at least one access token lifetime
+// after the keys were rolled out.
+func NewKeySet(keys []*SigningKey, retireAfter time.Duration, hmacSecret string, hmacUntil time.Time) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+	if hmacSecret != "" && hmacUntil.IsZero() {
+		return nil, errors.New("an HMAC secret next to signing keys needs a cutoff")
+	}
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key.Kid == "" {
+			return nil, errors.New("signing key without kid")
+		}
+		if _, ok := seen[key.Kid]; ok {
+			return nil, fmt.Errorf("duplicate signing key kid %q", key.Kid)
+		}
+		seen[key.Kid] = struct{}{}
+	}
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+	var secret []byte
+	if hmacSecret != "" {
+		secret = []byte(hmacSecret)
+	}
+	return &KeySet{keys: sorted, hmacSecret: secret, hmacUntil: hmacUntil, retireAfter: retireAfter}, nil
+}
+
+// hmacKey returns the HS256 secret if it may still be used at now: always in
+// a set built by NewHMACKeySet, until the cutoff next to asymmetric keys.
+func (k *KeySet) hmacKey(now time.Time) []byte {
+	if len(k.keys) > 0 && !now.Before(k.hmacUntil) {
+		return nil
+	}
+	return k.hmacSecret
+}
+
+// LoadPEMKey reads a PKCS#8, PKCS#1 or SEC 1 private key and picks the signing
+// method from its type: RSA keys use RS256, P-256 keys ES256 and Ed25519 keys EdDSA.
+func LoadPEMKey(kid, path string, activeFrom time.Time) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewSigningKey(kid, key, activeFrom)
+}
+
+// NewSigningKey wraps an already parsed private key.
+func NewSigningKey(kid string, key any, activeFrom time.Time) (*SigningKey, error) {
+	signingKey := &SigningKey{Kid: kid, ActiveFrom: activeFrom}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signingKey.Method, signingKey.Private = jwt.SigningMethodRS256, k
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("key %s: only P-256 EC keys are supported", kid)
+		}
+		signingKey.Method, signingKey.Private = jwt.SigningMethodES256, k
+	case ed25519.PrivateKey:
+		signingKey.Method, signingKey.Private = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %s: unsupported key type %T", kid, key)
+	}
+	return signingKey, nil
+}
+
+// Current returns the key that signs tokens at now, nil when the set only has
+// an HMAC secret or no key is active yet.
+func (k *KeySet) Current(now time.Time) *SigningKey {
+	var current *SigningKey
+	for _, key := range k.keys {
+		if key.ActiveFrom.After(now) {
+			break
+		}
+		current = key
+	}
+	return current
+}
+
+// Verifier returns the key with the given kid if it may still verify tokens at
+// now: it is the current key, or its successor has been active for less than
+// the retirement window. Keys that are not active yet never verify.
+func (k *KeySet) Verifier(kid string, now time.Time) *SigningKey {
+	for i, key := range k.keys {
+		if key.Kid != kid {
+			continue
+		}
+		if key.ActiveFrom.After(now) {
+			return nil
+		}
+		if i+1 < len(k.keys) {
+			next := k.keys[i+1]
+			if !next.ActiveFrom.After(now) && now.Sub(next.ActiveFrom) > k.retireAfter {
+				return nil
+			}
+		}
+		return key
+	}
+	return nil
+}
+
+// Published returns the keys exposed through JWKS: every key that can still
+// verify plus upcoming keys, so verifiers can cache them before they sign.
+func (k *KeySet) Published(now time.Time) []*SigningKey {
+	var published []*SigningKey
+	for _, key := range k.keys {
+		if key.ActiveFrom.After(now) || k.Verifier(key.Kid, now) != nil {
+			published = append(published, key)
+		}
+	}
+	return published
+}
@@ -0,0 +1,139 @@
+package signature
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// Supported password hashing algorithms.
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// PasswordConfig is the current hashing policy. New hashes are made with it,
+// and verified hashes made with anything else are reported for rehashing.
+type PasswordConfig struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32 // in KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+}
+
+// PasswordHasher produces self-describing hashes: argon2id hashes use the PHC
+// string format ($argon2id$v=19$m=...,t=...,p=...$salt$hash) and bcrypt hashes
+// their own $2a$<cost>$ format, so any stored hash can be verified whatever
+// the current policy is.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encoded and whether encoded was
+	// made with an outdated algorithm or parameters and should be replaced.
+	Verify(password, encoded string) (ok bool, needsRehash bool)
+}
+
+type passwordHasher struct {
+	conf *PasswordConfig
+}
+
+func NewPasswordHasher(conf *PasswordConfig) (PasswordHasher, error) {
+	switch conf.Algorithm {
+	case PasswordAlgorithmArgon2id:
+		if conf.Argon2Memory == 0 || conf.Argon2Iterations == 0 || conf.Argon2Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be positive")
+		}
+	case PasswordAlgorithmBcrypt:
+		if conf.BcryptCost < bcrypt.MinCost || conf.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hashing algorithm %q", conf.Algorithm)
+	}
+	return &passwordHasher{conf: conf}, nil
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	if h.conf.Algorithm == PasswordAlgorithmBcrypt {
+		bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.conf.BcryptCost)
+		return string(bytes), err
+	}
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt,
+		h.conf.Argon2Iterations, h.conf.Argon2Memory, h.conf.Argon2Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.conf.Argon2Memory, h.conf.Argon2Iterations, h.conf.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *passwordHasher) Verify(password, encoded string) (bool, bool) {
+	if strings.HasPrefix(encoded, "$argon2id$") {
+		params, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return false, false
+		}
+		actual := argon2.IDKey([]byte(password), salt,
+			params.Argon2Iterations, params.Argon2Memory, params.Argon2Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, false
+		}
+		return true, h.conf.Algorithm != PasswordAlgorithmArgon2id ||
+			params.Argon2Memory != h.conf.Argon2Memory ||
+			params.Argon2Iterations != h.conf.Argon2Iterations ||
+			params.Argon2Parallelism != h.conf.Argon2Parallelism ||
+			len(key) != argon2KeyLength
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return false, false
+	}
+	if bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) != nil {
+		return false, false
+	}
+	return true, h.conf.Algorithm != PasswordAlgorithmBcrypt || cost != h.conf.BcryptCost
+}
+
+// decodeArgon2id parses "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>".
+func decodeArgon2id(encoded string) (*PasswordConfig, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, nil, nil, errors.New("malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	params := &PasswordConfig{Algorithm: PasswordAlgorithmArgon2id}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&params.Argon2Memory, &params.Argon2Iterations, &params.Argon2Parallelism); err != nil {
+		return nil, nil, nil, err
+	}
+	if params.Argon2Memory == 0 || params.Argon2Iterations == 0 || params.Argon2Parallelism == 0 {
+		return nil, nil, nil, errors.New("malformed argon2id parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, errors.New("malformed argon2id hash")
+	}
+	return params, salt, key, nil
+}
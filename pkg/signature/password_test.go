@@ -0,0 +1,127 @@
+package signature
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"testing"
+)
+
+var testArgon2Config = &PasswordConfig{
+	Algorithm:         PasswordAlgorithmArgon2id,
+	BcryptCost:        bcrypt.MinCost,
+	Argon2Memory:      1024,
+	Argon2Iterations:  1,
+	Argon2Parallelism: 1,
+}
+
+var testBcryptConfig = &PasswordConfig{
+	Algorithm:  PasswordAlgorithmBcrypt,
+	BcryptCost: bcrypt.MinCost,
+}
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	hasher, err := NewPasswordHasher(testArgon2Config)
+	require.NoError(t, err)
+
+	hash, err := hasher.Hash("SecurePass123!")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	ok, needsRehash := hasher.Verify("SecurePass123!", hash)
+	assert.True(t, ok)
+	assert.False(t, needsRehash)
+
+	ok, _ = hasher.Verify("WrongPass123!", hash)
+	assert.False(t, ok)
+}
+
+func TestPasswordHasher_Bcrypt(t *testing.T) {
+	hasher, err := NewPasswordHasher(testBcryptConfig)
+	require.NoError(t, err)
+
+	hash, err := hasher.Hash("SecurePass123!")
+	require.NoError(t, err)
+	cost, err := bcrypt.Cost([]byte(hash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost, cost)
+
+	ok, needsRehash := hasher.Verify("SecurePass123!", hash)
+	assert.True(t, ok)
+	assert.False(t, needsRehash)
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	bcryptHasher, err := NewPasswordHasher(testBcryptConfig)
+	require.NoError(t, err)
+	argon2Hasher, err := NewPasswordHasher(testArgon2Config)
+	require.NoError(t, err)
+	bcryptHash, err := bcryptHasher.Hash("SecurePass123!")
+	require.NoError(t, err)
+	argon2Hash, err := argon2Hasher.Hash("SecurePass123!")
+	require.NoError(t, err)
+
+	t.Run("bcrypt hash under argon2id policy", func(t *testing.T) {
+		ok, needsRehash := argon2Hasher.Verify("SecurePass123!", bcryptHash)
+		assert.True(t, ok)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("argon2id hash under bcrypt policy", func(t *testing.T) {
+		ok, needsRehash := bcryptHasher.Verify("SecurePass123!", argon2Hash)
+		assert.True(t, ok)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("bcrypt cost changed", func(t *testing.T) {
+		hasher, err := NewPasswordHasher(&PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+		require.NoError(t, err)
+		ok, needsRehash := hasher.Verify("SecurePass123!", bcryptHash)
+		assert.True(t, ok)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("argon2id parameters changed", func(t *testing.T) {
+		conf := *testArgon2Config
+		conf.Argon2Iterations = 2
+		hasher, err := NewPasswordHasher(&conf)
+		require.NoError(t, err)
+		ok, needsRehash := hasher.Verify("SecurePass123!", argon2Hash)
+		assert.True(t, ok)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("wrong password is never reported for rehash", func(t *testing.T) {
+		ok, needsRehash := argon2Hasher.Verify("WrongPass123!", bcryptHash)
+		assert.False(t, ok)
+		assert.False(t, needsRehash)
+	})
+}
+
+func TestPasswordHasher_RejectsMalformedHashes(t *testing.T) {
+	hasher, err := NewPasswordHasher(testArgon2Config)
+	require.NoError(t, err)
+
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0$aGFzaA",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdHNhbHRzYWx0$aGFzaA",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdHNhbHRzYWx0$aGFzaA",
+	} {
+		ok, needsRehash := hasher.Verify("SecurePass123!", hash)
+		assert.False(t, ok, hash)
+		assert.False(t, needsRehash, hash)
+	}
+}
+
+func TestNewPasswordHasher_InvalidConfig(t *testing.T) {
+	_, err := NewPasswordHasher(&PasswordConfig{Algorithm: "md5"})
+	assert.Error(t, err)
+	_, err = NewPasswordHasher(&PasswordConfig{Algorithm: PasswordAlgorithmBcrypt, BcryptCost: 100})
+	assert.Error(t, err)
+	_, err = NewPasswordHasher(&PasswordConfig{Algorithm: PasswordAlgorithmArgon2id})
+	assert.Error(t, err)
+}
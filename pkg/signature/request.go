@@ -0,0 +1,41 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// RequestStringToSign builds the canonical form of a server-to-server request:
+// the upper case method, the path with its query string, the unix timestamp,
+// the nonce and the hex SHA-256 of the body, joined by newlines.
+func RequestStringToSign(method, path, timestamp, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		timestamp,
+		nonce,
+		hex.EncodeToString(sum[:]),
+	}, "\n")
+}
+
+// SignRequest returns the hex encoded HMAC-SHA256 of stringToSign under secret.
+func SignRequest(secret, stringToSign string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequestSignature reports whether sig is the signature of stringToSign,
+// comparing in constant time.
+func VerifyRequestSignature(secret, stringToSign, sig string) bool {
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	return hmac.Equal(got, mac.Sum(nil))
+}
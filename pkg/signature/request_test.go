@@ -0,0 +1,24 @@
+package signature
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRequestStringToSign(t *testing.T) {
+	got := RequestStringToSign("post", "/signature/verify?a=1", "1700000000", "n-1", []byte(`{"a":1}`))
+
+	assert.Equal(t, "POST\n/signature/verify?a=1\n1700000000\nn-1\n"+
+		"015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862", got)
+}
+
+func TestSignRequest(t *testing.T) {
+	stringToSign := RequestStringToSign("GET", "/signature/verify", "1700000000", "n-1", nil)
+	sig := SignRequest("partner-secret", stringToSign)
+
+	assert.Len(t, sig, 64)
+	assert.True(t, VerifyRequestSignature("partner-secret", stringToSign, sig))
+	assert.False(t, VerifyRequestSignature("other-secret", stringToSign, sig))
+	assert.False(t, VerifyRequestSignature("partner-secret", stringToSign+"x", sig))
+	assert.False(t, VerifyRequestSignature("partner-secret", stringToSign, "not-hex"))
+}
@@ -1,92 +1,256 @@
 package signature
 
 import (
+	"errors"
 	"fmt"
 	"github.com/golang-jwt/jwt/v4"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 	"time"
 	"user-simple-crud/pkg/exception"
 )
 
 type Signature struct {
-	jwtSecretAccessToken string
+	keys   *KeySet
+	conf   *Config
+	hasher PasswordHasher
+}
+
+// Config holds the registered claims every access token is issued with and
+// checked against.
+type Config struct {
+	Issuer         string
+	Audience       []string
+	AccessTokenTTL time.Duration
 }
 
 type Signaturer interface {
-	HashBscryptPassword(password string) (string, error)
-	CheckBscryptPasswordHash(password, hash string) bool
-	GenerateJWT(username string) (string, error)
+	// HashPassword hashes with the current PasswordConfig policy
+	HashPassword(password string) (string, error)
+	// CheckPasswordHash verifies any supported hash format and reports whether
+	// the hash should be replaced with one made under the current policy
+	CheckPasswordHash(password, hash string) (ok bool, needsRehash bool)
+	// GenerateJWT signs claims, filling in the iss, aud, jti, iat, nbf and, unless
+	// the caller set a shorter one, exp
+	GenerateJWT(claims JWTClaims) (string, error)
+	// GenerateIDToken signs OpenID Connect ID token claims, filling in the iss,
+	// jti, iat and exp. The caller sets sub and aud, the client the token is for.
+	GenerateIDToken(claims IDTokenClaims) (string, error)
 	JWTCheck(token string) (*JwtAuthenticationRes, *exception.Exception)
+	// JWKS returns the public keys verifiers should trust right now
+	JWKS() *JSONWebKeySet
 }
 
-func NewSignature(jwtToken string) Signaturer {
+func NewSignature(keys *KeySet, conf *Config, hasher PasswordHasher) Signaturer {
 	return &Signature{
-		jwtSecretAccessToken: jwtToken,
+		keys:   keys,
+		conf:   conf,
+		hasher: hasher,
 	}
 }
-func (s *Signature) HashBscryptPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+func (s *Signature) HashPassword(password string) (string, error) {
+	return s.hasher.Hash(password)
 }
 
-func (s *Signature) CheckBscryptPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+func (s *Signature) CheckPasswordHash(password, hash string) (bool, bool) {
+	return s.hasher.Verify(password, hash)
 }
 
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	Username string `json:"Username"`
+	Username    string   `json:"Username"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// SessionID is set on tokens issued for a first-party login
+	SessionID string `json:"sid,omitempty"`
+	// Scope and ClientID are only set on tokens issued to an OAuth client
+	Scope    string `json:"scope,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	// Actor is set while an admin impersonates the subject (RFC 8693 act claim)
+	Actor *ActorClaim `json:"act,omitempty"`
+}
+
+// ActorClaim names who is really behind an impersonation token.
+type ActorClaim struct {
+	Subject  string `json:"sub"`
+	Username string `json:"username,omitempty"`
+}
+
+// IDTokenClaims are the claims of an OpenID Connect ID token.
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	AuthTime          *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce             string           `json:"nonce,omitempty"`
+	PreferredUsername string           `json:"preferred_username,omitempty"`
+	Email             string           `json:"email,omitempty"`
+	EmailVerified     *bool            `json:"email_verified,omitempty"`
 }
 
 type JwtAuthenticationRes struct {
-	Username string `json:"username"`
-	Token    string `json:"token"`
+	Username    string   `json:"username"`
+	Subject     string   `json:"subject"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	Scope       string   `json:"scope,omitempty"`
+	ClientID    string   `json:"client_id,omitempty"`
+	SessionID   string   `json:"session_id,omitempty"`
+	// APIKey is set when the request carried an API key instead of an access token
+	APIKey bool `json:"api_key,omitempty"`
+	// Actor is the admin impersonating Subject, nil for a regular token
+	Actor     *ActorClaim `json:"actor,omitempty"`
+	TokenID   string      `json:"token_id"`
+	IssuedAt  time.Time   `json:"issued_at"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	Token     string      `json:"token"`
 }
 
-func (s *Signature) GenerateJWT(username string) (string, error) {
-	claims := JWTClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "user-simple-crud",
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(1 * time.Hour)),
-		},
-		Username: username,
+func (s *Signature) GenerateJWT(claims JWTClaims) (string, error) {
+	now := time.Now()
+	claims.Issuer = s.conf.Issuer
+	claims.Audience = s.conf.Audience
+	claims.ID = uuid.NewString()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+	expiresAt := now.Add(s.conf.AccessTokenTTL)
+	if claims.ExpiresAt == nil || claims.ExpiresAt.Time.After(expiresAt) {
+		claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	}
+	if key := s.keys.Current(now); key != nil {
+		return signWith(key, claims)
+	}
+	secret := s.keys.hmacKey(now)
+	if secret == nil {
+		return "", errors.New("no signing key is active")
 	}
 	token := jwt.NewWithClaims(
 		jwt.SigningMethodHS256,
 		claims,
 	)
-	signedToken, err := token.SignedString([]byte(s.jwtSecretAccessToken))
+	signedToken, err := token.SignedString(secret)
 	if err != nil {
 		return "", err
 	}
 	return signedToken, nil
 }
 
-func (s *Signature) JWTCheck(token string) (*JwtAuthenticationRes, *exception.Exception) {
-	fmt.Println("JWTAuthentication", token)
-	fmt.Println("secret", s.jwtSecretAccessToken)
+// GenerateIDToken only signs with an asymmetric key: clients verify ID tokens
+// through the JWKS and could not check one signed with the HMAC secret.
+func (s *Signature) GenerateIDToken(claims IDTokenClaims) (string, error) {
+	now := time.Now()
+	key := s.keys.Current(now)
+	if key == nil {
+		return "", errors.New("id tokens require an active asymmetric signing key")
+	}
+	claims.Issuer = s.conf.Issuer
+	claims.ID = uuid.NewString()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(s.conf.AccessTokenTTL))
+	return signWith(key, claims)
+}
+
+func signWith(key *SigningKey, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.Private)
+}
 
-	jwtToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+func (s *Signature) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		secret := s.keys.hmacKey(time.Now())
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || secret == nil {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(s.jwtSecretAccessToken), nil
-	})
+		return secret, nil
+	}
+	key := s.keys.Verifier(kid, time.Now())
+	if key == nil {
+		return nil, fmt.Errorf("unknown or retired key %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Private.Public(), nil
+}
+
+func (s *Signature) JWKS() *JSONWebKeySet {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range s.keys.Published(time.Now()) {
+		set.Keys = append(set.Keys, key.PublicJWK())
+	}
+	return set
+}
+
+func (s *Signature) JWTCheck(token string) (*JwtAuthenticationRes, *exception.Exception) {
+	claims := &JWTClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.verificationKey)
 	if err != nil {
 		return nil, exception.Unauthenticated("Invalid token, " + err.Error())
 	}
-
-	var username string
-	claims, ok := jwtToken.Claims.(jwt.MapClaims)
-	if ok || jwtToken.Valid {
-		username = fmt.Sprintf("%v", claims["name"])
-	} else {
-		return nil, exception.Unauthenticated("Invalid token")
+	if err := s.verifyClaims(claims); err != nil {
+		return nil, exception.Unauthenticated("Invalid token, " + err.Error())
 	}
 
 	return &JwtAuthenticationRes{
-		Username: username,
-		Token:    token,
+		Username:    claims.Username,
+		Subject:     claims.Subject,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		Scope:       claims.Scope,
+		ClientID:    claims.ClientID,
+		SessionID:   claims.SessionID,
+		Actor:       claims.Actor,
+		TokenID:     claims.ID,
+		IssuedAt:    claims.IssuedAt.Time,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		Token:       token,
 	}, nil
 }
+
+// verifyClaims enforces what jwt.RegisteredClaims.Valid leaves optional. The
+// parser has already rejected tokens that are expired, not yet valid or
+// issued in the future.
+func (s *Signature) verifyClaims(claims *JWTClaims) error {
+	if claims.Subject == "" {
+		return errors.New("token has no subject")
+	}
+	if claims.ID == "" {
+		return errors.New("token has no id")
+	}
+	if claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return errors.New("token has no issued at or expiry")
+	}
+	if claims.Actor != nil && (claims.Actor.Subject == "" || claims.Actor.Subject == claims.Subject) {
+		return errors.New("token has an invalid actor")
+	}
+	if !claims.VerifyIssuer(s.conf.Issuer, true) {
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	for _, audience := range s.conf.Audience {
+		if claims.VerifyAudience(audience, true) {
+			return nil
+		}
+	}
+	return errors.New("token is not intended for this audience")
+}
+
+// IsImpersonated reports whether an admin is acting as the subject.
+func (r *JwtAuthenticationRes) IsImpersonated() bool {
+	return r.Actor != nil
+}
+
+// IsFirstParty reports whether the user signed in to this service directly,
+// rather than through an OAuth client or an API key whose scopes may be
+// narrower than the user's.
+func (r *JwtAuthenticationRes) IsFirstParty() bool {
+	return r.ClientID == "" && !r.APIKey
+}
+
+// HasPermission reports whether the authenticated token grants permission.
+func (r *JwtAuthenticationRes) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
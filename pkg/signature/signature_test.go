@@ -0,0 +1,306 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testConfig = &Config{
+	Issuer:         "user-simple-crud",
+	Audience:       []string{"user-simple-crud"},
+	AccessTokenTTL: time.Hour,
+}
+
+const testSubject = "123e4567-e89b-12d3-a456-426614174000"
+
+func newTestKey(t *testing.T, kid, alg string, activeFrom time.Time) *SigningKey {
+	var key any
+	var err error
+	switch alg {
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+	signingKey, err := NewSigningKey(kid, key, activeFrom)
+	require.NoError(t, err)
+	return signingKey
+}
+
+func TestSignature_AsymmetricRoundTrip(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			key := newTestKey(t, "key-"+alg, alg, time.Time{})
+			keySet, err := NewKeySet([]*SigningKey{key}, time.Hour, "", time.Time{})
+			require.NoError(t, err)
+			s := NewSignature(keySet, testConfig, nil)
+
+			token, err := s.GenerateJWT(JWTClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject},
+				Username:         "john_doe",
+			})
+			require.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg, parsed.Header["alg"])
+			assert.Equal(t, key.Kid, parsed.Header["kid"])
+
+			res, exc := s.JWTCheck(token)
+			assert.Nil(t, exc)
+			assert.Equal(t, testSubject, res.Subject)
+			assert.Equal(t, "john_doe", res.Username)
+
+			jwks := s.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, key.Kid, jwks.Keys[0].Kid)
+			assert.Equal(t, alg, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestSignature_GenerateIDToken(t *testing.T) {
+	key := newTestKey(t, "es", "ES256", time.Time{})
+	keySet, err := NewKeySet([]*SigningKey{key}, time.Hour, "", time.Time{})
+	require.NoError(t, err)
+	s := NewSignature(keySet, testConfig, nil)
+	clientID := "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	token, err := s.GenerateIDToken(IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject, Audience: jwt.ClaimStrings{clientID}},
+		Nonce:            "n-0S6_WzA2Mj",
+	})
+	require.NoError(t, err)
+
+	t.Run("Verifiable With Published Key", func(t *testing.T) {
+		claims := &IDTokenClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+			return key.Private.Public(), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testConfig.Issuer, claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{clientID}, claims.Audience)
+		assert.Equal(t, "n-0S6_WzA2Mj", claims.Nonce)
+	})
+
+	t.Run("Not Accepted As Access Token", func(t *testing.T) {
+		_, exc := s.JWTCheck(token)
+		assert.NotNil(t, exc)
+	})
+
+	t.Run("HMAC Only Key Set Rejected", func(t *testing.T) {
+		hmac := NewSignature(NewHMACKeySet("wkhB8NarrReKujasQzlRaOQGOO4S1G884ol9SIyQ7Fr4zxLBJI9Ezml4DeaisAss"), testConfig, nil)
+		_, err := hmac.GenerateIDToken(IDTokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject, Audience: jwt.ClaimStrings{clientID}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Now()
+	oldKey := newTestKey(t, "old", "ES256", now.Add(-48*time.Hour))
+	newKey := newTestKey(t, "new", "ES256", now.Add(-time.Hour))
+	nextKey := newTestKey(t, "next", "ES256", now.Add(24*time.Hour))
+	keySet, err := NewKeySet([]*SigningKey{nextKey, oldKey, newKey}, 2*time.Hour, "", time.Time{})
+	require.NoError(t, err)
+
+	t.Run("Newest Active Key Signs", func(t *testing.T) {
+		assert.Equal(t, "new", keySet.Current(now).Kid)
+		assert.Equal(t, "next", keySet.Current(now.Add(25*time.Hour)).Kid)
+	})
+
+	t.Run("Previous Key Verifies During Retirement Window", func(t *testing.T) {
+		assert.NotNil(t, keySet.Verifier("old", now))
+		assert.Nil(t, keySet.Verifier("old", now.Add(2*time.Hour)))
+	})
+
+	t.Run("Upcoming Key Is Published But Does Not Verify", func(t *testing.T) {
+		assert.Nil(t, keySet.Verifier("next", now))
+		var kids []string
+		for _, key := range keySet.Published(now) {
+			kids = append(kids, key.Kid)
+		}
+		assert.Equal(t, []string{"old", "new", "next"}, kids)
+	})
+
+	t.Run("Duplicate Kid Rejected", func(t *testing.T) {
+		_, err := NewKeySet([]*SigningKey{oldKey, oldKey}, time.Hour, "", time.Time{})
+		assert.Error(t, err)
+	})
+}
+
+func TestSignature_HMACMigration(t *testing.T) {
+	secret := "wkhB8NarrReKujasQzlRaOQGOO4S1G884ol9SIyQ7Fr4zxLBJI9Ezml4DeaisAss"
+	legacy := NewSignature(NewHMACKeySet(secret), testConfig, nil)
+	legacyToken, err := legacy.GenerateJWT(JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject},
+		Username:         "john_doe",
+	})
+	require.NoError(t, err)
+
+	t.Run("Legacy HS256 Token Accepted Until The Cutoff", func(t *testing.T) {
+		keySet, err := NewKeySet([]*SigningKey{newTestKey(t, "rsa", "RS256", time.Time{})}, time.Hour, secret, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		res, exc := NewSignature(keySet, testConfig, nil).JWTCheck(legacyToken)
+		assert.Nil(t, exc)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("Legacy HS256 Token Rejected After The Cutoff", func(t *testing.T) {
+		keySet, err := NewKeySet([]*SigningKey{newTestKey(t, "rsa", "RS256", time.Time{})}, time.Hour, secret, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		_, exc := NewSignature(keySet, testConfig, nil).JWTCheck(legacyToken)
+		assert.NotNil(t, exc)
+	})
+
+	t.Run("No HS256 Signing After The Cutoff", func(t *testing.T) {
+		keySet, err := NewKeySet([]*SigningKey{newTestKey(t, "rsa", "RS256", time.Now().Add(time.Hour))}, time.Hour, secret, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+		_, err = NewSignature(keySet, testConfig, nil).GenerateJWT(JWTClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Secret Without Cutoff Refused", func(t *testing.T) {
+		_, err := NewKeySet([]*SigningKey{newTestKey(t, "rsa", "RS256", time.Time{})}, time.Hour, secret, time.Time{})
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256 Token Rejected Once Keys Are Configured Without Opt-In", func(t *testing.T) {
+		keySet, err := NewKeySet([]*SigningKey{newTestKey(t, "rsa", "RS256", time.Time{})}, time.Hour, "", time.Time{})
+		require.NoError(t, err)
+		_, exc := NewSignature(keySet, testConfig, nil).JWTCheck(legacyToken)
+		assert.NotNil(t, exc)
+	})
+
+	t.Run("HS256 Token Claiming A Public Key Kid Rejected", func(t *testing.T) {
+		key := newTestKey(t, "rsa", "RS256", time.Time{})
+		keySet, err := NewKeySet([]*SigningKey{key}, time.Hour, secret, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{Username: "john_doe"})
+		forged.Header["kid"] = key.Kid
+		token, err := forged.SignedString([]byte(secret))
+		require.NoError(t, err)
+		_, exc := NewSignature(keySet, testConfig, nil).JWTCheck(token)
+		assert.NotNil(t, exc)
+	})
+}
+
+func TestSignature_JWTCheckClaims(t *testing.T) {
+	secret := "wkhB8NarrReKujasQzlRaOQGOO4S1G884ol9SIyQ7Fr4zxLBJI9Ezml4DeaisAss"
+	s := NewSignature(NewHMACKeySet(secret), testConfig, nil)
+	sign := func(claims JWTClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		require.NoError(t, err)
+		return token
+	}
+	valid := func() JWTClaims {
+		now := time.Now()
+		return JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    testConfig.Issuer,
+				Subject:   testSubject,
+				Audience:  jwt.ClaimStrings{"user-simple-crud"},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+				NotBefore: jwt.NewNumericDate(now),
+				IssuedAt:  jwt.NewNumericDate(now),
+				ID:        "a4c1f0f4-3d0c-4d3e-9f62-7d6f0b2b9c11",
+			},
+			Username:    "john_doe",
+			Roles:       []string{"admin"},
+			Permissions: []string{"users:read"},
+		}
+	}
+
+	t.Run("Typed Claims Success", func(t *testing.T) {
+		res, exc := s.JWTCheck(sign(valid()))
+		require.Nil(t, exc)
+		assert.Equal(t, "john_doe", res.Username)
+		assert.Equal(t, testSubject, res.Subject)
+		assert.Equal(t, "a4c1f0f4-3d0c-4d3e-9f62-7d6f0b2b9c11", res.TokenID)
+		assert.Equal(t, []string{"admin"}, res.Roles)
+		assert.True(t, res.HasPermission("users:read"))
+	})
+
+	rejected := map[string]func(c *JWTClaims){
+		"Wrong Issuer":      func(c *JWTClaims) { c.Issuer = "someone-else" },
+		"Wrong Audience":    func(c *JWTClaims) { c.Audience = jwt.ClaimStrings{"another-api"} },
+		"Missing Audience":  func(c *JWTClaims) { c.Audience = nil },
+		"Missing Subject":   func(c *JWTClaims) { c.Subject = "" },
+		"Missing Token ID":  func(c *JWTClaims) { c.ID = "" },
+		"Missing Issued At": func(c *JWTClaims) { c.IssuedAt = nil },
+		"Not Yet Valid":     func(c *JWTClaims) { c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour)) },
+		"Issued In Future":  func(c *JWTClaims) { c.IssuedAt = jwt.NewNumericDate(time.Now().Add(time.Hour)) },
+		"Expired":           func(c *JWTClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) },
+		"Actor Is Subject":  func(c *JWTClaims) { c.Actor = &ActorClaim{Subject: testSubject} },
+		"Actor Without Sub": func(c *JWTClaims) { c.Actor = &ActorClaim{Username: "admin"} },
+	}
+	for name, mutate := range rejected {
+		t.Run(name, func(t *testing.T) {
+			claims := valid()
+			mutate(&claims)
+			_, exc := s.JWTCheck(sign(claims))
+			assert.NotNil(t, exc)
+		})
+	}
+}
+
+func TestLoadPEMKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "es256.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	signingKey, err := LoadPEMKey("es", path, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", signingKey.Method.Alg())
+}
+
+func TestSignature_ImpersonationClaims(t *testing.T) {
+	s := NewSignature(NewHMACKeySet("wkhB8NarrReKujasQzlRaOQGOO4S1G884ol9SIyQ7Fr4zxLBJI9Ezml4DeaisAss"), testConfig, nil)
+	actor := &ActorClaim{Subject: "8f14e45f-ceea-467f-a8f4-9d2c7c1e2b33", Username: "admin"}
+
+	t.Run("Shorter Expiry Is Kept", func(t *testing.T) {
+		expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+		token, err := s.GenerateJWT(JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject, ExpiresAt: jwt.NewNumericDate(expiresAt)},
+			Actor:            actor,
+		})
+		require.NoError(t, err)
+
+		res, exc := s.JWTCheck(token)
+		require.Nil(t, exc)
+		assert.True(t, res.IsImpersonated())
+		assert.Equal(t, actor, res.Actor)
+		assert.True(t, res.ExpiresAt.Equal(expiresAt))
+	})
+
+	t.Run("Longer Expiry Is Capped", func(t *testing.T) {
+		token, err := s.GenerateJWT(JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{Subject: testSubject, ExpiresAt: jwt.NewNumericDate(time.Now().Add(48 * time.Hour))},
+		})
+		require.NoError(t, err)
+
+		res, exc := s.JWTCheck(token)
+		require.Nil(t, exc)
+		assert.False(t, res.IsImpersonated())
+		assert.WithinDuration(t, time.Now().Add(testConfig.AccessTokenTTL), res.ExpiresAt, 2*time.Second)
+	})
+}
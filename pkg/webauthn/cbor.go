@@ -0,0 +1,166 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// CBOR major types, RFC 8949 section 3.1.
+const (
+	cborUint   = 0
+	cborNegInt = 1
+	cborBytes  = 2
+	cborText   = 3
+	cborArray  = 4
+	cborMap    = 5
+	cborSimple = 7
+)
+
+const (
+	// maxCBORDepth bounds nesting so a hostile payload can't exhaust the stack
+	maxCBORDepth = 16
+	// maxCBORItems bounds the declared length of an array or map
+	maxCBORItems = 1024
+)
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// cborDecoder reads the subset of CBOR authenticators send: definite length
+// integers, byte and text strings, arrays, maps and simple values. Integers
+// decode to int64, byte strings to []byte, text to string, arrays to []any
+// and maps to map[any]any.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// decodeCBOR decodes one item from data and returns it with the number of
+// bytes it took, as authenticator data puts extensions after the COSE key.
+func decodeCBOR(data []byte) (any, int, error) {
+	d := &cborDecoder{data: data}
+	value, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, d.pos, nil
+}
+
+func (d *cborDecoder) head() (byte, uint64, error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, errCBORTruncated
+	}
+	initial := d.data[d.pos]
+	d.pos++
+	major, info := initial>>5, initial&0x1f
+	var size int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+	if len(d.data)-d.pos < size {
+		return 0, 0, errCBORTruncated
+	}
+	var arg uint64
+	switch size {
+	case 1:
+		arg = uint64(d.data[d.pos])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(d.data[d.pos:]))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(d.data[d.pos:]))
+	case 8:
+		arg = binary.BigEndian.Uint64(d.data[d.pos:])
+	}
+	d.pos += size
+	return major, arg, nil
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > maxCBORDepth {
+		return nil, errors.New("cbor: nested too deeply")
+	}
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint, cborNegInt:
+		if arg > 1<<63-1 {
+			return nil, errors.New("cbor: integer overflows int64")
+		}
+		if major == cborNegInt {
+			return -1 - int64(arg), nil
+		}
+		return int64(arg), nil
+	case cborBytes, cborText:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBORTruncated
+		}
+		value := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		if major == cborText {
+			return string(value), nil
+		}
+		return append([]byte(nil), value...), nil
+	case cborArray:
+		if arg > maxCBORItems {
+			return nil, errors.New("cbor: array too long")
+		}
+		items := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		if arg > maxCBORItems {
+			return nil, errors.New("cbor: map too long")
+		}
+		items := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errors.New("cbor: map keys must be integers or text")
+			}
+			if _, ok := items[key]; ok {
+				return nil, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items[key] = value
+		}
+		return items, nil
+	case cborSimple:
+		switch arg {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+	return nil, fmt.Errorf("cbor: unsupported major type %d", major)
+}
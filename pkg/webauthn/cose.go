@@ -0,0 +1,119 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers accepted for credentials, in order of preference.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// SupportedAlgorithms are offered to authenticators when registering.
+var SupportedAlgorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key parameters, RFC 9053.
+const (
+	coseKty    = 1
+	coseAlg    = 3
+	coseCrv    = -1
+	coseX      = -2
+	coseY      = -3
+	coseRSAN   = -1
+	coseRSAE   = -2
+	ktyOKP     = 1
+	ktyEC2     = 2
+	ktyRSA     = 3
+	crvP256    = 1
+	crvEd25519 = 6
+	// minRSABits rejects RSA keys too short to be trusted
+	minRSABits = 2048
+)
+
+// parseCOSEKey converts a decoded COSE_Key to a Go public key and its algorithm.
+func parseCOSEKey(value any) (crypto.PublicKey, int, error) {
+	key, ok := value.(map[any]any)
+	if !ok {
+		return nil, 0, errors.New("credential public key is not a COSE key")
+	}
+	kty, _ := key[int64(coseKty)].(int64)
+	alg, _ := key[int64(coseAlg)].(int64)
+	switch {
+	case kty == ktyEC2 && alg == AlgES256:
+		crv, _ := key[int64(coseCrv)].(int64)
+		x, _ := key[int64(coseX)].([]byte)
+		y, _ := key[int64(coseY)].([]byte)
+		if crv != crvP256 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid ES256 public key")
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, errors.New("ES256 public key is not on the curve")
+		}
+		return pub, AlgES256, nil
+	case kty == ktyOKP && alg == AlgEdDSA:
+		crv, _ := key[int64(coseCrv)].(int64)
+		x, _ := key[int64(coseX)].([]byte)
+		if crv != crvEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("invalid EdDSA public key")
+		}
+		return ed25519.PublicKey(x), AlgEdDSA, nil
+	case kty == ktyRSA && alg == AlgRS256:
+		n, _ := key[int64(coseRSAN)].([]byte)
+		e, _ := key[int64(coseRSAE)].([]byte)
+		if len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("invalid RS256 public key")
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if pub.N.BitLen() < minRSABits {
+			return nil, 0, errors.New("RS256 public key is too short")
+		}
+		return pub, AlgRS256, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported credential key type %d with algorithm %d", kty, alg)
+}
+
+// verifySignature checks sig over data with a PKIX encoded public key
+// registered for alg.
+func verifySignature(publicKey []byte, alg int, data, sig []byte) error {
+	pub, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid stored public key: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	switch alg {
+	case AlgES256:
+		if key, ok := pub.(*ecdsa.PublicKey); ok {
+			if !ecdsa.VerifyASN1(key, digest[:], sig) {
+				return errors.New("invalid signature")
+			}
+			return nil
+		}
+	case AlgEdDSA:
+		if key, ok := pub.(ed25519.PublicKey); ok {
+			if !ed25519.Verify(key, data, sig) {
+				return errors.New("invalid signature")
+			}
+			return nil
+		}
+	case AlgRS256:
+		if key, ok := pub.(*rsa.PublicKey); ok {
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) != nil {
+				return errors.New("invalid signature")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("public key does not match algorithm %d", alg)
+}
@@ -0,0 +1,380 @@
+// Package webauthn implements the relying party side of the WebAuthn
+// registration and authentication ceremonies (W3C Web Authentication Level 2,
+// sections 7.1 and 7.2) for platform and roaming authenticators.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	challengeBytes = 32
+	// maxCredentialIDBytes is the limit set by the specification
+	maxCredentialIDBytes = 1023
+)
+
+// User verification requirements, passed on to the authenticator.
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent    = 0x01
+	flagUserVerified   = 0x04
+	flagBackupEligible = 0x08
+	flagBackedUp       = 0x10
+	flagAttestedData   = 0x40
+	flagExtensionData  = 0x80
+)
+
+const (
+	clientDataCreate = "webauthn.create"
+	clientDataGet    = "webauthn.get"
+)
+
+// Config describes the relying party. RPID is the domain credentials are
+// scoped to and Origins lists every origin allowed to run a ceremony for it.
+type Config struct {
+	RPID             string
+	RPName           string
+	Origins          []string
+	Timeout          time.Duration
+	UserVerification string
+}
+
+// RelyingParty builds ceremony options and verifies what authenticators return.
+type RelyingParty struct {
+	conf     *Config
+	rpIDHash [32]byte
+}
+
+func NewRelyingParty(conf *Config) *RelyingParty {
+	return &RelyingParty{conf: conf, rpIDHash: sha256.Sum256([]byte(conf.RPID))}
+}
+
+// RelyingPartyEntity names the relying party to the authenticator.
+type RelyingPartyEntity struct {
+	ID   string `json:"id" example:"localhost"`
+	Name string `json:"name" example:"user-simple-crud"`
+}
+
+// UserEntity names the account a credential is created for. ID is the user
+// handle, base64url encoded, that authenticators return on login.
+type UserEntity struct {
+	ID          string `json:"id" example:"MTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAw"`
+	Name        string `json:"name" example:"john_doe"`
+	DisplayName string `json:"displayName" example:"john_doe"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type" example:"public-key"`
+	Alg  int    `json:"alg" example:"-7"`
+}
+
+// CredentialDescriptor refers to an existing credential by its base64url ID.
+type CredentialDescriptor struct {
+	Type       string   `json:"type" example:"public-key"`
+	ID         string   `json:"id" example:"mT3eGz3bVqHc0y1pJ2xk6w"`
+	Transports []string `json:"transports,omitempty" example:"internal"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey" example:"preferred"`
+	UserVerification string `json:"userVerification" example:"preferred"`
+}
+
+// CreationOptions is the publicKey argument of navigator.credentials.create,
+// with binary values base64url encoded.
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout" example:"300000"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation" example:"none"`
+}
+
+// RequestOptions is the publicKey argument of navigator.credentials.get. An
+// empty AllowCredentials lets the user pick any discoverable credential.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge" example:"3q2-7wYl0Yw6mO0sJvN8gD1z7aVZ0Jm6cXl2pV0xq0E"`
+	Timeout          int64                  `json:"timeout" example:"300000"`
+	RPID             string                 `json:"rpId" example:"localhost"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification" example:"preferred"`
+}
+
+// Credential is a newly registered credential. PublicKey is PKIX encoded.
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte
+	Algorithm      int
+	SignCount      uint32
+	AAGUID         []byte
+	UserVerified   bool
+	BackupEligible bool
+	BackedUp       bool
+}
+
+// Assertion is the outcome of a verified login.
+type Assertion struct {
+	SignCount    uint32
+	UserVerified bool
+	BackedUp     bool
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	// set when flagAttestedData is
+	aaguid       []byte
+	credentialID []byte
+	publicKey    any
+}
+
+// NewChallenge returns a random, base64url encoded challenge.
+func NewChallenge() (string, error) {
+	b := make([]byte, challengeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ClientDataChallenge reads the challenge out of clientDataJSON without
+// verifying anything, so the caller can find the ceremony it belongs to.
+func ClientDataChallenge(clientDataJSON []byte) (string, error) {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return "", fmt.Errorf("invalid client data: %w", err)
+	}
+	if data.Challenge == "" {
+		return "", errors.New("client data has no challenge")
+	}
+	return data.Challenge, nil
+}
+
+// CreationOptions returns the options for registering a credential for user,
+// excluding the ones it already has.
+func (rp *RelyingParty) CreationOptions(
+	challenge string, user UserEntity, exclude []CredentialDescriptor,
+) *CreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: "public-key", Alg: alg})
+	}
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+	return &CreationOptions{
+		Challenge:          challenge,
+		RP:                 RelyingPartyEntity{ID: rp.conf.RPID, Name: rp.conf.RPName},
+		User:               user,
+		PubKeyCredParams:   params,
+		Timeout:            rp.conf.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: rp.conf.UserVerification,
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options for logging in with one of allow.
+func (rp *RelyingParty) RequestOptions(challenge string, allow []CredentialDescriptor) *RequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          rp.conf.Timeout.Milliseconds(),
+		RPID:             rp.conf.RPID,
+		AllowCredentials: allow,
+		UserVerification: rp.conf.UserVerification,
+	}
+}
+
+// VerifyRegistration checks the response to navigator.credentials.create and
+// returns the new credential. Attestation statements aren't verified: options
+// ask for none, and no decision here depends on the authenticator's make.
+func (rp *RelyingParty) VerifyRegistration(
+	clientDataJSON, attestationObject []byte, challenge string,
+) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, clientDataCreate, challenge); err != nil {
+		return nil, err
+	}
+	decoded, n, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+	if n != len(attestationObject) {
+		return nil, errors.New("invalid attestation object: trailing data")
+	}
+	object, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, errors.New("invalid attestation object")
+	}
+	format, _ := object["fmt"].(string)
+	statement, _ := object["attStmt"].(map[any]any)
+	rawAuthData, _ := object["authData"].([]byte)
+	if format == "" || statement == nil || rawAuthData == nil {
+		return nil, errors.New("attestation object is missing fmt, attStmt or authData")
+	}
+	if format == "none" && len(statement) != 0 {
+		return nil, errors.New("none attestation must have an empty statement")
+	}
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedData == 0 {
+		return nil, errors.New("authenticator data has no attested credential")
+	}
+	publicKey, alg, err := parseCOSEKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Credential{
+		ID:             authData.credentialID,
+		PublicKey:      encoded,
+		Algorithm:      alg,
+		SignCount:      authData.signCount,
+		AAGUID:         authData.aaguid,
+		UserVerified:   authData.flags&flagUserVerified != 0,
+		BackupEligible: authData.flags&flagBackupEligible != 0,
+		BackedUp:       authData.flags&flagBackedUp != 0,
+	}, nil
+}
+
+// VerifyAssertion checks the response to navigator.credentials.get against
+// the stored credential key. Comparing the returned sign count with the
+// stored one is left to the caller, who owns the counter.
+func (rp *RelyingParty) VerifyAssertion(
+	clientDataJSON, rawAuthData, sig []byte, challenge string, publicKey []byte, alg int,
+) (*Assertion, error) {
+	if err := rp.verifyClientData(clientDataJSON, clientDataGet, challenge); err != nil {
+		return nil, err
+	}
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if err := verifySignature(publicKey, alg, signed, sig); err != nil {
+		return nil, err
+	}
+	return &Assertion{
+		SignCount:    authData.signCount,
+		UserVerified: authData.flags&flagUserVerified != 0,
+		BackedUp:     authData.flags&flagBackedUp != 0,
+	}, nil
+}
+
+func (rp *RelyingParty) verifyClientData(clientDataJSON []byte, ceremony, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(clientDataJSON, &data); err != nil {
+		return fmt.Errorf("invalid client data: %w", err)
+	}
+	if data.Type != ceremony {
+		return fmt.Errorf("unexpected client data type %q", data.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(data.Challenge), []byte(challenge)) != 1 {
+		return errors.New("challenge does not match")
+	}
+	if !slices.Contains(rp.conf.Origins, data.Origin) {
+		return fmt.Errorf("unexpected origin %q", data.Origin)
+	}
+	if data.CrossOrigin {
+		return errors.New("cross-origin ceremonies are not allowed")
+	}
+	return nil
+}
+
+func (rp *RelyingParty) verifyAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	data, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(data.rpIDHash, rp.rpIDHash[:]) {
+		return nil, errors.New("credential is scoped to another relying party")
+	}
+	if data.flags&flagUserPresent == 0 {
+		return nil, errors.New("user was not present")
+	}
+	if rp.conf.UserVerification == UserVerificationRequired && data.flags&flagUserVerified == 0 {
+		return nil, errors.New("user was not verified")
+	}
+	return data, nil
+}
+
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, errors.New("authenticator data is too short")
+	}
+	data := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[37:]
+	if data.flags&flagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data is too short")
+		}
+		data.aaguid = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength > maxCredentialIDBytes || len(rest) < idLength {
+			return nil, errors.New("invalid credential id length")
+		}
+		data.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+		publicKey, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %w", err)
+		}
+		data.publicKey = publicKey
+		rest = rest[n:]
+	}
+	if data.flags&flagExtensionData != 0 {
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extension data: %w", err)
+		}
+		rest = rest[n:]
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("authenticator data has trailing bytes")
+	}
+	return data, nil
+}
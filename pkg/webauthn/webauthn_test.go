@@ -0,0 +1,178 @@
+package webauthn_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	"user-simple-crud/pkg/webauthn"
+	"user-simple-crud/pkg/webauthn/webauthntest"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://app.example.com"
+)
+
+func newRelyingParty(userVerification string) *webauthn.RelyingParty {
+	return webauthn.NewRelyingParty(&webauthn.Config{
+		RPID:             testRPID,
+		RPName:           "user-simple-crud",
+		Origins:          []string{testOrigin},
+		Timeout:          5 * time.Minute,
+		UserVerification: userVerification,
+	})
+}
+
+func newAuthenticator(t *testing.T) *webauthntest.Authenticator {
+	authenticator, err := webauthntest.NewAuthenticator(testRPID, testOrigin)
+	require.NoError(t, err)
+	return authenticator
+}
+
+func register(t *testing.T, rp *webauthn.RelyingParty, authenticator *webauthntest.Authenticator) *webauthn.Credential {
+	challenge, err := webauthn.NewChallenge()
+	require.NoError(t, err)
+	clientData, attestation := authenticator.Register(challenge)
+	credential, err := rp.VerifyRegistration(clientData, attestation, challenge)
+	require.NoError(t, err)
+	return credential
+}
+
+func TestWebAuthn_Registration(t *testing.T) {
+	rp := newRelyingParty(webauthn.UserVerificationPreferred)
+
+	t.Run("Registration Success", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+
+		credential := register(t, rp, authenticator)
+
+		assert.Equal(t, authenticator.CredentialID, credential.ID)
+		assert.Equal(t, webauthn.AlgES256, credential.Algorithm)
+		assert.NotEmpty(t, credential.PublicKey)
+		assert.True(t, credential.UserVerified)
+	})
+
+	t.Run("Registration Wrong Challenge", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		clientData, attestation := authenticator.Register("other-challenge")
+
+		_, err := rp.VerifyRegistration(clientData, attestation, "expected-challenge")
+
+		assert.EqualError(t, err, "challenge does not match")
+	})
+
+	t.Run("Registration Foreign Origin", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		authenticator.Origin = "https://app.example.com.evil.test"
+		clientData, attestation := authenticator.Register("challenge")
+
+		_, err := rp.VerifyRegistration(clientData, attestation, "challenge")
+
+		assert.ErrorContains(t, err, "unexpected origin")
+	})
+
+	t.Run("Registration Other Relying Party", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		authenticator.RPID = "evil.test"
+		clientData, attestation := authenticator.Register("challenge")
+
+		_, err := rp.VerifyRegistration(clientData, attestation, "challenge")
+
+		assert.EqualError(t, err, "credential is scoped to another relying party")
+	})
+
+	t.Run("Registration User Verification Required", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		authenticator.UserVerified = false
+		clientData, attestation := authenticator.Register("challenge")
+
+		_, err := newRelyingParty(webauthn.UserVerificationRequired).
+			VerifyRegistration(clientData, attestation, "challenge")
+
+		assert.EqualError(t, err, "user was not verified")
+	})
+
+	t.Run("Registration Truncated Attestation", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		clientData, attestation := authenticator.Register("challenge")
+
+		_, err := rp.VerifyRegistration(clientData, attestation[:len(attestation)-10], "challenge")
+
+		assert.ErrorContains(t, err, "invalid attestation object")
+	})
+}
+
+func TestWebAuthn_Assertion(t *testing.T) {
+	rp := newRelyingParty(webauthn.UserVerificationPreferred)
+
+	t.Run("Assertion Success", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		credential := register(t, rp, authenticator)
+		clientData, authData, sig, err := authenticator.Assert("challenge")
+		require.NoError(t, err)
+
+		assertion, err := rp.VerifyAssertion(clientData, authData, sig, "challenge", credential.PublicKey, credential.Algorithm)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), assertion.SignCount)
+		assert.True(t, assertion.UserVerified)
+	})
+
+	t.Run("Assertion Registration Client Data", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		credential := register(t, rp, authenticator)
+		clientData, _ := authenticator.Register("challenge")
+		_, authData, sig, err := authenticator.Assert("challenge")
+		require.NoError(t, err)
+
+		_, err = rp.VerifyAssertion(clientData, authData, sig, "challenge", credential.PublicKey, credential.Algorithm)
+
+		assert.EqualError(t, err, `unexpected client data type "webauthn.create"`)
+	})
+
+	t.Run("Assertion Other Key", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		credential := register(t, rp, newAuthenticator(t))
+		clientData, authData, sig, err := authenticator.Assert("challenge")
+		require.NoError(t, err)
+
+		_, err = rp.VerifyAssertion(clientData, authData, sig, "challenge", credential.PublicKey, credential.Algorithm)
+
+		assert.EqualError(t, err, "invalid signature")
+	})
+
+	t.Run("Assertion Tampered Authenticator Data", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		credential := register(t, rp, authenticator)
+		clientData, authData, sig, err := authenticator.Assert("challenge")
+		require.NoError(t, err)
+		authData[36] ^= 0xff
+
+		_, err = rp.VerifyAssertion(clientData, authData, sig, "challenge", credential.PublicKey, credential.Algorithm)
+
+		assert.EqualError(t, err, "invalid signature")
+	})
+
+	t.Run("Assertion Algorithm Mismatch", func(t *testing.T) {
+		authenticator := newAuthenticator(t)
+		credential := register(t, rp, authenticator)
+		clientData, authData, sig, err := authenticator.Assert("challenge")
+		require.NoError(t, err)
+
+		_, err = rp.VerifyAssertion(clientData, authData, sig, "challenge", credential.PublicKey, webauthn.AlgEdDSA)
+
+		assert.ErrorContains(t, err, "does not match algorithm")
+	})
+}
+
+func TestWebAuthn_ClientDataChallenge(t *testing.T) {
+	authenticator := newAuthenticator(t)
+	clientData, _, _, err := authenticator.Assert("3q2-7wYl")
+	require.NoError(t, err)
+
+	challenge, err := webauthn.ClientDataChallenge(clientData)
+
+	require.NoError(t, err)
+	assert.Equal(t, "3q2-7wYl", challenge)
+}
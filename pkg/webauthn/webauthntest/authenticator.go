@@ -0,0 +1,172 @@
+// Package webauthntest provides a software authenticator for exercising
+// WebAuthn ceremonies in tests without a browser or security key.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+)
+
+// Authenticator holds one ES256 credential. Tests may change any field
+// between ceremonies, e.g. Origin to act as a phishing site or SignCount to
+// act as a cloned authenticator.
+type Authenticator struct {
+	RPID         string
+	Origin       string
+	CredentialID []byte
+	UserHandle   []byte
+	// SignCount is incremented before every assertion; leave it at zero
+	// together with Counterless to mimic authenticators without a counter
+	SignCount    uint32
+	Counterless  bool
+	UserVerified bool
+	Key          *ecdsa.PrivateKey
+}
+
+// NewAuthenticator returns an authenticator with a fresh key and credential
+// ID that verifies the user.
+func NewAuthenticator(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Authenticator{RPID: rpID, Origin: origin, CredentialID: id, UserVerified: true, Key: key}, nil
+}
+
+// EncodedID returns the credential ID as sent in id and rawId.
+func (a *Authenticator) EncodedID() string {
+	return base64.RawURLEncoding.EncodeToString(a.CredentialID)
+}
+
+// Register answers navigator.credentials.create for challenge with a "none"
+// attestation and returns clientDataJSON and the attestation object.
+func (a *Authenticator) Register(challenge string) ([]byte, []byte) {
+	clientData := a.clientData("webauthn.create", challenge)
+	authData := a.authenticatorData(flagAttestedData)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, a.coseKey()...)
+
+	var object []byte
+	object = appendHead(object, majorMap, 3)
+	object = appendText(object, "fmt")
+	object = appendText(object, "none")
+	object = appendText(object, "attStmt")
+	object = appendHead(object, majorMap, 0)
+	object = appendText(object, "authData")
+	object = appendBytes(object, authData)
+	return clientData, object
+}
+
+// Assert answers navigator.credentials.get for challenge and returns
+// clientDataJSON, the authenticator data and the signature.
+func (a *Authenticator) Assert(challenge string) ([]byte, []byte, []byte, error) {
+	if !a.Counterless {
+		a.SignCount++
+	}
+	clientData := a.clientData("webauthn.get", challenge)
+	authData := a.authenticatorData(0)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.Key, digest[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return clientData, authData, sig, nil
+}
+
+func (a *Authenticator) clientData(ceremony, challenge string) []byte {
+	data, _ := json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	return data
+}
+
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+func (a *Authenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	flags |= flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.SignCount)
+}
+
+// coseKey encodes the public key as an EC2 COSE_Key for ES256.
+func (a *Authenticator) coseKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.Key.X.FillBytes(x)
+	a.Key.Y.FillBytes(y)
+	var key []byte
+	key = appendHead(key, majorMap, 5)
+	key = appendInt(key, 1) // kty: EC2
+	key = appendInt(key, 2)
+	key = appendInt(key, 3) // alg: ES256
+	key = appendInt(key, -7)
+	key = appendInt(key, -1) // crv: P-256
+	key = appendInt(key, 1)
+	key = appendInt(key, -2) // x
+	key = appendBytes(key, x)
+	key = appendInt(key, -3) // y
+	key = appendBytes(key, y)
+	return key
+}
+
+// CBOR major types used by the encoder below.
+const (
+	majorUint   = 0
+	majorNegInt = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorMap    = 5
+)
+
+func appendHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= 0xff:
+		return append(b, major|24, byte(arg))
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+}
+
+func appendInt(b []byte, v int64) []byte {
+	if v < 0 {
+		return appendHead(b, majorNegInt, uint64(-1-v))
+	}
+	return appendHead(b, majorUint, uint64(v))
+}
+
+func appendBytes(b, v []byte) []byte {
+	return append(appendHead(b, majorBytes, uint64(len(v))), v...)
+}
+
+func appendText(b []byte, v string) []byte {
+	return append(appendHead(b, majorText, uint64(len(v))), v...)
+}
@@ -0,0 +1,132 @@
+package xvalidator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Rules a password can break, reported in PasswordViolation.Rule.
+const (
+	PasswordRuleMinLength   = "min_length"
+	PasswordRuleUppercase   = "uppercase"
+	PasswordRuleLowercase   = "lowercase"
+	PasswordRuleDigit       = "digit"
+	PasswordRuleSymbol      = "symbol"
+	PasswordRuleMaxRepeated = "max_repeated"
+	PasswordRuleUserInfo    = "user_info"
+	PasswordRuleReused      = "reused"
+)
+
+// minIdentityLength keeps short usernames such as "al" from rejecting every
+// password that happens to contain them.
+const minIdentityLength = 3
+
+// PasswordPolicy lists the rules a new password must follow.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeated is the longest run of one character allowed, 0 allows any
+	MaxRepeated int
+	// RejectUserInfo refuses passwords containing the username or the local part of the email
+	RejectUserInfo bool
+}
+
+// PasswordViolation is one rule a password broke.
+type PasswordViolation struct {
+	Rule    string `json:"rule" example:"min_length"`
+	Message string `json:"message" example:"must be at least 12 characters long"`
+}
+
+// DefaultPasswordPolicy is the policy used until SetPasswordPolicy is called.
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+}
+
+// Check returns every rule password breaks, or nil if it follows the policy.
+// identities are the username and email of the account the password is for.
+func (p *PasswordPolicy) Check(password string, identities ...string) []PasswordViolation {
+	var violations []PasswordViolation
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMinLength,
+			Message: fmt.Sprintf("must be at least %d characters long", p.MinLength),
+		})
+	}
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, PasswordViolation{Rule: PasswordRuleUppercase, Message: "must contain an uppercase letter"})
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, PasswordViolation{Rule: PasswordRuleLowercase, Message: "must contain a lowercase letter"})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, PasswordViolation{Rule: PasswordRuleDigit, Message: "must contain a digit"})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, PasswordViolation{Rule: PasswordRuleSymbol, Message: "must contain a symbol"})
+	}
+	if p.MaxRepeated > 0 && longestRun(password) > p.MaxRepeated {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleMaxRepeated,
+			Message: fmt.Sprintf("must not repeat a character more than %d times in a row", p.MaxRepeated),
+		})
+	}
+	if p.RejectUserInfo && containsIdentity(password, identities) {
+		violations = append(violations, PasswordViolation{
+			Rule:    PasswordRuleUserInfo,
+			Message: "must not contain the username or email",
+		})
+	}
+	return violations
+}
+
+func longestRun(s string) int {
+	var longest, run int
+	var prev rune
+	for i, r := range []rune(s) {
+		if i > 0 && r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		prev = r
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+func containsIdentity(password string, identities []string) bool {
+	password = strings.ToLower(password)
+	for _, identity := range identities {
+		identity = strings.ToLower(identity)
+		if local, _, ok := strings.Cut(identity, "@"); ok {
+			identity = local
+		}
+		if len([]rune(identity)) >= minIdentityLength && strings.Contains(password, identity) {
+			return true
+		}
+	}
+	return false
+}
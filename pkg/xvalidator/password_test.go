@@ -0,0 +1,62 @@
+package xvalidator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := &PasswordPolicy{
+		MinLength:      8,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSymbol:  true,
+		MaxRepeated:    3,
+		RejectUserInfo: true,
+	}
+	rules := func(violations []PasswordViolation) []string {
+		var names []string
+		for _, violation := range violations {
+			names = append(names, violation.Rule)
+		}
+		return names
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.Empty(t, policy.Check("SecurePass123!", "john_doe", "john@example.com"))
+	})
+
+	t.Run("Character Classes", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleLowercase}, rules(policy.Check("SECUREPASS123!")))
+		assert.Equal(t, []string{PasswordRuleUppercase, PasswordRuleDigit, PasswordRuleSymbol}, rules(policy.Check("securepass")))
+	})
+
+	t.Run("Length Counts Characters", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleMinLength}, rules(policy.Check("Pä1!ßé")))
+	})
+
+	t.Run("Repeated Characters", func(t *testing.T) {
+		assert.Empty(t, policy.Check("Seeecure1!"))
+		assert.Equal(t, []string{PasswordRuleMaxRepeated}, rules(policy.Check("Seeeecure1!")))
+	})
+
+	t.Run("User Info", func(t *testing.T) {
+		assert.Equal(t, []string{PasswordRuleUserInfo}, rules(policy.Check("My-John_Doe-1", "john_doe", "")))
+		assert.Equal(t, []string{PasswordRuleUserInfo}, rules(policy.Check("Johnny-Rules1", "", "johnny@example.com")))
+		// Identities shorter than three characters are ignored
+		assert.Empty(t, policy.Check("Alpha-Bravo1", "al", ""))
+	})
+}
+
+func TestPasswordValidationMessage(t *testing.T) {
+	v, _ := NewValidator()
+	v.SetPasswordPolicy(&PasswordPolicy{MinLength: 12, RequireDigit: true})
+
+	type request struct {
+		Password string `validate:"password" name:"password"`
+	}
+	errs := v.Struct(request{Password: "short"})
+	assert.Equal(t, "password is not a valid password, it must be at least 12 characters long, must contain a digit", errs["password"])
+	assert.Nil(t, v.Struct(request{Password: "long enough password 1"}))
+}
@@ -5,13 +5,15 @@ import (
 	"github.com/go-playground/validator/v10"
 	"log/slog"
 	"reflect"
-	"regexp"
+	"strings"
 	"time"
 )
 
 // Validator is a struct that contains a pointer to a validator.Validate instance.
+// passwordPolicy backs the "password" rule.
 type Validator struct {
-	validate *validator.Validate
+	validate       *validator.Validate
+	passwordPolicy *PasswordPolicy
 }
 
 // NewValidator is a function that initializes a new Validator instance.
@@ -22,19 +24,10 @@ func NewValidator() (*Validator, error) {
 	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
 		return field.Tag.Get("name")
 	})
+	v := &Validator{validate: validate, passwordPolicy: DefaultPasswordPolicy()}
 
 	validate.RegisterValidation("password", func(fl validator.FieldLevel) bool {
-		password := fl.Field().String()
-
-		// Regular expression to check for at least one uppercase letter
-		hasUpperCase := regexp.MustCompile(`[A-Z]`).MatchString(password)
-		// Regular expression to check for at least one digit
-		hasDigit := regexp.MustCompile(`\d`).MatchString(password)
-		// Regular expression to check for at least one symbol (non-alphanumeric)
-		hasSymbol := regexp.MustCompile(`[\W_]`).MatchString(password)
-
-		// Validate the password
-		return hasUpperCase && hasDigit && hasSymbol
+		return len(v.passwordPolicy.Check(fl.Field().String())) == 0
 	})
 
 	validate.RegisterValidation("dateLocal", func(fl validator.FieldLevel) bool {
@@ -46,7 +39,18 @@ func NewValidator() (*Validator, error) {
 	})
 
 	slog.Info("validator initialized")
-	return &Validator{validate: validate}, nil
+	return v, nil
+}
+
+// SetPasswordPolicy replaces the policy checked by the "password" rule. The
+// validator is built before config is loaded, so the policy is set afterwards.
+func (v *Validator) SetPasswordPolicy(policy *PasswordPolicy) {
+	v.passwordPolicy = policy
+}
+
+// PasswordPolicy returns the policy checked by the "password" rule.
+func (v *Validator) PasswordPolicy() *PasswordPolicy {
+	return v.passwordPolicy
 }
 
 // Struct is a method of the Validator struct that validates a struct.
@@ -102,7 +106,11 @@ func (v *Validator) formatValidationError(err error) map[string]string {
 		case "phone":
 			errors[err.Field()] = fmt.Sprintf("%s invalid phone number", err.Field())
 		case "password":
-			errors[err.Field()] = fmt.Sprintf("%s is not a valid password, at least 8 characters, 1 uppercase, 1 lowercase, 1 number, and 1 special character", err.Field())
+			var reasons []string
+			for _, violation := range v.passwordPolicy.Check(fmt.Sprint(err.Value())) {
+				reasons = append(reasons, violation.Message)
+			}
+			errors[err.Field()] = fmt.Sprintf("%s is not a valid password, it %s", err.Field(), strings.Join(reasons, ", "))
 		case "dateLocal":
 			errors[err.Field()] = fmt.Sprintf("%s is not a valid date, use YYYY-MM-DD", err.Field())
 		default: